@@ -214,12 +214,71 @@ type AddonMetadataSpec struct {
 
 // AddonMetadataStatus defines the observed state of AddonMetadata
 type AddonMetadataStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// +optional
+	// The generation of the AddonMetadata which was last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// Conditions describing the outcome of the last validation.
+	// Known condition types are 'Valid', 'BundlesResolved' and 'OCMQuotaVerified'.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// +optional
+	// Bundles of the addon operator which were resolved from the index image.
+	Bundles []BundleReference `json:"bundles,omitempty"`
+
+	// +optional
+	// The imageset version which was last validated.
+	LastValidatedImageSetVersion string `json:"lastValidatedImageSetVersion,omitempty"`
+}
+
+const (
+	// AddonMetadataValid is 'True' if all validators succeeded against the
+	// addon metadata and its bundles.
+	AddonMetadataValid = "Valid"
+	// AddonMetadataBundlesResolved is 'True' if the bundles of the addon
+	// operator could be extracted from the index image.
+	AddonMetadataBundlesResolved = "BundlesResolved"
+	// AddonMetadataOCMQuotaVerified is 'True' if a QuotaRule exists in OCM
+	// for the ocmQuotaName of the addon.
+	AddonMetadataOCMQuotaVerified = "OCMQuotaVerified"
+)
+
+// Reasons of the AddonMetadata conditions.
+const (
+	AddonMetadataReasonImageSetUnresolved  = "ImageSetUnresolved"
+	AddonMetadataReasonExtractionFailed    = "ExtractionFailed"
+	AddonMetadataReasonNoBundlesFound      = "NoBundlesFound"
+	AddonMetadataReasonBundlesFound        = "BundlesFound"
+	AddonMetadataReasonBundlesNotResolved  = "BundlesNotResolved"
+	AddonMetadataReasonValidationSucceeded = "ValidationSucceeded"
+	AddonMetadataReasonValidationFailed    = "ValidationFailed"
+	AddonMetadataReasonValidationErrored   = "ValidationErrored"
+	AddonMetadataReasonQuotaRuleFound      = "QuotaRuleFound"
+	AddonMetadataReasonQuotaRuleMissing    = "QuotaRuleMissing"
+	AddonMetadataReasonQuotaNotChecked     = "QuotaNotChecked"
+)
+
+// BundleReference identifies a bundle of the addon operator.
+type BundleReference struct {
+	// Name of the bundle, usually the name of its ClusterServiceVersion.
+	Name string `json:"name"`
+
+	// +optional
+	// Version of the bundle.
+	Version string `json:"version,omitempty"`
+
+	// +optional
+	// Image of the bundle.
+	Image string `json:"image,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Valid",type=string,JSONPath=`.status.conditions[?(@.type=="Valid")].status`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.lastValidatedImageSetVersion`
 // AddonMetadata is the Schema for the AddonMetadata API
 type AddonMetadata struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"encoding/json"
 
+	mtsrev1 "github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1"
 	ocmv1 "github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1"
 	"k8s.io/apimachinery/pkg/util/yaml"
 )
@@ -19,6 +20,8 @@ func (a *AddonMetadata) ToJSON() ([]byte, error) {
 
 // CombineWithImageSet - Returns a new AddonMetadataSpec combined with the
 // related imageSet fields. Using deep copy to avoid overriding the existing CR.
+// Fields set in the imageSet, including config, pullSecretName and
+// additionalCatalogSources, take precedence over those of the metadata.
 func (a *AddonMetadataSpec) CombineWithImageSet(imageSet *AddonImageSetSpec) (*AddonMetadataSpec, error) {
 	combined := a.DeepCopy()
 
@@ -49,5 +52,19 @@ func (a *AddonMetadataSpec) CombineWithImageSet(imageSet *AddonImageSetSpec) (*A
 		combined.SubOperators = &subOperators
 	}
 
+	if imageSet.Config != nil {
+		combined.Config = imageSet.Config.DeepCopy()
+	}
+
+	if imageSet.PullSecretName != "" {
+		combined.PullSecretName = imageSet.PullSecretName
+	}
+
+	if imageSet.AdditionalCatalogSources != nil {
+		sources := make([]mtsrev1.AdditionalCatalogSource, len(*imageSet.AdditionalCatalogSources))
+		copy(sources, *imageSet.AdditionalCatalogSources)
+		combined.AdditionalCatalogSources = &sources
+	}
+
 	return combined, nil
 }
@@ -0,0 +1,112 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	mtsrev1 "github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombineWithImageSet(t *testing.T) {
+	t.Parallel()
+
+	metaConfig := &mtsrev1.Config{
+		Env:     &[]mtsrev1.EnvItem{{Name: "FROM", Value: "metadata"}},
+		Secrets: &[]mtsrev1.Secret{},
+	}
+	imageSetConfig := &mtsrev1.Config{
+		Env:     &[]mtsrev1.EnvItem{{Name: "FROM", Value: "imageset"}},
+		Secrets: &[]mtsrev1.Secret{},
+	}
+	metaSources := &[]mtsrev1.AdditionalCatalogSource{
+		{Name: "metadata-catalog", Image: "quay.io/osd-addons/metadata-catalog:latest"},
+	}
+	imageSetSources := &[]mtsrev1.AdditionalCatalogSource{
+		{Name: "imageset-catalog", Image: "quay.io/osd-addons/imageset-catalog:latest"},
+	}
+
+	for name, tc := range map[string]struct {
+		Meta            AddonMetadataSpec
+		ImageSet        AddonImageSetSpec
+		ExpectedConfig  *mtsrev1.Config
+		ExpectedSecret  string
+		ExpectedSources *[]mtsrev1.AdditionalCatalogSource
+	}{
+		"set in imageset only": {
+			ImageSet: AddonImageSetSpec{
+				Config:                   imageSetConfig,
+				PullSecretName:           "imageset-secret",
+				AdditionalCatalogSources: imageSetSources,
+			},
+			ExpectedConfig:  imageSetConfig,
+			ExpectedSecret:  "imageset-secret",
+			ExpectedSources: imageSetSources,
+		},
+		"set in both": {
+			Meta: AddonMetadataSpec{
+				Config:                   metaConfig,
+				PullSecretName:           "metadata-secret",
+				AdditionalCatalogSources: metaSources,
+			},
+			ImageSet: AddonImageSetSpec{
+				Config:                   imageSetConfig,
+				PullSecretName:           "imageset-secret",
+				AdditionalCatalogSources: imageSetSources,
+			},
+			ExpectedConfig:  imageSetConfig,
+			ExpectedSecret:  "imageset-secret",
+			ExpectedSources: imageSetSources,
+		},
+		"unset in imageset": {
+			Meta: AddonMetadataSpec{
+				Config:                   metaConfig,
+				PullSecretName:           "metadata-secret",
+				AdditionalCatalogSources: metaSources,
+			},
+			ExpectedConfig:  metaConfig,
+			ExpectedSecret:  "metadata-secret",
+			ExpectedSources: metaSources,
+		},
+		"empty sources in imageset": {
+			Meta: AddonMetadataSpec{
+				AdditionalCatalogSources: metaSources,
+			},
+			ImageSet: AddonImageSetSpec{
+				AdditionalCatalogSources: &[]mtsrev1.AdditionalCatalogSource{},
+			},
+			ExpectedSources: &[]mtsrev1.AdditionalCatalogSource{},
+		},
+		"unset in both": {},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.ImageSet.Name = "reference-addon.v0.1.0"
+			tc.ImageSet.IndexImage = "quay.io/osd-addons/reference-addon-index:0.1.0"
+
+			combined, err := tc.Meta.CombineWithImageSet(&tc.ImageSet)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.ExpectedConfig, combined.Config)
+			assert.Equal(t, tc.ExpectedSecret, combined.PullSecretName)
+			assert.Equal(t, tc.ExpectedSources, combined.AdditionalCatalogSources)
+
+			require.NotNil(t, combined.IndexImage)
+			assert.Equal(t, tc.ImageSet.IndexImage, *combined.IndexImage)
+			require.NotNil(t, combined.ImageSetVersion)
+			assert.Equal(t, "0.1.0", *combined.ImageSetVersion)
+
+			if combined.Config != nil {
+				assert.NotSame(t, tc.ImageSet.Config, combined.Config, "config must be copied")
+			}
+
+			if combined.AdditionalCatalogSources != nil {
+				assert.NotSame(t, tc.ImageSet.AdditionalCatalogSources, combined.AdditionalCatalogSources,
+					"additional catalog sources must be copied")
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ package v1alpha1
 import (
 	mtsrev1 "github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1"
 	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -172,7 +173,7 @@ func (in *AddonMetadata) DeepCopyInto(out *AddonMetadata) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonMetadata.
@@ -417,6 +418,18 @@ func (in *AddonMetadataSpec) DeepCopy() *AddonMetadataSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonMetadataStatus) DeepCopyInto(out *AddonMetadataStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Bundles != nil {
+		in, out := &in.Bundles, &out.Bundles
+		*out = make([]BundleReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonMetadataStatus.
@@ -429,6 +442,21 @@ func (in *AddonMetadataStatus) DeepCopy() *AddonMetadataStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleReference) DeepCopyInto(out *BundleReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleReference.
+func (in *BundleReference) DeepCopy() *BundleReference {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Channel) DeepCopyInto(out *Channel) {
 	*out = *in
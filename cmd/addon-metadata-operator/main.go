@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	stdlog "log"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/stdr"
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/internal/controller"
+	"github.com/mt-sre/addon-metadata-operator/internal/webhook"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/register"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+const (
+	ocmTokenEnvVar        = "OCM_TOKEN"
+	ocmClientIDEnvVar     = "OCM_CLIENT_ID"
+	ocmClientSecretEnvVar = "OCM_CLIENT_SECRET"
+)
+
+type options struct {
+	MetricsAddr      string
+	ProbeAddr        string
+	LeaderElection   bool
+	OCMURL           string
+	RetryInterval    time.Duration
+	EnableWebhook    bool
+	WebhookPort      int
+	WebhookCertDir   string
+	DenyAt           string
+	ValidatorTimeout time.Duration
+}
+
+func main() {
+	opts := options{
+		MetricsAddr:   ":8080",
+		ProbeAddr:     ":8081",
+		OCMURL:        "https://api.stage.openshift.com",
+		RetryInterval: controller.DefaultRetryInterval,
+		WebhookPort:   9443,
+		DenyAt:        validator.SeverityError.String(),
+		// bounds reconciliations blocked by unresponsive services
+		ValidatorTimeout: 5 * time.Minute,
+	}
+
+	flags := pflag.CommandLine
+
+	flags.StringVar(&opts.MetricsAddr, "metrics-bind-address", opts.MetricsAddr, "address the metrics endpoint binds to")
+	flags.StringVar(&opts.ProbeAddr, "health-probe-bind-address", opts.ProbeAddr, "address the health probe endpoint binds to")
+	flags.BoolVar(&opts.LeaderElection, "leader-elect", opts.LeaderElection, "enable leader election for the controller manager")
+	flags.StringVar(&opts.OCMURL, "ocm-url", opts.OCMURL, "URL of the OCM API used to verify quota rules")
+	flags.DurationVar(&opts.RetryInterval, "retry-interval", opts.RetryInterval, "time after which incomplete validations are retried")
+	flags.BoolVar(&opts.EnableWebhook, "enable-webhook", opts.EnableWebhook, "serve the validating webhook for AddonMetadata objects")
+	flags.IntVar(&opts.WebhookPort, "webhook-port", opts.WebhookPort, "port the webhook server listens on")
+	flags.StringVar(&opts.WebhookCertDir, "webhook-cert-dir", opts.WebhookCertDir, "directory holding the 'tls.crt' and 'tls.key' files of the webhook server")
+	flags.StringVar(&opts.DenyAt, "deny-at", opts.DenyAt, "minimum severity (info, warning, error) of failures which deny AddonMetadata objects; other failures are returned as warnings")
+	flags.DurationVar(&opts.ValidatorTimeout, "validator-timeout", opts.ValidatorTimeout, "time after which a running validator is cancelled and reported as timed out; zero means no timeout")
+
+	pflag.Parse()
+
+	log := stdr.New(stdlog.New(os.Stderr, "", stdlog.LstdFlags))
+	ctrl.SetLogger(log)
+
+	if err := run(log, opts); err != nil {
+		log.Error(err, "running manager")
+
+		os.Exit(1)
+	}
+}
+
+func run(log logr.Logger, opts options) error {
+	denyAt, err := validator.ParseSeverity(opts.DenyAt)
+	if err != nil {
+		return fmt.Errorf("parsing '--deny-at': %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+
+	if err := addonsv1alpha1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("adding addons types to scheme: %w", err)
+	}
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                metricsserver.Options{BindAddress: opts.MetricsAddr},
+		HealthProbeBindAddress: opts.ProbeAddr,
+		LeaderElection:         opts.LeaderElection,
+		LeaderElectionID:       "addon-metadata-operator.addonsflow.redhat.openshift.io",
+		WebhookServer: ctrlwebhook.NewServer(ctrlwebhook.Options{
+			Port:    opts.WebhookPort,
+			CertDir: opts.WebhookCertDir,
+		}),
+	})
+	if err != nil {
+		return fmt.Errorf("initializing manager: %w", err)
+	}
+
+	ocm, err := newOCMClient(opts.OCMURL)
+	if err != nil {
+		return fmt.Errorf("initializing ocm client: %w", err)
+	}
+
+	validatorMetrics := validator.NewMetricsMiddleware()
+
+	if err := metrics.Registry.Register(validatorMetrics); err != nil {
+		return fmt.Errorf("registering validator metrics: %w", err)
+	}
+
+	runner, err := validator.NewRunner(
+		validator.WithLogger{Logger: log.WithName("validator")},
+		validator.WithMiddleware{validator.NewRetryMiddleware()},
+		validator.WithInstrumentation{validatorMetrics},
+		validator.WithOCMClient{OCMClient: ocm},
+		validator.WithTimeout(opts.ValidatorTimeout),
+	)
+	if err != nil {
+		return fmt.Errorf("initializing validators: %w", err)
+	}
+
+	reconciler, err := controller.NewAddonMetadataReconciler(mgr.GetClient(),
+		controller.WithLogger{Logger: log.WithName("addonmetadata")},
+		controller.WithRetryInterval(opts.RetryInterval),
+		controller.WithRunner{Runner: runner},
+		controller.WithSkipQuotaCheck(isDisconnected(ocm)),
+	)
+	if err != nil {
+		return fmt.Errorf("initializing reconciler: %w", err)
+	}
+
+	if err := reconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("setting up reconciler: %w", err)
+	}
+
+	if opts.EnableWebhook {
+		// Admission requests can't wait for retries or the timeout
+		// of the controller so the webhook runs its own validators.
+		webhookRunner, err := validator.NewRunner(
+			validator.WithLogger{Logger: log.WithName("webhook-validator")},
+			validator.WithInstrumentation{validatorMetrics},
+			validator.WithTimeout(webhook.DefaultValidatorTimeout),
+		)
+		if err != nil {
+			return fmt.Errorf("initializing webhook validators: %w", err)
+		}
+
+		wh, err := webhook.NewAddonMetadataWebhook(mgr.GetClient(),
+			webhook.WithDenyAt(denyAt),
+			webhook.WithLogger{Logger: log.WithName("webhook")},
+			webhook.WithRunner{Runner: webhookRunner},
+		)
+		if err != nil {
+			return fmt.Errorf("initializing webhook: %w", err)
+		}
+
+		if err := wh.SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("setting up webhook: %w", err)
+		}
+	}
+
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		return fmt.Errorf("adding health check: %w", err)
+	}
+
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		return fmt.Errorf("adding ready check: %w", err)
+	}
+
+	return mgr.Start(ctrl.SetupSignalHandler())
+}
+
+// newOCMClient returns a client connected to the OCM API at 'url' using
+// the credentials from the environment. Without credentials a disconnected
+// client is returned and the quota of addons is not verified.
+func newOCMClient(url string) (validator.OCMClient, error) {
+	token := os.Getenv(ocmTokenEnvVar)
+	clientID := os.Getenv(ocmClientIDEnvVar)
+	clientSecret := os.Getenv(ocmClientSecretEnvVar)
+
+	if token == "" && (clientID == "" || clientSecret == "") {
+		return validator.NewDisconnectedOCMClient(), nil
+	}
+
+	return validator.NewOCMClient(
+		validator.WithConnectOptions{
+			validator.WithAPIURL(url),
+			validator.WithAccessToken(token),
+			validator.WithClientID(clientID),
+			validator.WithClientSecret(clientSecret),
+		},
+	)
+}
+
+// isDisconnected reports whether 'ocm' can't reach OCM
+// because no credentials are configured.
+func isDisconnected(ocm validator.OCMClient) bool {
+	_, ok := ocm.(validator.DisconnectedOCMClient)
+
+	return ok
+}
@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func clearExamples() string {
+	return strings.Join([]string{
+		"  # Remove all entries from the cache.",
+		"  mtcli cache clear",
+	}, "\n")
+}
+
+func clearCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:     "clear",
+		Short:   "Remove all entries from the cache.",
+		Example: clearExamples(),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cache, err := opts.OpenCache()
+			if err != nil {
+				return fmt.Errorf("opening cache: %w", err)
+			}
+
+			removed, err := cache.Clear()
+			if err != nil {
+				return fmt.Errorf("clearing cache: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed %d entries from %s\n", removed, cache.Dir())
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func Cmd() *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "cache [command]",
+		Short: "Manage the on-disk cache of extracted bundles, index images and validator results.",
+	}
+
+	opts.AddCacheDirFlag(cmd.PersistentFlags())
+
+	cmd.AddCommand(listCmd(opts))
+	cmd.AddCommand(pruneCmd(opts))
+	cmd.AddCommand(clearCmd(opts))
+
+	return cmd
+}
+
+type options struct {
+	CacheDir string
+	MaxAge   time.Duration
+}
+
+func (o *options) AddCacheDirFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.CacheDir,
+		"cache-dir",
+		o.CacheDir,
+		"Cache directory. Defaults to '$XDG_CACHE_HOME/mtcli'.",
+	)
+}
+
+func (o *options) AddMaxAgeFlag(flags *pflag.FlagSet) {
+	flags.DurationVar(
+		&o.MaxAge,
+		"max-age",
+		o.MaxAge,
+		"Also remove entries which were cached longer ago than the given duration.",
+	)
+}
+
+func (o *options) OpenCache() (*extractor.DiskCache, error) {
+	return extractor.NewDiskCache(extractor.WithCacheDir(o.CacheDir))
+}
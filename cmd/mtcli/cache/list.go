@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func listExamples() string {
+	return strings.Join([]string{
+		"  # List all cached bundles, index images and validator results.",
+		"  mtcli cache list",
+	}, "\n")
+}
+
+func listCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "List all cached bundles, index images and validator results.",
+		Example: listExamples(),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cache, err := opts.OpenCache()
+			if err != nil {
+				return fmt.Errorf("opening cache: %w", err)
+			}
+
+			entries, err := cache.List()
+			if err != nil {
+				return fmt.Errorf("listing cache entries: %w", err)
+			}
+
+			table, err := cli.NewTable(
+				cli.WithHeaders{"KIND", "KEY", "SIZE", "CACHED", "EXPIRES"},
+			)
+			if err != nil {
+				return fmt.Errorf("initializing table: %w", err)
+			}
+
+			now := time.Now()
+
+			for _, e := range entries {
+				expires := "never"
+
+				if e.Expired(now) {
+					expires = "expired"
+				} else if !e.ExpiresAt.IsZero() {
+					expires = e.ExpiresAt.Local().Format(time.RFC3339)
+				}
+
+				table.WriteRow(cli.TableRow{
+					cli.Field{Value: e.Kind},
+					cli.Field{Value: e.Key},
+					cli.Field{Value: fmt.Sprintf("%d", e.Size)},
+					cli.Field{Value: e.CreatedAt.Local().Format(time.RFC3339)},
+					cli.Field{Value: expires},
+				})
+			}
+
+			out := cmd.OutOrStdout()
+
+			fmt.Fprintf(out, "Cache directory: %s\n", cache.Dir())
+			fmt.Fprintln(out, table.String())
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func pruneExamples() string {
+	return strings.Join([]string{
+		"  # Remove expired index image listings, validator results and unreadable entries.",
+		"  mtcli cache prune",
+		"  # Additionally remove all entries cached more than 30 days ago.",
+		"  mtcli cache prune --max-age 720h",
+	}, "\n")
+}
+
+func pruneCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "prune",
+		Short:   "Remove expired entries from the cache.",
+		Example: pruneExamples(),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cache, err := opts.OpenCache()
+			if err != nil {
+				return fmt.Errorf("opening cache: %w", err)
+			}
+
+			removed, err := cache.Prune(opts.MaxAge)
+			if err != nil {
+				return fmt.Errorf("pruning cache: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed %d entries from %s\n", removed, cache.Dir())
+
+			return nil
+		},
+	}
+
+	opts.AddMaxAgeFlag(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,167 @@
+package diff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/diff"
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+const long = "Report the semantic changes between two imageset versions of an addon."
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Compare two imageset versions of a staging addon.",
+		"  mtcli diff --from 1.2.0 --to 1.3.0 <path/to/addon_dir>",
+		"  # Compare a production imageset with the latest one and print the changes as JSON.",
+		"  mtcli diff --env production --from 1.2.0 --to latest --output json <path/to/addon_dir>",
+		"  # Only compare the addon metadata without extracting any bundles.",
+		"  mtcli diff --from 1.2.0 --to 1.3.0 --skip-bundles <path/to/addon_dir>",
+	}, "\n")
+}
+
+func Cmd() *cobra.Command {
+	opts := &options{
+		Env:    "stage",
+		Output: outputText,
+	}
+
+	cmd := &cobra.Command{
+		Use:           "diff",
+		Short:         "Diff two imageset versions of an addon.",
+		Long:          long,
+		Example:       examples(),
+		Args:          cobra.ExactArgs(1),
+		RunE:          run(opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	flags := cmd.Flags()
+
+	opts.AddEnvFlag(flags)
+	opts.AddFromFlag(flags)
+	opts.AddToFlag(flags)
+	opts.AddOutputFlag(flags)
+	opts.AddSkipBundlesFlag(flags)
+	opts.AddCacheFlags(flags)
+
+	return cmd
+}
+
+func run(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := opts.VerifyFlags(); err != nil {
+			return fmt.Errorf("verifying flags: %w", err)
+		}
+
+		addonDir, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("parsing addon dir %q: %w", args[0], err)
+		}
+
+		from, err := loadMeta(addonDir, opts.Env, opts.From)
+		if err != nil {
+			return err
+		}
+
+		to, err := loadMeta(addonDir, opts.Env, opts.To)
+		if err != nil {
+			return err
+		}
+
+		report := diff.Report{
+			Addon:   filepath.Base(addonDir),
+			Env:     opts.Env,
+			From:    opts.From,
+			To:      opts.To,
+			Changes: diff.Metadata(from, to),
+		}
+
+		if !opts.SkipBundles {
+			changes, err := diffBundles(cmd.Context(), opts, from, to)
+			if err != nil {
+				return err
+			}
+
+			report.Changes = append(report.Changes, changes...)
+		}
+
+		if opts.Output == outputJSON {
+			return report.WriteJSON(cmd.OutOrStdout())
+		}
+
+		return report.WriteText(cmd.OutOrStdout())
+	}
+}
+
+func loadMeta(addonDir, env, version string) (*addonsv1alpha1.AddonMetadataSpec, error) {
+	meta, err := utils.NewMetaLoader(addonDir, env, version).Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading addon metadata version %q from '%s': %w", version, addonDir, err)
+	}
+
+	return meta, nil
+}
+
+// diffBundles extracts the bundles referenced by both versions
+// and compares the RBAC requested by their head bundles.
+func diffBundles(ctx context.Context, opts *options, from, to *addonsv1alpha1.AddonMetadataSpec) ([]diff.Change, error) {
+	ext, err := newExtractor(opts)
+	if err != nil {
+		return nil, fmt.Errorf("initializing extractor: %w", err)
+	}
+
+	fromBundles, err := extractBundles(ctx, ext, from)
+	if err != nil {
+		return nil, fmt.Errorf("extracting bundles of version %q: %w", opts.From, err)
+	}
+
+	toBundles, err := extractBundles(ctx, ext, to)
+	if err != nil {
+		return nil, fmt.Errorf("extracting bundles of version %q: %w", opts.To, err)
+	}
+
+	changes, err := diff.RBAC(fromBundles, toBundles)
+	if err != nil {
+		return nil, fmt.Errorf("comparing bundle permissions: %w", err)
+	}
+
+	return changes, nil
+}
+
+func extractBundles(ctx context.Context, ext extractor.Extractor, meta *addonsv1alpha1.AddonMetadataSpec) ([]operator.Bundle, error) {
+	if meta.IndexImage == nil {
+		return nil, errors.New("addon metadata does not reference an index image")
+	}
+
+	return ext.ExtractBundles(ctx, *meta.IndexImage, meta.OperatorName)
+}
+
+func newExtractor(opts *options) (extractor.Extractor, error) {
+	if opts.NoCache {
+		return extractor.New(), nil
+	}
+
+	cache, err := extractor.NewDiskCache(extractor.WithCacheDir(opts.CacheDir))
+	if err != nil {
+		return nil, fmt.Errorf("opening cache: %w", err)
+	}
+
+	return extractor.New(
+		extractor.WithIndexExtractor(
+			extractor.NewIndexExtractor(extractor.WithIndexCache(cache)),
+		),
+		extractor.WithBundleExtractor(
+			extractor.NewBundleExtractor(extractor.WithBundleCache(cache)),
+		),
+	), nil
+}
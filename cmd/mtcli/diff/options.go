@@ -0,0 +1,113 @@
+package diff
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/mod/semver"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+type options struct {
+	Env         string
+	From        string
+	To          string
+	Output      string
+	SkipBundles bool
+	CacheDir    string
+	NoCache     bool
+}
+
+func (o *options) AddEnvFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Env,
+		"env",
+		o.Env,
+		"integration, stage or production",
+	)
+}
+
+func (o *options) AddFromFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.From,
+		"from",
+		o.From,
+		"imageset version to compare from",
+	)
+}
+
+func (o *options) AddToFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.To,
+		"to",
+		o.To,
+		"imageset version to compare to, may be 'latest'",
+	)
+}
+
+func (o *options) AddOutputFlag(flags *pflag.FlagSet) {
+	flags.StringVarP(
+		&o.Output,
+		"output",
+		"o",
+		o.Output,
+		"Output format. One of 'text' or 'json'.",
+	)
+}
+
+func (o *options) AddSkipBundlesFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.SkipBundles,
+		"skip-bundles",
+		o.SkipBundles,
+		"Only compare the addon metadata and skip extracting the head bundles to compare their RBAC.",
+	)
+}
+
+func (o *options) AddCacheFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.CacheDir,
+		"cache-dir",
+		o.CacheDir,
+		"Directory of the on-disk bundle cache. Defaults to '$XDG_CACHE_HOME/mtcli'.",
+	)
+	flags.BoolVar(
+		&o.NoCache,
+		"no-cache",
+		o.NoCache,
+		"Disable the on-disk bundle cache.",
+	)
+}
+
+func (o *options) VerifyFlags() error {
+	switch o.Env {
+	case "stage", "integration", "production":
+	default:
+		return fmt.Errorf("'%s' is not a valid environment; must be one of 'integration', 'stage' or 'production'", o.Env)
+	}
+
+	if o.From == "" || o.To == "" {
+		return errors.New("both '--from' and '--to' must be set")
+	}
+
+	for _, version := range []string{o.From, o.To} {
+		// semver.IsValid(...) requires the following format vMAJOR.MINOR.PATCH
+		// so we temporarily prefix the 'v' character
+		if version != "latest" && !semver.IsValid(fmt.Sprintf("v%v", version)) {
+			return fmt.Errorf("'%s' is not a valid version; must be one of 'latest' or match 'MAJOR.MINOR.PATCH'", version)
+		}
+	}
+
+	switch o.Output {
+	case outputText, outputJSON:
+	default:
+		return fmt.Errorf("'%s' is not a valid output format; must be one of '%s' or '%s'", o.Output, outputText, outputJSON)
+	}
+
+	return nil
+}
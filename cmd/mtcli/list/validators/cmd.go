@@ -33,7 +33,7 @@ func run(cmd *cobra.Command, args []string) error {
 	}
 
 	table, err := cli.NewTable(
-		cli.WithHeaders{"CODE", "NAME", "DESCRIPTION"},
+		cli.WithHeaders{"CODE", "NAME", "SEVERITY", "DESCRIPTION"},
 	)
 	if err != nil {
 		return fmt.Errorf("initializing table: %w", err)
@@ -43,6 +43,7 @@ func run(cmd *cobra.Command, args []string) error {
 		table.WriteRow(cli.TableRow{
 			cli.Field{Value: v.Code().String()},
 			cli.Field{Value: v.Name()},
+			cli.Field{Value: v.Severity().String()},
 			cli.Field{Value: v.Description()},
 		})
 	}
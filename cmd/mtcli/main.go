@@ -7,8 +7,14 @@ import (
 	"os/signal"
 
 	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/bundle"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/cache"
 	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/completion"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/diff"
 	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/list"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/migrate"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/ocm"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/render"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/schema"
 	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/validate"
 	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/version"
 	log "github.com/sirupsen/logrus"
@@ -43,8 +49,14 @@ func generateRootCmd() *cobra.Command {
 	}
 
 	rootCmd.AddCommand(bundle.Cmd())
+	rootCmd.AddCommand(cache.Cmd())
 	rootCmd.AddCommand(completion.Cmd())
+	rootCmd.AddCommand(diff.Cmd())
 	rootCmd.AddCommand(list.Cmd())
+	rootCmd.AddCommand(migrate.Cmd())
+	rootCmd.AddCommand(ocm.Cmd())
+	rootCmd.AddCommand(render.Cmd())
+	rootCmd.AddCommand(schema.Cmd())
 	rootCmd.AddCommand(validate.Cmd())
 	rootCmd.AddCommand(version.Cmd())
 
@@ -0,0 +1,16 @@
+package migrate
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate [command]",
+		Short: "Migrate addons to newer metadata layouts.",
+	}
+
+	cmd.AddCommand(imageSetCmd())
+
+	return cmd
+}
@@ -0,0 +1,213 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/migrate"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/mod/semver"
+)
+
+const imageSetLong = "Move the index image of an addon and the fields bound to it, i.e. " +
+	"'addOnParameters', 'addOnRequirements', 'subOperators', 'config', 'pullSecretName' " +
+	"and 'additionalCatalogSources', from 'metadata/<env>/addon.yaml' to a new imageset " +
+	"'addonimagesets/<env>/<addon>.v<version>.yaml' which the metadata then references. " +
+	"The imageset version is taken from the tag of the index image unless given with '--version'. " +
+	"Anything which could not be migrated is reported and must be completed by hand."
+
+func imageSetExamples() string {
+	return strings.Join([]string{
+		"  # Migrate every environment of an addon whose index image is tagged with its version.",
+		"  mtcli migrate imageset <path/to/addon_dir>",
+		"  # Preview the migration of an addon whose index image is pinned by digest.",
+		"  mtcli migrate imageset --env stage --version 1.2.0 --dry-run <path/to/addon_dir>",
+		"  # Migrate a legacy addon which does not reference an index image.",
+		"  mtcli migrate imageset --env stage --index-image quay.io/osd-addons/<addon>-index:v1.2.0 <path/to/addon_dir>",
+	}, "\n")
+}
+
+func imageSetCmd() *cobra.Command {
+	var opts imageSetOptions
+
+	cmd := &cobra.Command{
+		Use:           "imageset",
+		Short:         "Migrate an addon using a static index image to imagesets.",
+		Long:          imageSetLong,
+		Example:       imageSetExamples(),
+		Args:          cobra.ExactArgs(1),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.VerifyFlags(); err != nil {
+				return fmt.Errorf("verifying flags: %w", err)
+			}
+
+			addonDir, err := filepath.Abs(args[0])
+			if err != nil {
+				return fmt.Errorf("parsing addon dir %q: %w", args[0], err)
+			}
+
+			envs := opts.Envs
+			if len(envs) == 0 {
+				if envs, err = metadataEnvs(addonDir); err != nil {
+					return fmt.Errorf("listing environments of '%s': %w", addonDir, err)
+				}
+			}
+
+			for _, env := range envs {
+				if err := migrateEnv(cmd.OutOrStdout(), addonDir, env, opts); err != nil {
+					return fmt.Errorf("migrating environment '%s': %w", env, err)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+
+	opts.AddEnvFlag(flags)
+	opts.AddVersionFlag(flags)
+	opts.AddIndexImageFlag(flags)
+	opts.AddDryRunFlag(flags)
+
+	return cmd
+}
+
+func migrateEnv(out io.Writer, addonDir, env string, opts imageSetOptions) error {
+	var migrateOpts []migrate.ImageSetOption
+
+	if opts.Version != "" {
+		migrateOpts = append(migrateOpts, migrate.WithVersion(opts.Version))
+	}
+
+	if opts.IndexImage != "" {
+		migrateOpts = append(migrateOpts, migrate.WithIndexImage(opts.IndexImage))
+	}
+
+	m, err := migrate.ImageSet(addonDir, env, migrateOpts...)
+	if errors.Is(err, migrate.ErrAlreadyMigrated) {
+		fmt.Fprintf(out, "%s: skipped; %v\n", env, err)
+
+		return nil
+	} else if errors.Is(err, migrate.ErrUnknownVersion) {
+		return fmt.Errorf("%w; set the version with '--version'", err)
+	} else if errors.Is(err, migrate.ErrNoIndexImage) {
+		return fmt.Errorf("%w; set the index image with '--index-image'", err)
+	} else if err != nil {
+		return err
+	}
+
+	if opts.DryRun {
+		diff, err := m.Diff()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprint(out, diff)
+	} else if err := m.Write(); err != nil {
+		return err
+	}
+
+	moved := append([]string{"indexImage"}, m.Moved...)
+
+	fmt.Fprintf(out, "%s: moved %s to %s\n", env, strings.Join(moved, ", "), m.ImageSetPath)
+
+	for _, note := range m.Notes {
+		fmt.Fprintf(out, "%s: not migrated: %s\n", env, note)
+	}
+
+	return nil
+}
+
+// metadataEnvs returns the environments for which
+// the addon in 'addonDir' defines metadata.
+func metadataEnvs(addonDir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(addonDir, "metadata"))
+	if err != nil {
+		return nil, err
+	}
+
+	var envs []string
+
+	for _, e := range entries {
+		if e.IsDir() {
+			envs = append(envs, e.Name())
+		}
+	}
+
+	sort.Strings(envs)
+
+	return envs, nil
+}
+
+type imageSetOptions struct {
+	Envs       []string
+	Version    string
+	IndexImage string
+	DryRun     bool
+}
+
+func (o *imageSetOptions) AddEnvFlag(flags *pflag.FlagSet) {
+	flags.StringSliceVar(
+		&o.Envs,
+		"env",
+		o.Envs,
+		"integration, stage or production; defaults to every environment of the addon",
+	)
+}
+
+func (o *imageSetOptions) AddVersionFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Version,
+		"version",
+		o.Version,
+		"version of the created imageset; defaults to the tag of the index image",
+	)
+}
+
+func (o *imageSetOptions) AddIndexImageFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.IndexImage,
+		"index-image",
+		o.IndexImage,
+		"index image of the created imageset; replaces the index image of the addon metadata",
+	)
+}
+
+func (o *imageSetOptions) AddDryRunFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.DryRun,
+		"dry-run",
+		o.DryRun,
+		"print the changes as a unified diff instead of writing them",
+	)
+}
+
+func (o *imageSetOptions) VerifyFlags() error {
+	for _, env := range o.Envs {
+		switch env {
+		case "stage", "integration", "production":
+		default:
+			return fmt.Errorf("'%s' is not a valid environment; must be one of 'integration', 'stage' or 'production'", env)
+		}
+	}
+
+	if o.Version == "" {
+		return nil
+	}
+	// semver.IsValid(...) requires the following format vMAJOR.MINOR.PATCH
+	// so we temporarily prefix the 'v' character
+	if !semver.IsValid(fmt.Sprintf("v%v", strings.TrimPrefix(o.Version, "v"))) {
+		return fmt.Errorf("'%s' is not a valid version; must match 'MAJOR.MINOR.PATCH'", o.Version)
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package ocm
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ocm [command]",
+		Short: "Work with the OCM representation of addons.",
+	}
+
+	cmd.AddCommand(exportCmd())
+
+	return cmd
+}
@@ -0,0 +1,113 @@
+package ocm
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/convert"
+	"github.com/mt-sre/addon-metadata-operator/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/mod/semver"
+)
+
+func exportExamples() string {
+	return strings.Join([]string{
+		"  # Print the OCM payload of a staging addon using its default imageset version.",
+		"  mtcli ocm export <path/to/addon_dir>",
+		"  # Print the OCM payload of the latest imageset of a production addon.",
+		"  mtcli ocm export --env production --version latest <path/to/addon_dir>",
+	}, "\n")
+}
+
+func exportCmd() *cobra.Command {
+	opts := &exportOptions{
+		Env: "stage",
+	}
+
+	cmd := &cobra.Command{
+		Use:           "export",
+		Short:         "Print the AddOn and AddOnVersion payloads sent to OCM for an addon as JSON.",
+		Example:       exportExamples(),
+		Args:          cobra.ExactArgs(1),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.VerifyFlags(); err != nil {
+				return fmt.Errorf("verifying flags: %w", err)
+			}
+
+			addonDir, err := filepath.Abs(args[0])
+			if err != nil {
+				return fmt.Errorf("parsing addon dir %q: %w", args[0], err)
+			}
+
+			meta, err := utils.NewMetaLoader(addonDir, opts.Env, opts.Version).Load()
+			if err != nil {
+				return fmt.Errorf("loading addon metadata from '%s': %w", addonDir, err)
+			}
+
+			payload, err := convert.Convert(meta)
+			if err != nil {
+				return fmt.Errorf("converting addon '%s': %w", meta.ID, err)
+			}
+
+			if err := payload.WriteJSON(cmd.OutOrStdout()); err != nil {
+				return fmt.Errorf("writing payload: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+
+	opts.AddEnvFlag(flags)
+	opts.AddVersionFlag(flags)
+
+	return cmd
+}
+
+type exportOptions struct {
+	Env     string
+	Version string
+}
+
+func (o *exportOptions) AddEnvFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Env,
+		"env",
+		o.Env,
+		"integration, stage or production",
+	)
+}
+
+func (o *exportOptions) AddVersionFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Version,
+		"version",
+		o.Version,
+		"addon imageset version, may be 'latest'",
+	)
+}
+
+func (o *exportOptions) VerifyFlags() error {
+	switch o.Env {
+	case "stage", "integration", "production":
+	default:
+		return fmt.Errorf("'%s' is not a valid environment; must be one of 'integration', 'stage' or 'production'", o.Env)
+	}
+
+	// unset version is OK, will fallback to meta.addonImageSetVersion
+	if o.Version == "" {
+		return nil
+	}
+	// semver.IsValid(...) requires the following format vMAJOR.MINOR.PATCH
+	// so we temporarily prefix the 'v' character
+	if o.Version != "latest" && !semver.IsValid(fmt.Sprintf("v%v", o.Version)) {
+		return fmt.Errorf("'%s' is not a valid version; must be one of 'latest' or match 'MAJOR.MINOR.PATCH'", o.Version)
+	}
+
+	return nil
+}
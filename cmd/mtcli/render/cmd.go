@@ -0,0 +1,73 @@
+package render
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/render"
+	"github.com/mt-sre/addon-metadata-operator/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+const long = "Render the Kubernetes objects created on clusters for an addon as a stream of YAML documents."
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Render the objects of a staging addon using its default imageset version.",
+		"  mtcli render <path/to/addon_dir>",
+		"  # Render the objects of a production addon using the latest imageset.",
+		"  mtcli render --env production --version latest <path/to/addon_dir>",
+		"  # Compare the objects of two imageset versions.",
+		"  diff <(mtcli render --version 1.2.0 <path/to/addon_dir>) <(mtcli render --version 1.3.0 <path/to/addon_dir>)",
+	}, "\n")
+}
+
+func Cmd() *cobra.Command {
+	opts := &options{
+		Env: "stage",
+	}
+
+	cmd := &cobra.Command{
+		Use:           "render",
+		Short:         "Render the Kubernetes objects of an addon.",
+		Long:          long,
+		Example:       examples(),
+		Args:          cobra.ExactArgs(1),
+		RunE:          run(opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	flags := cmd.Flags()
+
+	opts.AddEnvFlag(flags)
+	opts.AddVersionFlag(flags)
+
+	return cmd
+}
+
+func run(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := opts.VerifyFlags(); err != nil {
+			return fmt.Errorf("verifying flags: %w", err)
+		}
+
+		addonDir, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("parsing addon dir %q: %w", args[0], err)
+		}
+
+		meta, err := utils.NewMetaLoader(addonDir, opts.Env, opts.Version).Load()
+		if err != nil {
+			return fmt.Errorf("loading addon metadata from '%s': %w", addonDir, err)
+		}
+
+		objs, err := render.Render(meta)
+		if err != nil {
+			return fmt.Errorf("rendering addon '%s': %w", meta.ID, err)
+		}
+
+		return render.Write(cmd.OutOrStdout(), objs)
+	}
+}
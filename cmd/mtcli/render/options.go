@@ -0,0 +1,51 @@
+package render
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/mod/semver"
+)
+
+type options struct {
+	Env     string
+	Version string
+}
+
+func (o *options) AddEnvFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Env,
+		"env",
+		o.Env,
+		"integration, stage or production",
+	)
+}
+
+func (o *options) AddVersionFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Version,
+		"version",
+		o.Version,
+		"addon imageset version, may be 'latest'",
+	)
+}
+
+func (o *options) VerifyFlags() error {
+	switch o.Env {
+	case "stage", "integration", "production":
+	default:
+		return fmt.Errorf("'%s' is not a valid environment; must be one of 'integration', 'stage' or 'production'", o.Env)
+	}
+
+	// unset version is OK, will fallback to meta.addonImageSetVersion
+	if o.Version == "" {
+		return nil
+	}
+	// semver.IsValid(...) requires the following format vMAJOR.MINOR.PATCH
+	// so we temporarily prefix the 'v' character
+	if o.Version != "latest" && !semver.IsValid(fmt.Sprintf("v%v", o.Version)) {
+		return fmt.Errorf("'%s' is not a valid version; must be one of 'latest' or match 'MAJOR.MINOR.PATCH'", o.Version)
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package schema
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema [command]",
+		Short: "Work with the schemas of the addon metadata files.",
+	}
+
+	cmd.AddCommand(generateCmd())
+
+	return cmd
+}
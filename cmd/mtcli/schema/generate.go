@@ -0,0 +1,85 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/schema"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/exp/slices"
+)
+
+func generateExamples() string {
+	return strings.Join([]string{
+		"  # Print the JSON Schema of 'addon.yaml' files.",
+		"  mtcli schema generate addon",
+		"  # Write the OpenAPI v3 schema of imageset files to a file.",
+		"  mtcli schema generate imageset --format openapi > imageset.openapi.json",
+	}, "\n")
+}
+
+func generateCmd() *cobra.Command {
+	opts := &generateOptions{
+		Format: string(schema.FormatJSONSchema),
+	}
+
+	cmd := &cobra.Command{
+		Use:       "generate [addon|imageset]",
+		Short:     "Generate the schema of an addon metadata file from the Go types and their markers.",
+		Example:   generateExamples(),
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: kindNames(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.VerifyFlags(); err != nil {
+				return fmt.Errorf("verifying flags: %w", err)
+			}
+
+			data, err := schema.Generate(schema.Kind(args[0]), schema.Format(opts.Format))
+			if err != nil {
+				return fmt.Errorf("generating schema: %w", err)
+			}
+
+			if _, err := cmd.OutOrStdout().Write(data); err != nil {
+				return fmt.Errorf("writing schema: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	opts.AddFormatFlag(cmd.Flags())
+
+	return cmd
+}
+
+type generateOptions struct {
+	Format string
+}
+
+func (o *generateOptions) AddFormatFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Format,
+		"format",
+		o.Format,
+		"Schema format. One of 'jsonschema' or 'openapi'.",
+	)
+}
+
+func (o *generateOptions) VerifyFlags() error {
+	if !slices.Contains(schema.Formats, schema.Format(o.Format)) {
+		return fmt.Errorf("unknown format %q, must be one of 'jsonschema' or 'openapi'", o.Format)
+	}
+
+	return nil
+}
+
+func kindNames() []string {
+	names := make([]string, 0, len(schema.Kinds))
+
+	for _, k := range schema.Kinds {
+		names = append(names, string(k))
+	}
+
+	return names
+}
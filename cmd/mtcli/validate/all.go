@@ -0,0 +1,161 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/report"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"golang.org/x/sync/errgroup"
+)
+
+// addonTarget is a single addon directory to be
+// validated against a single environment.
+type addonTarget struct {
+	Dir string
+	Env string
+}
+
+// discoverAddons returns a target for every directory directly below
+// 'root' which contains addon metadata for one of the given environments.
+// Targets are ordered by addon name and then by the order of 'envs'.
+func discoverAddons(root string, envs []string) ([]addonTarget, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("reading addons root: %w", err)
+	}
+
+	var targets []addonTarget
+
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		dir := filepath.Join(root, e.Name())
+
+		for _, env := range envs {
+			path := filepath.Join(dir, "metadata", env, "addon.yaml")
+
+			if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+				continue
+			} else if err != nil {
+				return nil, fmt.Errorf("checking for addon metadata %q: %w", path, err)
+			}
+
+			targets = append(targets, addonTarget{
+				Dir: dir,
+				Env: env,
+			})
+		}
+	}
+
+	return targets, nil
+}
+
+// runAll validates every addon discovered below 'root' for each requested
+// environment. A single bundle source and Runner are shared between all
+// addons so that bundles referenced by several addons are only extracted once.
+// Addons which cannot be loaded are reported as errors and do not prevent
+// the remaining addons from being validated.
+func runAll(
+	ctx context.Context,
+	out, errOut io.Writer,
+	opts *options,
+	root string,
+	filter validator.Filter,
+	format report.Format,
+	threshold validator.Severity,
+) error {
+	envs := opts.Envs()
+
+	targets, err := discoverAddons(root, envs)
+	if err != nil {
+		return fmt.Errorf("discovering addons in %q: %w", root, err)
+	}
+
+	if len(targets) == 0 {
+		return fmt.Errorf("no addons found in %q", root)
+	}
+
+	ocm, err := newEnvOCMClient(envs...)
+	if err != nil {
+		return fmt.Errorf("initializing ocm clients: %w", err)
+	}
+
+	defer func() { _ = ocm.CloseConnection() }()
+
+	runner, err := newRunner(opts, ocm)
+	if err != nil {
+		return fmt.Errorf("initializing validators: %w", err)
+	}
+
+	src, err := newBundleSource(opts)
+	if err != nil {
+		return fmt.Errorf("initializing bundle source: %w", err)
+	}
+
+	reports := make([]report.AddonReport, len(targets))
+
+	var g errgroup.Group
+
+	g.SetLimit(opts.Concurrency)
+
+	for i, t := range targets {
+		i, t := i, t
+
+		g.Go(func() error {
+			results, timings, err := validateAddon(withEnv(ctx, t.Env), src, runner, t.Dir, opts.MetaLoader(t.Dir, t.Env), filter)
+
+			reports[i] = report.AddonReport{
+				Addon:   filepath.Base(t.Dir),
+				Env:     t.Env,
+				Results: results,
+				Err:     err,
+				Timings: timings,
+			}
+
+			// errors are recorded per addon rather than cancelling the group
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	writer, err := report.NewAggregateWriter(format, threshold)
+	if err != nil {
+		return fmt.Errorf("initializing %s writer: %w", format, err)
+	}
+
+	if err := writer.WriteAggregate(out, reports); err != nil {
+		return fmt.Errorf("writing results: %w", err)
+	}
+
+	if opts.Timings {
+		if err := report.WriteAggregateTimings(errOut, reports); err != nil {
+			return fmt.Errorf("writing timings: %w", err)
+		}
+	}
+
+	var failed bool
+
+	for _, r := range reports {
+		if r.IsError() {
+			return ErrValidationErrored
+		}
+
+		if r.Results.HasFailureAtOrAbove(threshold) {
+			failed = true
+		}
+	}
+
+	if failed {
+		return ErrValidationFailed
+	}
+
+	return nil
+}
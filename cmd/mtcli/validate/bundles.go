@@ -0,0 +1,72 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+)
+
+// bundleSource resolves the bundles of the operator an addon installs.
+type bundleSource interface {
+	Bundles(ctx context.Context, meta *addonsv1alpha1.AddonMetadataSpec) ([]operator.Bundle, error)
+}
+
+// indexBundleSource extracts bundles from the index image
+// referenced by the addon metadata.
+type indexBundleSource struct {
+	ext extractor.Extractor
+}
+
+func (s indexBundleSource) Bundles(ctx context.Context, meta *addonsv1alpha1.AddonMetadataSpec) ([]operator.Bundle, error) {
+	if meta.IndexImage == nil {
+		return nil, errors.New("addon metadata does not reference an index image")
+	}
+
+	return s.ext.ExtractBundles(ctx, *meta.IndexImage, meta.OperatorName)
+}
+
+// dirBundleSource loads bundles from a local directory tree laid out
+// like 'internal/testdata/bundles'. The tree is read once and only the
+// bundles belonging to the addon's operator package are returned.
+type dirBundleSource struct {
+	dir string
+
+	once    sync.Once
+	bundles []operator.Bundle
+	err     error
+}
+
+func newDirBundleSource(dir string) *dirBundleSource {
+	return &dirBundleSource{dir: dir}
+}
+
+func (s *dirBundleSource) Bundles(_ context.Context, meta *addonsv1alpha1.AddonMetadataSpec) ([]operator.Bundle, error) {
+	s.once.Do(func() {
+		s.bundles, s.err = operator.NewBundlesFromDirectoryTree(s.dir)
+	})
+
+	if s.err != nil {
+		return nil, fmt.Errorf("loading bundles from %q: %w", s.dir, s.err)
+	}
+
+	var res []operator.Bundle
+
+	for _, b := range s.bundles {
+		if b.Package != meta.OperatorName {
+			continue
+		}
+
+		res = append(res, b)
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("no bundles for package %q found in %q", meta.OperatorName, s.dir)
+	}
+
+	return res, nil
+}
@@ -9,13 +9,19 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
 	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/internal/report"
 	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/schema"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/mt-sre/addon-metadata-operator/pkg/utils"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
 	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/register"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -33,12 +39,46 @@ func examples() string {
 		"  mtcli validate --env integration --disabled AM0001,AM0002 <path/to/addon_dir>",
 		"  # Validate an integration addon using imageset, enabled only 001_foo.",
 		"  mtcli validate --env integration --enabled AM0001 <path/to/addon_dir>",
+		"  # Validate a staging addon and write the results as a JUnit XML report.",
+		"  mtcli validate --env stage --output junit <path/to/addon_dir> > results.xml",
+		"  # Validate a staging addon and also fail on warnings.",
+		"  mtcli validate --env stage --fail-on warning <path/to/addon_dir>",
+		"  # Print the configuration resolved from the '.mtcli.yaml' file and flags.",
+		"  mtcli validate --print-config <path/to/addon_dir>",
+		"  # Validate every addon below an addons root in stage and production, eight at a time.",
+		"  mtcli validate --all --env stage,production --concurrency 8 <path/to/addons_root>",
+		"  # Validate a staging addon against locally built bundles without pulling any images.",
+		"  mtcli validate --env stage --bundles-dir <path/to/bundles_dir> <path/to/addon_dir>",
+		"  # Validate a staging addon against the bundles listed in a local file-based catalog.",
+		"  mtcli validate --env stage --catalog-dir <path/to/catalog_dir> <path/to/addon_dir>",
+		"  # Validate a staging addon giving each validator at most two minutes and running four at a time.",
+		"  mtcli validate --env stage --validator-timeout 2m --validator-parallelism 4 <path/to/addon_dir>",
+		"  # Validate a staging addon without using the on-disk bundle cache.",
+		"  mtcli validate --env stage --no-cache <path/to/addon_dir>",
+		"  # Validate a staging addon running every validator again instead of reusing cached results.",
+		"  mtcli validate --env stage --refresh-result-cache <path/to/addon_dir>",
+		"  # Validate a staging addon and report unknown fields, duplicate keys and mistyped values.",
+		"  mtcli validate --env stage --strict <path/to/addon_dir>",
+		"  # Print the fixes suggested by validators as a diff of the addon metadata file.",
+		"  mtcli validate --env stage --fix --dry-run <path/to/addon_dir>",
+		"  # Print the time spent on each validator and on extracting bundles.",
+		"  mtcli validate --env stage --timings <path/to/addon_dir>",
 	}, "\n")
 }
 
 func Cmd() *cobra.Command {
 	opts := &options{
-		Env: "stage",
+		Env:    "stage",
+		Output: string(report.FormatTable),
+		FailOn: validator.SeverityError.String(),
+
+		RetryMaxAttempts: validator.DefaultRetryMaxAttempts,
+		RetryDelay:       validator.DefaultRetryDelay,
+		RetryBackoff:     "constant",
+
+		Concurrency:    4,
+		IndexCacheTTL:  extractor.DefaultIndexTTL,
+		ResultCacheTTL: extractor.DefaultResultTTL,
 	}
 
 	cmd := &cobra.Command{
@@ -59,6 +99,20 @@ func Cmd() *cobra.Command {
 	opts.AddDisabledFlag(flags)
 	opts.AddEnabledFlag(flags)
 	opts.AddExcludedNamespacesFlag(flags)
+	opts.AddOutputFlag(flags)
+	opts.AddFailOnFlag(flags)
+	opts.AddConfigFlag(flags)
+	opts.AddPrintConfigFlag(flags)
+	opts.AddAllFlag(flags)
+	opts.AddConcurrencyFlag(flags)
+	opts.AddValidatorLimitFlags(flags)
+	opts.AddRetryFlags(flags)
+	opts.AddCacheFlags(flags)
+	opts.AddBundlesDirFlag(flags)
+	opts.AddCatalogDirFlag(flags)
+	opts.AddStrictFlag(flags)
+	opts.AddFixFlags(flags)
+	opts.AddTimingsFlag(flags)
 
 	return cmd
 }
@@ -79,10 +133,6 @@ func run(opts *options) func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := context.WithCancel(cmd.Context())
 		defer cancel()
 
-		if err := opts.VerifyFlags(); err != nil {
-			return fmt.Errorf("verifying flags: %w", err)
-		}
-
 		addonDir, err := parseAddonDir(args[0])
 		if err != nil {
 			return fmt.Errorf("parsing addon dir %q: %w", args[0], err)
@@ -92,15 +142,28 @@ func run(opts *options) func(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("verifying addon dir %q: %w", addonDir, err)
 		}
 
-		meta, err := utils.NewMetaLoader(addonDir, opts.Env, opts.Version).Load()
-		if err != nil {
-			return fmt.Errorf("loading addon metadata from '%s': %w", addonDir, err)
+		configFile := opts.ConfigFile
+		if configFile == "" {
+			if configFile, err = findConfigFile(addonDir); err != nil {
+				return fmt.Errorf("searching for config file: %w", err)
+			}
 		}
 
-		extractor := extractor.New()
-		bundles, err := extractor.ExtractBundles(ctx, *meta.IndexImage, meta.OperatorName)
-		if err != nil {
-			return fmt.Errorf("extracting and parsing addon bundles: %w", err)
+		if configFile != "" {
+			cfg, err := loadConfigFile(configFile)
+			if err != nil {
+				return fmt.Errorf("loading config file %q: %w", configFile, err)
+			}
+
+			opts.ApplyConfig(cfg, cmd.Flags())
+		}
+
+		if err := opts.VerifyFlags(); err != nil {
+			return fmt.Errorf("verifying flags: %w", err)
+		}
+
+		if opts.PrintConfig {
+			return printConfig(cmd.OutOrStdout(), configFile, opts.ResolvedConfig())
 		}
 
 		filter, err := generateFilter(opts.Disabled, opts.Enabled)
@@ -108,68 +171,75 @@ func run(opts *options) func(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("generating validator filter: %w", err)
 		}
 
-		ocm, err := validator.NewOCMClient(
-			validator.WithConnectOptions{
-				validator.WithAPIURL(envToOCMURL(opts.Env)),
-				validator.WithAccessToken(os.Getenv(ocmTokenEnvVar)),
-				validator.WithClientID(os.Getenv(ocmClientIDEnvVar)),
-				validator.WithClientSecret(os.Getenv(ocmClientSecretEnvVar)),
-			},
-		)
+		format, err := report.ParseFormat(opts.Output)
+		if err != nil {
+			return fmt.Errorf("parsing output format: %w", err)
+		}
+
+		threshold, err := opts.FailOnSeverity()
+		if err != nil {
+			return fmt.Errorf("parsing severity threshold: %w", err)
+		}
+
+		if opts.All {
+			return runAll(ctx, cmd.OutOrStdout(), cmd.ErrOrStderr(), opts, addonDir, filter, format, threshold)
+		}
+
+		ocm, err := newOCMClient(opts.Env)
 		if err != nil {
 			return fmt.Errorf("initializing ocm client: %w", err)
 		}
 
 		defer func() { _ = ocm.CloseConnection() }()
 
-		runner, err := validator.NewRunner(
-			validator.WithMiddleware{
-				validator.NewRetryMiddleware(),
-			},
-			validator.WithOCMClient{OCMClient: ocm},
-			validator.WithValidatorOptions{
-				validator.WithExcludedNamespaces(opts.ExcludedNamespaces),
-			},
-		)
+		runner, err := newRunner(opts, ocm)
 		if err != nil {
 			return fmt.Errorf("initializing validators: %w", err)
 		}
 
-		mb := types.MetaBundle{
-			AddonMeta: meta,
-			Bundles:   bundles,
+		src, err := newBundleSource(opts)
+		if err != nil {
+			return fmt.Errorf("initializing bundle source: %w", err)
 		}
 
-		var results validator.ResultList
-
-		for res := range runner.Run(ctx, mb, filter) {
-			results = append(results, res)
+		results, timings, err := validateAddon(withEnv(ctx, opts.Env), src, runner, addonDir, opts.MetaLoader(addonDir, opts.Env), filter)
+		if err != nil {
+			return err
 		}
 
-		sort.Sort(results)
-
-		table, err := cli.NewTable(
-			cli.WithHeaders{"STATUS", "CODE", "NAME", "DESCRIPTION", "FAILURE MESSAGE"},
-		)
+		writer, err := report.NewWriter(format, threshold)
 		if err != nil {
-			return fmt.Errorf("initializing table: %w", err)
+			return fmt.Errorf("initializing %s writer: %w", format, err)
 		}
-		for _, res := range results {
-			writeResult(table, res)
+
+		if err := writer.Write(cmd.OutOrStdout(), results); err != nil {
+			return fmt.Errorf("writing results: %w", err)
 		}
 
-		out := cmd.OutOrStdout()
+		if opts.Timings {
+			if err := report.WriteTimings(cmd.ErrOrStderr(), results, timings); err != nil {
+				return fmt.Errorf("writing timings: %w", err)
+			}
+		}
 
-		fmt.Fprintln(out, table.String())
-		fmt.Fprintln(out)
-		fmt.Fprintln(out, "Please consult corresponding validator wikis: https://github.com/mt-sre/addon-metadata-operator/wiki/<code>.")
+		if opts.Fix {
+			fixes := results.FixesByFile(utils.MetadataPath(addonDir, opts.Env))
+
+			if err := applyFixes(cmd.ErrOrStderr(), fixes, opts.DryRun); err != nil {
+				return err
+			}
+		}
 
 		if errs := results.Errors(); len(errs) > 0 {
-			cli.PrintValidationErrors(errs)
+			// machine readable formats already include errors
+			if format == report.FormatTable {
+				cli.PrintValidationErrors(errs)
+			}
+
 			return ErrValidationErrored
 		}
 
-		if results.HasFailure() {
+		if results.HasFailureAtOrAbove(threshold) {
 			return ErrValidationFailed
 		}
 
@@ -177,6 +247,209 @@ func run(opts *options) func(cmd *cobra.Command, args []string) error {
 	}
 }
 
+// newBundleSource returns a source reading bundles from the local
+// bundles directory if one is given and from index images otherwise.
+func newBundleSource(opts *options) (bundleSource, error) {
+	if opts.BundlesDir != "" {
+		dir, err := parseAddonDir(opts.BundlesDir)
+		if err != nil {
+			return nil, fmt.Errorf("parsing bundles dir %q: %w", opts.BundlesDir, err)
+		}
+
+		if err := verifyAddonDir(dir); err != nil {
+			return nil, fmt.Errorf("verifying bundles dir %q: %w", dir, err)
+		}
+
+		return newDirBundleSource(dir), nil
+	}
+
+	ext, err := newExtractor(opts)
+	if err != nil {
+		return nil, fmt.Errorf("initializing extractor: %w", err)
+	}
+
+	return indexBundleSource{ext: ext}, nil
+}
+
+// newExtractor returns an extractor backed by the on-disk cache unless
+// caching is disabled. If a catalog directory is given, bundle images are
+// resolved from it rather than from the addon's index image.
+func newExtractor(opts *options) (*extractor.MainExtractor, error) {
+	var extOpts []extractor.MainExtractorOpt
+
+	if opts.CatalogDir != "" {
+		dir, err := parseAddonDir(opts.CatalogDir)
+		if err != nil {
+			return nil, fmt.Errorf("parsing catalog dir %q: %w", opts.CatalogDir, err)
+		}
+
+		if err := verifyAddonDir(dir); err != nil {
+			return nil, fmt.Errorf("verifying catalog dir %q: %w", dir, err)
+		}
+
+		extOpts = append(extOpts, extractor.WithIndexExtractor(
+			extractor.NewFBCIndexExtractor(dir),
+		))
+	}
+
+	if opts.NoCache {
+		return extractor.New(extOpts...), nil
+	}
+
+	cache, err := extractor.NewDiskCache(
+		extractor.WithCacheDir(opts.CacheDir),
+		extractor.WithIndexTTL(opts.IndexCacheTTL),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("opening cache: %w", err)
+	}
+
+	if opts.CatalogDir == "" {
+		extOpts = append(extOpts, extractor.WithIndexExtractor(
+			extractor.NewIndexExtractor(extractor.WithIndexCache(cache)),
+		))
+	}
+
+	extOpts = append(extOpts, extractor.WithBundleExtractor(
+		extractor.NewBundleExtractor(extractor.WithBundleCache(cache)),
+	))
+
+	return extractor.New(extOpts...), nil
+}
+
+func newRunner(opts *options, ocm validator.OCMClient) (*validator.Runner, error) {
+	backoff, err := opts.RetryBackoffPolicy()
+	if err != nil {
+		return nil, fmt.Errorf("configuring retries: %w", err)
+	}
+
+	middleware := validator.WithMiddleware{
+		validator.NewRetryMiddleware(
+			validator.WithMaxAttempts(opts.RetryMaxAttempts),
+			validator.WithBackoff{Backoff: backoff},
+			validator.WithMaxElapsedTime(opts.RetryMaxElapsedTime),
+		),
+	}
+
+	if build := cli.BuildID(); build == "" {
+		// results cached by a build with uncommitted changes could
+		// be reused after those changes were edited
+		logrus.Debug("result cache disabled: the build of mtcli can't be identified")
+	} else if !opts.NoCache && !opts.NoResultCache {
+		cache, err := newResultCache(opts, build)
+		if err != nil {
+			return nil, fmt.Errorf("initializing result cache: %w", err)
+		}
+
+		// outermost so that cached results skip retries altogether
+		middleware = append(middleware, cache)
+	}
+
+	return validator.NewRunner(
+		validator.WithLogger{Logger: newLogger().WithName("validator")},
+		middleware,
+		validator.WithOCMClient{OCMClient: ocm},
+		validator.WithTimeout(opts.ValidatorTimeout),
+		validator.WithMaxParallelism(opts.Parallelism),
+		validator.WithValidatorOptions{
+			validator.WithExcludedNamespaces(opts.ExcludedNamespaces),
+		},
+	)
+}
+
+// newResultCache returns a middleware reusing validator results from
+// the on-disk cache. Results are only reused by the same build of mtcli,
+// identified by 'build', with the same validator options and against the
+// same environment since validators such as AM0011 query OCM.
+func newResultCache(opts *options, build string) (*validator.CacheMiddleware, error) {
+	cache, err := extractor.NewDiskCache(
+		extractor.WithCacheDir(opts.CacheDir),
+		extractor.WithResultTTL(opts.ResultCacheTTL),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("opening cache: %w", err)
+	}
+
+	version := strings.Join(append(
+		[]string{build}, opts.ExcludedNamespaces...,
+	), ",")
+
+	return validator.NewCacheMiddleware(cache,
+		validator.WithCacheVersion(version),
+		validator.WithCacheScope(func(ctx context.Context) string {
+			env, _ := ctx.Value(envKey{}).(string)
+
+			return env
+		}),
+		validator.WithCacheRefresh(opts.RefreshResultCache),
+	), nil
+}
+
+// newLogger returns a logger writing structured entries through
+// logrus at debug level so that they are shown with '--verbose'.
+func newLogger() logr.Logger {
+	return funcr.New(func(prefix, args string) {
+		logrus.Debug(strings.TrimSpace(prefix + " " + args))
+	}, funcr.Options{})
+}
+
+// validateAddon loads the metadata of the addon in 'addonDir' using the
+// loader, resolves its bundles from the source and runs all validators
+// matching the filter. Issues found by strict decoding are reported as
+// an invalid result preceding the validator results which are sorted by
+// code. The time spent extracting bundles and running validators is
+// returned alongside the results.
+func validateAddon(
+	ctx context.Context,
+	src bundleSource,
+	runner *validator.Runner,
+	addonDir string,
+	loader utils.MetaLoader,
+	filter validator.Filter,
+) (validator.ResultList, report.Timings, error) {
+	var (
+		results validator.ResultList
+		timings report.Timings
+	)
+
+	meta, positions, err := loader.LoadWithPositions()
+
+	var decodeErr *schema.DecodeError
+
+	if meta != nil && errors.As(err, &decodeErr) {
+		results = append(results, validator.NewInvalidInputResult(decodeErr.Issues...))
+	} else if err != nil {
+		return nil, timings, fmt.Errorf("loading addon metadata from '%s': %w", addonDir, err)
+	}
+
+	start := time.Now()
+
+	bundles, err := src.Bundles(ctx, meta)
+	if err != nil {
+		return nil, timings, fmt.Errorf("extracting and parsing addon bundles: %w", err)
+	}
+
+	timings.Extraction = time.Since(start)
+
+	mb := types.MetaBundle{
+		AddonMeta: meta,
+		Bundles:   bundles,
+		Positions: positions,
+	}
+
+	start = time.Now()
+
+	for res := range runner.Run(ctx, mb, filter) {
+		results = append(results, res)
+	}
+
+	timings.Validation = time.Since(start)
+
+	sort.Sort(results)
+
+	return results, timings, nil
+}
+
 func parseAddonDir(dir string) (string, error) {
 	if !path.IsAbs(dir) {
 		return filepath.Abs(dir)
@@ -247,45 +520,3 @@ func envToOCMURL(env string) string {
 
 	return envToUrl[env]
 }
-
-func writeResult(t *cli.Table, res validator.Result) {
-	row := resultToRow(res)
-
-	if res.IsSuccess() {
-		t.WriteRow(append(row, cli.Field{Value: "None"}))
-	} else if res.IsError() {
-		t.WriteRow(append(row, cli.Field{Value: res.Error.Error()}))
-	} else {
-		for _, msg := range res.FailureMsgs {
-			t.WriteRow(append(row, cli.Field{Value: msg}))
-		}
-	}
-}
-
-func resultToRow(res validator.Result) cli.TableRow {
-	var status cli.Field
-
-	if res.IsSuccess() {
-		status = cli.Field{
-			Value: "Success",
-			Color: cli.FieldColorGreen,
-		}
-	} else if res.IsError() {
-		status = cli.Field{
-			Value: "Error",
-			Color: cli.FieldColorIntenselyBoldRed,
-		}
-	} else {
-		status = cli.Field{
-			Value: "Failed",
-			Color: cli.FieldColorRed,
-		}
-	}
-
-	return cli.TableRow{
-		status,
-		cli.Field{Value: res.Code.String()},
-		cli.Field{Value: res.Name},
-		cli.Field{Value: res.Description},
-	}
-}
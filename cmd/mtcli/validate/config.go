@@ -0,0 +1,198 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// configFileName is the name of the file which is searched for in the
+// addon directory and its parents up to the repository root.
+const configFileName = ".mtcli.yaml"
+
+// config is the on-disk representation of the options accepted
+// by 'mtcli validate'. Any field which is set by a command-line
+// flag takes precedence over the value in the file.
+type config struct {
+	Enabled    []string         `json:"enabled,omitempty"`
+	Disabled   []string         `json:"disabled,omitempty"`
+	Output     string           `json:"output,omitempty"`
+	FailOn     string           `json:"failOn,omitempty"`
+	Strict     bool             `json:"strict,omitempty"`
+	Validators validatorsConfig `json:"validators,omitempty"`
+	Retry      retryConfig      `json:"retry,omitempty"`
+}
+
+type validatorsConfig struct {
+	ExcludedNamespaces []string         `json:"excludedNamespaces,omitempty"`
+	Timeout            *metav1.Duration `json:"timeout,omitempty"`
+	Parallelism        int              `json:"parallelism,omitempty"`
+}
+
+type retryConfig struct {
+	MaxAttempts    int              `json:"maxAttempts,omitempty"`
+	Delay          *metav1.Duration `json:"delay,omitempty"`
+	Backoff        string           `json:"backoff,omitempty"`
+	MaxDelay       *metav1.Duration `json:"maxDelay,omitempty"`
+	MaxElapsedTime *metav1.Duration `json:"maxElapsedTime,omitempty"`
+}
+
+// findConfigFile searches for a config file starting in the addon
+// directory and moving up through its parents until either a file
+// is found or the repository root is reached. The repository root is
+// identified by the presence of a '.git' entry. If no config file is
+// found an empty string is returned.
+func findConfigFile(addonDir string) (string, error) {
+	dir := addonDir
+
+	for {
+		path := filepath.Join(dir, configFileName)
+
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("checking for config file %q: %w", path, err)
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "", nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+
+		dir = parent
+	}
+}
+
+// loadConfigFile reads and strictly decodes the config file at the given
+// path so that misspelled keys are reported rather than ignored.
+func loadConfigFile(path string) (config, error) {
+	var cfg config
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("reading file: %w", err)
+	}
+
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("decoding file: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// ApplyConfig sets option values from the given config for every option
+// whose flag was not explicitly set on the command-line.
+func (o *options) ApplyConfig(cfg config, flags *pflag.FlagSet) {
+	if !flags.Changed("enabled") && !flags.Changed("disabled") {
+		if len(cfg.Enabled) > 0 {
+			o.Enabled = strings.Join(cfg.Enabled, ",")
+		}
+
+		if len(cfg.Disabled) > 0 {
+			o.Disabled = strings.Join(cfg.Disabled, ",")
+		}
+	}
+
+	if !flags.Changed("output") && cfg.Output != "" {
+		o.Output = cfg.Output
+	}
+
+	if !flags.Changed("fail-on") && cfg.FailOn != "" {
+		o.FailOn = cfg.FailOn
+	}
+
+	if !flags.Changed("strict") && cfg.Strict {
+		o.Strict = true
+	}
+
+	if !flags.Changed("excluded-namespaces") && len(cfg.Validators.ExcludedNamespaces) > 0 {
+		o.ExcludedNamespaces = cfg.Validators.ExcludedNamespaces
+	}
+
+	if !flags.Changed("validator-timeout") && cfg.Validators.Timeout != nil {
+		o.ValidatorTimeout = cfg.Validators.Timeout.Duration
+	}
+
+	if !flags.Changed("validator-parallelism") && cfg.Validators.Parallelism > 0 {
+		o.Parallelism = cfg.Validators.Parallelism
+	}
+
+	if !flags.Changed("retry-max-attempts") && cfg.Retry.MaxAttempts > 0 {
+		o.RetryMaxAttempts = cfg.Retry.MaxAttempts
+	}
+
+	if !flags.Changed("retry-delay") && cfg.Retry.Delay != nil {
+		o.RetryDelay = cfg.Retry.Delay.Duration
+	}
+
+	if !flags.Changed("retry-backoff") && cfg.Retry.Backoff != "" {
+		o.RetryBackoff = cfg.Retry.Backoff
+	}
+
+	if !flags.Changed("retry-max-delay") && cfg.Retry.MaxDelay != nil {
+		o.RetryMaxDelay = cfg.Retry.MaxDelay.Duration
+	}
+
+	if !flags.Changed("retry-max-elapsed-time") && cfg.Retry.MaxElapsedTime != nil {
+		o.RetryMaxElapsedTime = cfg.Retry.MaxElapsedTime.Duration
+	}
+}
+
+// ResolvedConfig returns the config equivalent of the current option values.
+func (o *options) ResolvedConfig() config {
+	return config{
+		Enabled:  splitCodeList(o.Enabled),
+		Disabled: splitCodeList(o.Disabled),
+		Output:   o.Output,
+		FailOn:   o.FailOn,
+		Strict:   o.Strict,
+		Validators: validatorsConfig{
+			ExcludedNamespaces: o.ExcludedNamespaces,
+			Timeout:            &metav1.Duration{Duration: o.ValidatorTimeout},
+			Parallelism:        o.Parallelism,
+		},
+		Retry: retryConfig{
+			MaxAttempts:    o.RetryMaxAttempts,
+			Delay:          &metav1.Duration{Duration: o.RetryDelay},
+			Backoff:        o.RetryBackoff,
+			MaxDelay:       &metav1.Duration{Duration: o.RetryMaxDelay},
+			MaxElapsedTime: &metav1.Duration{Duration: o.RetryMaxElapsedTime},
+		},
+	}
+}
+
+func splitCodeList(list string) []string {
+	if list == "" {
+		return nil
+	}
+
+	return strings.Split(list, ",")
+}
+
+func printConfig(out io.Writer, source string, cfg config) error {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+
+	if source == "" {
+		source = "none"
+	}
+
+	fmt.Fprintf(out, "# config file: %s\n", source)
+
+	_, err = out.Write(data)
+
+	return err
+}
@@ -0,0 +1,98 @@
+package validate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/report"
+	"github.com/mt-sre/addon-metadata-operator/pkg/fix"
+)
+
+// applyFixes applies the edits to the files they are grouped by, i.e.
+// the addon metadata or imageset file defining the fixed field, and
+// reports them to 'out'. In dry-run mode the resulting changes are
+// printed as a unified diff instead of being written.
+func applyFixes(out io.Writer, fixes map[string][]fix.Edit, dryRun bool) error {
+	files := make([]string, 0, len(fixes))
+
+	for file := range fixes {
+		files = append(files, file)
+	}
+
+	sort.Strings(files)
+
+	var applied int
+
+	for _, file := range files {
+		n, err := applyFileFixes(out, file, fixes[file], dryRun)
+		if err != nil {
+			return fmt.Errorf("fixing %q: %w", report.DisplayPath(file), err)
+		}
+
+		applied += n
+	}
+
+	if applied == 0 {
+		fmt.Fprintln(out, "No fixes to apply.")
+	}
+
+	return nil
+}
+
+// applyFileFixes applies the edits to 'file' and returns the number of
+// edits which changed it. Edits already satisfied by the file are not
+// reported as fixed.
+func applyFileFixes(out io.Writer, file string, edits []fix.Edit, dryRun bool) (int, error) {
+	before, err := os.ReadFile(file)
+	if err != nil {
+		return 0, fmt.Errorf("reading file: %w", err)
+	}
+
+	var (
+		after   = before
+		changed []fix.Edit
+	)
+
+	for _, e := range edits {
+		res, err := fix.Apply(after, e)
+		if err != nil {
+			return 0, err
+		}
+
+		if !bytes.Equal(after, res) {
+			changed = append(changed, e)
+		}
+
+		after = res
+	}
+
+	if len(changed) == 0 {
+		return 0, nil
+	}
+
+	if dryRun {
+		diff, err := fix.Diff(report.DisplayPath(file), before, after)
+		if err != nil {
+			return 0, fmt.Errorf("computing diff: %w", err)
+		}
+
+		fmt.Fprint(out, diff)
+
+		return len(changed), nil
+	}
+
+	if err := fix.WriteFile(file, after); err != nil {
+		return 0, fmt.Errorf("writing file: %w", err)
+	}
+
+	for _, e := range changed {
+		fmt.Fprintf(out, "Fixed: %s\n", e.Description)
+	}
+
+	fmt.Fprintf(out, "Applied %d fix(es) to %s. Run the validation again to confirm.\n", len(changed), report.DisplayPath(file))
+
+	return len(changed), nil
+}
@@ -0,0 +1,75 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"go.uber.org/multierr"
+)
+
+func newOCMClient(env string) (*validator.OCMClientImpl, error) {
+	return validator.NewOCMClient(
+		validator.WithConnectOptions{
+			validator.WithAPIURL(envToOCMURL(env)),
+			validator.WithAccessToken(os.Getenv(ocmTokenEnvVar)),
+			validator.WithClientID(os.Getenv(ocmClientIDEnvVar)),
+			validator.WithClientSecret(os.Getenv(ocmClientSecretEnvVar)),
+		},
+	)
+}
+
+// envOCMClient dispatches OCM requests to a client connected to
+// the environment stored in the request context. This allows a
+// single Runner to validate addons for several environments.
+type envOCMClient struct {
+	clients map[string]*validator.OCMClientImpl
+}
+
+func newEnvOCMClient(envs ...string) (*envOCMClient, error) {
+	c := &envOCMClient{
+		clients: make(map[string]*validator.OCMClientImpl, len(envs)),
+	}
+
+	for _, env := range envs {
+		client, err := newOCMClient(env)
+		if err != nil {
+			_ = c.CloseConnection()
+
+			return nil, fmt.Errorf("initializing ocm client for %q: %w", env, err)
+		}
+
+		c.clients[env] = client
+	}
+
+	return c, nil
+}
+
+func (c *envOCMClient) QuotaRuleExists(ctx context.Context, quotaName string) (bool, error) {
+	env, _ := ctx.Value(envKey{}).(string)
+
+	client, ok := c.clients[env]
+	if !ok {
+		return false, fmt.Errorf("no ocm client configured for environment %q", env)
+	}
+
+	return client.QuotaRuleExists(ctx, quotaName)
+}
+
+// CloseConnection releases the connections of all clients.
+func (c *envOCMClient) CloseConnection() error {
+	var err error
+
+	for _, client := range c.clients {
+		err = multierr.Append(err, client.CloseConnection())
+	}
+
+	return err
+}
+
+type envKey struct{}
+
+func withEnv(ctx context.Context, env string) context.Context {
+	return context.WithValue(ctx, envKey{}, env)
+}
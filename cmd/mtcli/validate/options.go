@@ -3,17 +3,47 @@ package validate
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/mt-sre/addon-metadata-operator/internal/report"
+	"github.com/mt-sre/addon-metadata-operator/pkg/utils"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
 	"github.com/spf13/pflag"
 	"golang.org/x/mod/semver"
 )
 
 type options struct {
-	Env                string
-	Version            string
-	Disabled           string
-	Enabled            string
-	ExcludedNamespaces []string
+	Env                 string
+	Version             string
+	Disabled            string
+	Enabled             string
+	ExcludedNamespaces  []string
+	Output              string
+	FailOn              string
+	ConfigFile          string
+	PrintConfig         bool
+	RetryMaxAttempts    int
+	RetryDelay          time.Duration
+	RetryBackoff        string
+	RetryMaxDelay       time.Duration
+	RetryMaxElapsedTime time.Duration
+	ValidatorTimeout    time.Duration
+	Parallelism         int
+	All                 bool
+	Concurrency         int
+	CacheDir            string
+	NoCache             bool
+	IndexCacheTTL       time.Duration
+	NoResultCache       bool
+	RefreshResultCache  bool
+	ResultCacheTTL      time.Duration
+	BundlesDir          string
+	CatalogDir          string
+	Strict              bool
+	Fix                 bool
+	DryRun              bool
+	Timings             bool
 }
 
 func (o *options) AddEnvFlag(flags *pflag.FlagSet) {
@@ -21,7 +51,7 @@ func (o *options) AddEnvFlag(flags *pflag.FlagSet) {
 		&o.Env,
 		"env",
 		o.Env,
-		"integration, stage or production",
+		"integration, stage or production. Multiple environments may be separated by ',' when combined with --all.",
 	)
 }
 
@@ -61,9 +91,315 @@ func (o *options) AddExcludedNamespacesFlag(flags *pflag.FlagSet) {
 	)
 }
 
+func (o *options) AddOutputFlag(flags *pflag.FlagSet) {
+	flags.StringVarP(
+		&o.Output,
+		"output",
+		"o",
+		o.Output,
+		fmt.Sprintf("Output format of the validation results. One of: %s.", strings.Join(formatNames(), ", ")),
+	)
+}
+
+func (o *options) AddFailOnFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.FailOn,
+		"fail-on",
+		o.FailOn,
+		"Lowest severity of validation failures which results in a non-zero exit code. One of: warning, error.",
+	)
+}
+
+func (o *options) AddConfigFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.ConfigFile,
+		"config",
+		o.ConfigFile,
+		fmt.Sprintf("Path to a config file. Defaults to the first '%s' found in the addon dir or its parents up to the repository root.", configFileName),
+	)
+}
+
+func (o *options) AddPrintConfigFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.PrintConfig,
+		"print-config",
+		o.PrintConfig,
+		"Print the resolved configuration and exit without validating.",
+	)
+}
+
+func (o *options) AddAllFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.All,
+		"all",
+		o.All,
+		"Validate every addon found in the given addons root directory.",
+	)
+}
+
+func (o *options) AddConcurrencyFlag(flags *pflag.FlagSet) {
+	flags.IntVar(
+		&o.Concurrency,
+		"concurrency",
+		o.Concurrency,
+		"Maximum number of addons validated at the same time when combined with --all.",
+	)
+}
+
+func (o *options) AddValidatorLimitFlags(flags *pflag.FlagSet) {
+	flags.DurationVar(
+		&o.ValidatorTimeout,
+		"validator-timeout",
+		o.ValidatorTimeout,
+		"Time after which a running validator is cancelled and reported as timed out. Zero means no timeout.",
+	)
+	flags.IntVar(
+		&o.Parallelism,
+		"validator-parallelism",
+		o.Parallelism,
+		"Maximum number of validators run at the same time for each addon. Zero means no limit.",
+	)
+}
+
+func (o *options) AddRetryFlags(flags *pflag.FlagSet) {
+	flags.IntVar(
+		&o.RetryMaxAttempts,
+		"retry-max-attempts",
+		o.RetryMaxAttempts,
+		"Maximum number of attempts of validators which fail because a service is unavailable.",
+	)
+	flags.DurationVar(
+		&o.RetryDelay,
+		"retry-delay",
+		o.RetryDelay,
+		"Time waited before the first retry of a validator.",
+	)
+	flags.StringVar(
+		&o.RetryBackoff,
+		"retry-backoff",
+		o.RetryBackoff,
+		"Backoff between the attempts of a validator. One of: constant, exponential, decorrelatedJitter.",
+	)
+	flags.DurationVar(
+		&o.RetryMaxDelay,
+		"retry-max-delay",
+		o.RetryMaxDelay,
+		"Longest time waited between the attempts of a validator. Zero means no limit.",
+	)
+	flags.DurationVar(
+		&o.RetryMaxElapsedTime,
+		"retry-max-elapsed-time",
+		o.RetryMaxElapsedTime,
+		"Time after the first attempt of a validator after which it is no longer retried. Zero means no limit.",
+	)
+}
+
+func (o *options) AddCacheFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.CacheDir,
+		"cache-dir",
+		o.CacheDir,
+		"Directory in which extracted bundles and index images are cached. Defaults to '$XDG_CACHE_HOME/mtcli'.",
+	)
+	flags.BoolVar(
+		&o.NoCache,
+		"no-cache",
+		o.NoCache,
+		"Do not read from or write to the on-disk cache.",
+	)
+	flags.DurationVar(
+		&o.IndexCacheTTL,
+		"index-cache-ttl",
+		o.IndexCacheTTL,
+		"Duration for which bundle images listed from an index image referenced by tag are cached.",
+	)
+	flags.BoolVar(
+		&o.NoResultCache,
+		"no-result-cache",
+		o.NoResultCache,
+		"Run all validators instead of reusing their cached results. Implied by --no-cache.",
+	)
+	flags.BoolVar(
+		&o.RefreshResultCache,
+		"refresh-result-cache",
+		o.RefreshResultCache,
+		"Run all validators and replace their cached results.",
+	)
+	flags.DurationVar(
+		&o.ResultCacheTTL,
+		"result-cache-ttl",
+		o.ResultCacheTTL,
+		"Duration for which validator results are cached.",
+	)
+}
+
+func (o *options) AddBundlesDirFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.BundlesDir,
+		"bundles-dir",
+		o.BundlesDir,
+		"Load bundles from a local directory tree of unpacked bundles instead of the addon's index image. Can't be combined with --catalog-dir.",
+	)
+}
+
+func (o *options) AddCatalogDirFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.CatalogDir,
+		"catalog-dir",
+		o.CatalogDir,
+		"Resolve bundle images from a local file-based catalog directory instead of the addon's index image. Can't be combined with --bundles-dir.",
+	)
+}
+
+func (o *options) AddStrictFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.Strict,
+		"strict",
+		o.Strict,
+		"Report unknown fields, duplicate keys and values of the wrong type in the metadata and imageset files.",
+	)
+}
+
+func (o *options) AddFixFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.Fix,
+		"fix",
+		o.Fix,
+		"Apply the fixes suggested by validators to the addon metadata file.",
+	)
+	flags.BoolVar(
+		&o.DryRun,
+		"dry-run",
+		o.DryRun,
+		"Print the changes '--fix' would make as a diff instead of writing them.",
+	)
+}
+
+func (o *options) AddTimingsFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.Timings,
+		"timings",
+		o.Timings,
+		"Print the time spent on each validator and on extracting bundles versus running validators to stderr.",
+	)
+}
+
+// MetaLoader returns a loader for the metadata of the addon in
+// 'addonDir' for the given environment.
+func (o *options) MetaLoader(addonDir, env string) utils.MetaLoader {
+	var loaderOpts []utils.MetaLoaderOption
+
+	if o.Strict {
+		loaderOpts = append(loaderOpts, utils.WithStrictDecoding())
+	}
+
+	return utils.NewMetaLoader(addonDir, env, o.Version, loaderOpts...)
+}
+
+// Envs returns the environments given by the '--env' flag.
+func (o *options) Envs() []string {
+	return strings.Split(o.Env, ",")
+}
+
+// FailOnSeverity returns the parsed value of the '--fail-on' flag.
+func (o *options) FailOnSeverity() (validator.Severity, error) {
+	sev, err := validator.ParseSeverity(o.FailOn)
+	if err != nil {
+		return sev, err
+	}
+
+	if sev != validator.SeverityWarning && sev != validator.SeverityError {
+		return sev, fmt.Errorf("'%s' is not a valid severity threshold; must be one of 'warning' or 'error'", o.FailOn)
+	}
+
+	return sev, nil
+}
+
+// RetryBackoffPolicy returns the backoff policy of retried validators
+// which starts out waiting 'RetryDelay' and, unless zero, never waits
+// longer than 'RetryMaxDelay'.
+func (o *options) RetryBackoffPolicy() (validator.Backoff, error) {
+	switch o.RetryBackoff {
+	case "constant":
+		return validator.ConstantBackoff(o.RetryDelay), nil
+	case "exponential":
+		return validator.ExponentialBackoff{
+			Initial: o.RetryDelay,
+			Max:     o.RetryMaxDelay,
+		}, nil
+	case "decorrelatedJitter":
+		return validator.DecorrelatedJitterBackoff{
+			Base: o.RetryDelay,
+			Max:  o.RetryMaxDelay,
+		}, nil
+	default:
+		return nil, fmt.Errorf("'%s' is not a valid retry backoff; must be one of 'constant', 'exponential' or 'decorrelatedJitter'", o.RetryBackoff)
+	}
+}
+
 func (o *options) VerifyFlags() error {
-	if !isValidEnv(o.Env) {
-		return fmt.Errorf("'%s' is not a valid environment; must be one of 'integration', 'stage' or 'production'", o.Env)
+	envs := o.Envs()
+
+	for _, env := range envs {
+		if !isValidEnv(env) {
+			return fmt.Errorf("'%s' is not a valid environment; must be one of 'integration', 'stage' or 'production'", env)
+		}
+	}
+
+	if len(envs) > 1 && !o.All {
+		return errors.New("multiple environments can only be validated in combination with '--all'")
+	}
+
+	if o.IndexCacheTTL <= 0 {
+		return fmt.Errorf("'%s' is not a valid index cache ttl; must be greater than 0", o.IndexCacheTTL)
+	}
+
+	if o.ResultCacheTTL <= 0 {
+		return fmt.Errorf("'%s' is not a valid result cache ttl; must be greater than 0", o.ResultCacheTTL)
+	}
+
+	if o.Concurrency < 1 {
+		return fmt.Errorf("'%d' is not a valid concurrency; must be at least 1", o.Concurrency)
+	}
+
+	if o.ValidatorTimeout < 0 {
+		return fmt.Errorf("'%s' is not a valid validator timeout; must not be negative", o.ValidatorTimeout)
+	}
+
+	if o.Parallelism < 0 {
+		return fmt.Errorf("'%d' is not a valid validator parallelism; must not be negative", o.Parallelism)
+	}
+
+	if _, err := o.RetryBackoffPolicy(); err != nil {
+		return err
+	}
+
+	if o.RetryDelay < 0 || o.RetryMaxDelay < 0 || o.RetryMaxElapsedTime < 0 {
+		return errors.New("retry delays and the max elapsed time must not be negative")
+	}
+
+	if _, err := report.ParseFormat(o.Output); err != nil {
+		return fmt.Errorf("'%s' is not a valid output format; must be one of %s", o.Output, strings.Join(formatNames(), ", "))
+	}
+
+	if _, err := o.FailOnSeverity(); err != nil {
+		return fmt.Errorf("parsing '--fail-on' option argument: %w", err)
+	}
+
+	if o.BundlesDir != "" && o.CatalogDir != "" {
+		return errors.New("'--bundles-dir' and '--catalog-dir' are mutually exclusive options")
+	}
+
+	if o.DryRun && !o.Fix {
+		return errors.New("'--dry-run' can only be used in combination with '--fix'")
+	}
+
+	if o.Fix && o.All {
+		return errors.New("'--fix' and '--all' are mutually exclusive options")
+	}
+
+	if o.Disabled != "" && o.Enabled != "" {
+		return errors.New("'--disabled' and '--enabled' are mutually exclusive options")
 	}
 
 	// unset version is OK, will fallback to meta.addonImageSetVersion
@@ -76,10 +412,6 @@ func (o *options) VerifyFlags() error {
 		return fmt.Errorf("'%s' is not a valid version; must be one of 'latest' or match 'MAJOR.MINOR.PATCH'", o.Version)
 	}
 
-	if o.Disabled != "" && o.Enabled != "" {
-		return errors.New("'--disabled' and '--enabled' are mutually exclusive options")
-	}
-
 	return nil
 }
 
@@ -91,3 +423,15 @@ func isValidEnv(env string) bool {
 		return false
 	}
 }
+
+func formatNames() []string {
+	formats := report.Formats()
+
+	names := make([]string, 0, len(formats))
+
+	for _, f := range formats {
+		names = append(names, string(f))
+	}
+
+	return names
+}
@@ -0,0 +1,249 @@
+//go:build !unit
+// +build !unit
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var errUnknownIndexImage = errors.New("unknown index image")
+
+const (
+	namespace             = "default"
+	disconnectedNamespace = "disconnected"
+)
+
+var _ = Describe("AddonMetadata reconciler", func() {
+	When("the latest imageset of a valid addon is referenced", func() {
+		It("should record a valid status", func(ctx context.Context) {
+			addon := newAddonMetadata(namespace, "valid-addon")
+			addon.Spec.ImageSetVersion = stringPtr("latest")
+
+			createImageSet(ctx, namespace, "valid-addon.v0.1.0", "quay.io/osd-addons/valid-addon-index:0.1.0")
+			createImageSet(ctx, namespace, "valid-addon.v0.2.0", "quay.io/osd-addons/valid-addon-index:0.2.0")
+
+			_extractor.SetBundles("quay.io/osd-addons/valid-addon-index:0.2.0", newBundle("valid-addon", "0.2.0"))
+			_ocm.AddQuotaRule(addon.Spec.OcmQuotaName)
+
+			Expect(_client.Create(ctx, addon)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				status := getStatus(ctx, g, addon)
+
+				expectCondition(g, status, addonsv1alpha1.AddonMetadataBundlesResolved, metav1.ConditionTrue, addonsv1alpha1.AddonMetadataReasonBundlesFound)
+				expectCondition(g, status, addonsv1alpha1.AddonMetadataValid, metav1.ConditionTrue, addonsv1alpha1.AddonMetadataReasonValidationSucceeded)
+				expectCondition(g, status, addonsv1alpha1.AddonMetadataOCMQuotaVerified, metav1.ConditionTrue, addonsv1alpha1.AddonMetadataReasonQuotaRuleFound)
+
+				g.Expect(status.LastValidatedImageSetVersion).To(Equal("0.2.0"))
+				g.Expect(status.Bundles).To(ConsistOf(addonsv1alpha1.BundleReference{
+					Name:    "valid-addon.v0.2.0",
+					Version: "0.2.0",
+					Image:   "quay.io/osd-addons/valid-addon-bundle:0.2.0",
+				}))
+			}, "30s").Should(Succeed())
+		})
+	})
+
+	When("the addon fails validation", func() {
+		It("should report the failed validators", func(ctx context.Context) {
+			addon := newAddonMetadata(namespace, "invalid-addon")
+			addon.Spec.IndexImage = stringPtr("quay.io/osd-addons/invalid-addon-index:0.1.0")
+			addon.Spec.DefaultChannel = "beta"
+
+			_extractor.SetBundles(*addon.Spec.IndexImage, newBundle("invalid-addon", "0.1.0"))
+
+			Expect(_client.Create(ctx, addon)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				status := getStatus(ctx, g, addon)
+
+				expectCondition(g, status, addonsv1alpha1.AddonMetadataBundlesResolved, metav1.ConditionTrue, addonsv1alpha1.AddonMetadataReasonBundlesFound)
+				expectCondition(g, status, addonsv1alpha1.AddonMetadataValid, metav1.ConditionFalse, addonsv1alpha1.AddonMetadataReasonValidationFailed)
+				expectCondition(g, status, addonsv1alpha1.AddonMetadataOCMQuotaVerified, metav1.ConditionFalse, addonsv1alpha1.AddonMetadataReasonQuotaRuleMissing)
+
+				valid := meta.FindStatusCondition(status.Conditions, addonsv1alpha1.AddonMetadataValid)
+				g.Expect(valid.Message).To(ContainSubstring("AM0001"))
+				g.Expect(valid.Message).To(ContainSubstring("AM0011"))
+
+				g.Expect(status.LastValidatedImageSetVersion).To(BeEmpty())
+			}, "30s").Should(Succeed())
+		})
+	})
+
+	When("the referenced imageset does not exist yet", func() {
+		It("should validate the addon once the imageset is created", func(ctx context.Context) {
+			addon := newAddonMetadata(namespace, "pending-addon")
+			addon.Spec.ImageSetVersion = stringPtr("1.0.0")
+
+			_extractor.SetBundles("quay.io/osd-addons/pending-addon-index:1.0.0", newBundle("pending-addon", "1.0.0"))
+			_ocm.AddQuotaRule(addon.Spec.OcmQuotaName)
+
+			Expect(_client.Create(ctx, addon)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				status := getStatus(ctx, g, addon)
+
+				expectCondition(g, status, addonsv1alpha1.AddonMetadataBundlesResolved, metav1.ConditionFalse, addonsv1alpha1.AddonMetadataReasonImageSetUnresolved)
+				expectCondition(g, status, addonsv1alpha1.AddonMetadataValid, metav1.ConditionUnknown, addonsv1alpha1.AddonMetadataReasonBundlesNotResolved)
+			}, "30s").Should(Succeed())
+
+			createImageSet(ctx, namespace, "pending-addon.v1.0.0", "quay.io/osd-addons/pending-addon-index:1.0.0")
+
+			Eventually(func(g Gomega) {
+				status := getStatus(ctx, g, addon)
+
+				expectCondition(g, status, addonsv1alpha1.AddonMetadataBundlesResolved, metav1.ConditionTrue, addonsv1alpha1.AddonMetadataReasonBundlesFound)
+				expectCondition(g, status, addonsv1alpha1.AddonMetadataValid, metav1.ConditionTrue, addonsv1alpha1.AddonMetadataReasonValidationSucceeded)
+
+				g.Expect(status.LastValidatedImageSetVersion).To(Equal("1.0.0"))
+			}, "30s").Should(Succeed())
+		})
+	})
+
+	When("no OCM credentials are configured", func() {
+		It("should validate the addon without checking the quota", func(ctx context.Context) {
+			addon := newAddonMetadata(disconnectedNamespace, "offline-addon")
+			addon.Spec.IndexImage = stringPtr("quay.io/osd-addons/offline-addon-index:0.1.0")
+
+			_extractor.SetBundles(*addon.Spec.IndexImage, newBundle("offline-addon", "0.1.0"))
+
+			Expect(_client.Create(ctx, addon)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				status := getStatus(ctx, g, addon)
+
+				expectCondition(g, status, addonsv1alpha1.AddonMetadataBundlesResolved, metav1.ConditionTrue, addonsv1alpha1.AddonMetadataReasonBundlesFound)
+				expectCondition(g, status, addonsv1alpha1.AddonMetadataValid, metav1.ConditionTrue, addonsv1alpha1.AddonMetadataReasonValidationSucceeded)
+				expectCondition(g, status, addonsv1alpha1.AddonMetadataOCMQuotaVerified, metav1.ConditionUnknown, addonsv1alpha1.AddonMetadataReasonQuotaNotChecked)
+			}, "30s").Should(Succeed())
+		})
+	})
+
+	When("the bundles cannot be extracted", func() {
+		It("should report the extraction failure", func(ctx context.Context) {
+			addon := newAddonMetadata(namespace, "unreachable-addon")
+			addon.Spec.IndexImage = stringPtr("quay.io/osd-addons/unreachable-addon-index:0.1.0")
+
+			Expect(_client.Create(ctx, addon)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				status := getStatus(ctx, g, addon)
+
+				expectCondition(g, status, addonsv1alpha1.AddonMetadataBundlesResolved, metav1.ConditionFalse, addonsv1alpha1.AddonMetadataReasonExtractionFailed)
+				expectCondition(g, status, addonsv1alpha1.AddonMetadataValid, metav1.ConditionUnknown, addonsv1alpha1.AddonMetadataReasonBundlesNotResolved)
+				expectCondition(g, status, addonsv1alpha1.AddonMetadataOCMQuotaVerified, metav1.ConditionUnknown, addonsv1alpha1.AddonMetadataReasonBundlesNotResolved)
+
+				g.Expect(status.Bundles).To(BeEmpty())
+			}, "30s").Should(Succeed())
+		})
+	})
+})
+
+func newAddonMetadata(namespace, id string) *addonsv1alpha1.AddonMetadata {
+	return &addonsv1alpha1.AddonMetadata{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      id,
+			Namespace: namespace,
+		},
+		Spec: addonsv1alpha1.AddonMetadataSpec{
+			ID:              id,
+			Name:            id,
+			Description:     "Test addon " + id,
+			Label:           "api.openshift.com/addon-" + id,
+			Enabled:         true,
+			InstallMode:     "OwnNamespace",
+			TargetNamespace: "redhat-" + id,
+			Namespaces:      []string{"redhat-" + id},
+			OcmQuotaName:    "addon-" + id,
+			OperatorName:    id,
+			DefaultChannel:  "alpha",
+		},
+	}
+}
+
+func createImageSet(ctx context.Context, namespace, name, indexImage string) {
+	imageSet := &addonsv1alpha1.AddonImageSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: addonsv1alpha1.AddonImageSetSpec{
+			Name:       name,
+			IndexImage: indexImage,
+		},
+	}
+
+	Expect(_client.Create(ctx, imageSet)).To(Succeed())
+}
+
+func newBundle(pkg, version string) operator.Bundle {
+	return operator.Bundle{
+		Name:        fmt.Sprintf("%s.v%s", pkg, version),
+		Version:     version,
+		BundleImage: fmt.Sprintf("quay.io/osd-addons/%s-bundle:%s", pkg, version),
+		Package:     pkg,
+		Channels:    []string{"alpha"},
+	}
+}
+
+func getStatus(ctx context.Context, g Gomega, addon *addonsv1alpha1.AddonMetadata) addonsv1alpha1.AddonMetadataStatus {
+	var res addonsv1alpha1.AddonMetadata
+
+	g.Expect(_client.Get(ctx, client.ObjectKeyFromObject(addon), &res)).To(Succeed())
+
+	return res.Status
+}
+
+func expectCondition(g Gomega, status addonsv1alpha1.AddonMetadataStatus, condType string, condStatus metav1.ConditionStatus, reason string) {
+	cond := meta.FindStatusCondition(status.Conditions, condType)
+
+	g.Expect(cond).ToNot(BeNil(), "condition %q not found", condType)
+	g.Expect(cond.Status).To(Equal(condStatus), "condition %q: %s", condType, cond.Message)
+	g.Expect(cond.Reason).To(Equal(reason), "condition %q: %s", condType, cond.Message)
+}
+
+func stringPtr(s string) *string { return &s }
+
+// channelValidator fails if none of the bundles is
+// part of the default channel of the addon.
+type channelValidator struct {
+	*validator.Base
+}
+
+func newChannelValidator(validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(1,
+		validator.BaseName("default_channel"),
+		validator.BaseDesc("Ensures a bundle is part of the default channel"),
+		validator.BaseRequires(validator.RequiresBundles),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &channelValidator{Base: base}, nil
+}
+
+func (v *channelValidator) Run(_ context.Context, mb types.MetaBundle) validator.Result {
+	for _, b := range mb.Bundles {
+		for _, ch := range b.Channels {
+			if ch == mb.AddonMeta.DefaultChannel {
+				return v.Success()
+			}
+		}
+	}
+
+	return v.Fail(fmt.Sprintf("no bundle is part of channel %q", mb.AddonMeta.DefaultChannel))
+}
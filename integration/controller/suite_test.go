@@ -0,0 +1,356 @@
+//go:build !unit
+// +build !unit
+
+package controller
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/internal/controller"
+	"github.com/mt-sre/addon-metadata-operator/internal/webhook"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/am0011"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var (
+	_client    client.Client
+	_extractor = newFakeExtractor()
+	_ocm       = newFakeOCMClient()
+	_warnings  = &warningRecorder{}
+)
+
+func TestController(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "controller suite")
+}
+
+var _ = BeforeSuite(func() {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		Skip("KUBEBUILDER_ASSETS must point to the envtest binaries")
+	}
+
+	env := &envtest.Environment{
+		CRDs: []*apiextensionsv1.CustomResourceDefinition{
+			newCRD("addonmetadata", "AddonMetadata"),
+			newCRD("addonimagesets", "AddonImageSet"),
+		},
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			ValidatingWebhooks: []*admissionregistrationv1.ValidatingWebhookConfiguration{
+				newWebhookConfiguration(),
+			},
+		},
+	}
+
+	cfg, err := env.Start()
+	Expect(err).ToNot(HaveOccurred())
+
+	DeferCleanup(env.Stop)
+
+	scheme := runtime.NewScheme()
+	Expect(addonsv1alpha1.AddToScheme(scheme)).To(Succeed())
+	Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	// Warnings returned by the webhook are recorded
+	// rather than logged by the client.
+	clientCfg := *cfg
+	clientCfg.WarningHandler = _warnings
+
+	_client, err = client.New(&clientCfg, client.Options{
+		Scheme:         scheme,
+		WarningHandler: client.WarningHandlerOptions{SuppressWarnings: true},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	webhookOpts := env.WebhookInstallOptions
+
+	// The reconciler of each manager only watches its own namespace.
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:  scheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+		Cache: cache.Options{
+			DefaultNamespaces: map[string]cache.Config{namespace: {}},
+		},
+		WebhookServer: ctrlwebhook.NewServer(ctrlwebhook.Options{
+			Host:    webhookOpts.LocalServingHost,
+			Port:    webhookOpts.LocalServingPort,
+			CertDir: webhookOpts.LocalServingCertDir,
+		}),
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	runner, err := validator.NewRunner(
+		validator.WithInitializers{
+			newChannelValidator,
+			newLabelValidator,
+			newDescriptionValidator,
+			am0011.NewOCMSKURuleExists,
+		},
+		validator.WithOCMClient{OCMClient: _ocm},
+	)
+	Expect(err).ToNot(HaveOccurred())
+
+	reconciler, err := controller.NewAddonMetadataReconciler(mgr.GetClient(),
+		controller.WithExtractor{BundleExtractor: _extractor},
+		controller.WithRunner{Runner: runner},
+		controller.WithLogger{Logger: GinkgoLogr},
+	)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(reconciler.SetupWithManager(mgr)).To(Succeed())
+
+	wh, err := webhook.NewAddonMetadataWebhook(mgr.GetClient(),
+		webhook.WithRunner{Runner: runner},
+		webhook.WithLogger{Logger: GinkgoLogr},
+	)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(wh.SetupWithManager(mgr)).To(Succeed())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	DeferCleanup(cancel)
+
+	go func() {
+		defer GinkgoRecover()
+
+		Expect(mgr.Start(ctx)).To(Succeed())
+	}()
+
+	started := mgr.GetWebhookServer().StartedChecker()
+
+	Eventually(func() error { return started(nil) }, "30s").Should(Succeed())
+
+	startDisconnectedManager(ctx, cfg, scheme)
+})
+
+// startDisconnectedManager starts a reconciler for the addons in
+// 'disconnectedNamespace' which, like the operator without OCM
+// credentials, uses a disconnected OCM client.
+func startDisconnectedManager(ctx context.Context, cfg *rest.Config, scheme *runtime.Scheme) {
+	Expect(_client.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: disconnectedNamespace},
+	})).To(Succeed())
+
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:  scheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+		Cache: cache.Options{
+			DefaultNamespaces: map[string]cache.Config{disconnectedNamespace: {}},
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	runner, err := validator.NewRunner(
+		validator.WithInitializers{
+			newChannelValidator,
+			newLabelValidator,
+			newDescriptionValidator,
+			am0011.NewOCMSKURuleExists,
+		},
+		validator.WithOCMClient{OCMClient: validator.NewDisconnectedOCMClient()},
+	)
+	Expect(err).ToNot(HaveOccurred())
+
+	reconciler, err := controller.NewAddonMetadataReconciler(mgr.GetClient(),
+		controller.WithExtractor{BundleExtractor: _extractor},
+		controller.WithRunner{Runner: runner},
+		controller.WithLogger{Logger: GinkgoLogr},
+		controller.WithSkipQuotaCheck(true),
+	)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(reconciler.SetupWithManager(mgr)).To(Succeed())
+
+	go func() {
+		defer GinkgoRecover()
+
+		Expect(mgr.Start(ctx)).To(Succeed())
+	}()
+}
+
+// newWebhookConfiguration returns the configuration of the validating
+// webhook. The service reference is replaced by envtest with the address
+// of the local webhook server.
+func newWebhookConfiguration() *admissionregistrationv1.ValidatingWebhookConfiguration {
+	path := webhook.ValidatingPath
+	failurePolicy := admissionregistrationv1.Fail
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+
+	return &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "addon-metadata-operator",
+		},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{
+				Name: "vaddonmetadata." + addonsv1alpha1.GroupVersion.Group,
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service: &admissionregistrationv1.ServiceReference{
+						Name:      "addon-metadata-operator",
+						Namespace: "default",
+						Path:      &path,
+					},
+				},
+				Rules: []admissionregistrationv1.RuleWithOperations{
+					{
+						Operations: []admissionregistrationv1.OperationType{
+							admissionregistrationv1.Create,
+							admissionregistrationv1.Update,
+						},
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   []string{addonsv1alpha1.GroupVersion.Group},
+							APIVersions: []string{addonsv1alpha1.GroupVersion.Version},
+							Resources:   []string{"addonmetadata"},
+						},
+					},
+				},
+				FailurePolicy:           &failurePolicy,
+				SideEffects:             &sideEffects,
+				AdmissionReviewVersions: []string{"v1"},
+			},
+		},
+	}
+}
+
+// newCRD returns a CRD for 'kind' of the addons API group. The schema
+// preserves unknown fields as the types only carry validation markers
+// for the metadata files.
+func newCRD(plural, kind string) *apiextensionsv1.CustomResourceDefinition {
+	preserve := true
+
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: plural + "." + addonsv1alpha1.GroupVersion.Group,
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: addonsv1alpha1.GroupVersion.Group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:   plural,
+				Kind:     kind,
+				ListKind: kind + "List",
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{
+					Name:    addonsv1alpha1.GroupVersion.Version,
+					Served:  true,
+					Storage: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"spec":   {Type: "object", XPreserveUnknownFields: &preserve},
+								"status": {Type: "object", XPreserveUnknownFields: &preserve},
+							},
+						},
+					},
+					Subresources: &apiextensionsv1.CustomResourceSubresources{
+						Status: &apiextensionsv1.CustomResourceSubresourceStatus{},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newFakeExtractor() *fakeExtractor {
+	return &fakeExtractor{
+		bundles: make(map[string][]operator.Bundle),
+	}
+}
+
+// fakeExtractor returns the bundles registered for an index image
+// and an error for unknown index images.
+type fakeExtractor struct {
+	lock    sync.Mutex
+	bundles map[string][]operator.Bundle
+}
+
+func (e *fakeExtractor) SetBundles(indexImage string, bundles ...operator.Bundle) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.bundles[indexImage] = bundles
+}
+
+func (e *fakeExtractor) ExtractBundles(_ context.Context, indexImage string, _ string) ([]operator.Bundle, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	bundles, ok := e.bundles[indexImage]
+	if !ok {
+		return nil, errUnknownIndexImage
+	}
+
+	return bundles, nil
+}
+
+func newFakeOCMClient() *fakeOCMClient {
+	return &fakeOCMClient{
+		quotas: make(map[string]bool),
+	}
+}
+
+// fakeOCMClient reports QuotaRules to exist for registered quota names.
+type fakeOCMClient struct {
+	lock   sync.Mutex
+	quotas map[string]bool
+}
+
+func (c *fakeOCMClient) AddQuotaRule(name string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.quotas[name] = true
+}
+
+func (c *fakeOCMClient) QuotaRuleExists(_ context.Context, name string) (bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.quotas[name], nil
+}
+
+// warningRecorder records the warnings returned by the API server.
+type warningRecorder struct {
+	lock     sync.Mutex
+	warnings []string
+}
+
+func (r *warningRecorder) HandleWarningHeader(_ int, _ string, text string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.warnings = append(r.warnings, text)
+}
+
+// Matching returns the recorded warnings containing 'substr'.
+func (r *warningRecorder) Matching(substr string) []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var res []string
+
+	for _, w := range r.warnings {
+		if strings.Contains(w, substr) {
+			res = append(res, w)
+		}
+	}
+
+	return res
+}
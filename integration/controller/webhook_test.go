@@ -0,0 +1,161 @@
+//go:build !unit
+// +build !unit
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AddonMetadata webhook", func() {
+	When("an addon fails a validator at error severity", func() {
+		It("should deny the addon", func(ctx context.Context) {
+			addon := newAddonMetadata(namespace, "unlabeled-addon")
+			addon.Spec.IndexImage = stringPtr("quay.io/osd-addons/unlabeled-addon-index:0.1.0")
+			addon.Spec.Label = "unlabeled"
+
+			err := _client.Create(ctx, addon)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("AM0002 label: spec.label"))
+		})
+	})
+
+	When("an addon fails a validator below error severity", func() {
+		It("should admit the addon with a warning", func(ctx context.Context) {
+			addon := newAddonMetadata(namespace, "undescribed-addon")
+			addon.Spec.IndexImage = stringPtr("quay.io/osd-addons/undescribed-addon-index:0.1.0")
+			addon.Spec.Description = ""
+
+			Expect(_client.Create(ctx, addon)).To(Succeed())
+			Expect(_warnings.Matching(`"undescribed-addon" has no description`)).To(HaveLen(1))
+		})
+	})
+
+	When("an update makes an admitted addon invalid", func() {
+		It("should deny the update", func(ctx context.Context) {
+			addon := newAddonMetadata(namespace, "relabeled-addon")
+			addon.Spec.IndexImage = stringPtr("quay.io/osd-addons/relabeled-addon-index:0.1.0")
+
+			Expect(_client.Create(ctx, addon)).To(Succeed())
+
+			patch := client.MergeFrom(addon.DeepCopy())
+			addon.Spec.Label = "relabeled"
+
+			err := _client.Patch(ctx, addon, patch)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("AM0002 label: spec.label"))
+		})
+	})
+
+	When("an addon would only fail bundle or network validators", func() {
+		It("should admit the addon", func(ctx context.Context) {
+			addon := newAddonMetadata(namespace, "unchecked-addon")
+			addon.Spec.IndexImage = stringPtr("quay.io/osd-addons/unchecked-addon-index:0.1.0")
+			addon.Spec.DefaultChannel = "beta"
+
+			Expect(_client.Create(ctx, addon)).To(Succeed())
+		})
+	})
+
+	When("an addon references an existing imageset", func() {
+		It("should validate the addon combined with the imageset", func(ctx context.Context) {
+			createImageSet(ctx, namespace, "combined-addon.v0.1.0", "quay.io/osd-addons/combined-addon-index:0.1.0")
+
+			addon := newAddonMetadata(namespace, "combined-addon")
+			addon.Spec.ImageSetVersion = stringPtr("0.1.0")
+			addon.Spec.Label = "combined"
+
+			err := _client.Create(ctx, addon)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("AM0002 label: spec.label"))
+		})
+	})
+
+	When("an addon references an imageset which does not exist yet", func() {
+		It("should admit the addon with a warning", func(ctx context.Context) {
+			addon := newAddonMetadata(namespace, "deferred-addon")
+			addon.Spec.ImageSetVersion = stringPtr("0.1.0")
+			addon.Spec.Label = "deferred"
+
+			Expect(_client.Create(ctx, addon)).To(Succeed())
+			Expect(_warnings.Matching("validation is deferred until the imageset exists")).ToNot(BeEmpty())
+		})
+	})
+
+	When("an addon references both an index image and an imageset", func() {
+		It("should deny the addon", func(ctx context.Context) {
+			addon := newAddonMetadata(namespace, "ambiguous-addon")
+			addon.Spec.IndexImage = stringPtr("quay.io/osd-addons/ambiguous-addon-index:0.1.0")
+			addon.Spec.ImageSetVersion = stringPtr("0.1.0")
+
+			err := _client.Create(ctx, addon)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("can't set both the 'indexImage' and the 'imageSetVersion' field"))
+		})
+	})
+})
+
+// labelValidator fails if the label of the addon
+// does not carry the OCM addon label prefix.
+type labelValidator struct {
+	*validator.Base
+}
+
+func newLabelValidator(validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(2,
+		validator.BaseName("label"),
+		validator.BaseDesc("Ensures the addon label carries the OCM prefix"),
+		validator.BaseSeverity(validator.SeverityError),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &labelValidator{Base: base}, nil
+}
+
+func (v *labelValidator) Run(_ context.Context, mb types.MetaBundle) validator.Result {
+	if strings.HasPrefix(mb.AddonMeta.Label, "api.openshift.com/addon-") {
+		return v.Success()
+	}
+
+	return v.FailWith(validator.NewFinding("label",
+		fmt.Sprintf("label %q is missing the 'api.openshift.com/addon-' prefix", mb.AddonMeta.Label),
+	))
+}
+
+// descriptionValidator warns if the addon has no description.
+type descriptionValidator struct {
+	*validator.Base
+}
+
+func newDescriptionValidator(validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(4,
+		validator.BaseName("description"),
+		validator.BaseDesc("Ensures the addon is described"),
+		validator.BaseSeverity(validator.SeverityWarning),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &descriptionValidator{Base: base}, nil
+}
+
+func (v *descriptionValidator) Run(_ context.Context, mb types.MetaBundle) validator.Result {
+	if mb.AddonMeta.Description != "" {
+		return v.Success()
+	}
+
+	return v.FailWith(validator.NewFinding("description",
+		fmt.Sprintf("addon %q has no description", mb.AddonMeta.ID),
+	))
+}
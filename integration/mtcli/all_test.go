@@ -0,0 +1,71 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os/exec"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/report"
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate subcommand", func() {
+	It("validates every addon below an addons root with --all", func() {
+		cmd := exec.Command(_binPath,
+			"validate", "--all",
+			"--env", "stage",
+			"--enabled", "AM0005",
+			"--output", "json",
+			"--concurrency", "2",
+			"--cache-dir", GinkgoT().TempDir(),
+			testutils.RootDir().TestData().MetadataV1().Legacy(),
+		)
+		cmd.Env = []string{
+			`OCM_TOKEN=""`,
+		}
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		// connectors-operator is known to fail AM0005
+		Eventually(session, "120s").Should(Exit(1))
+
+		// the reports are followed by the 'validation failed' error
+		var reports []report.AddonReport
+		Expect(json.NewDecoder(bytes.NewReader(session.Out.Contents())).Decode(&reports)).To(Succeed())
+
+		addons := make([]string, 0, len(reports))
+
+		for _, r := range reports {
+			Expect(r.Env).To(Equal("stage"))
+
+			addons = append(addons, r.Addon)
+		}
+
+		Expect(addons).To(Equal([]string{
+			"advanced-cluster-management",
+			"connectors-operator",
+			"ocm-addon-test-operator",
+			"reference-addon",
+		}))
+	})
+
+	It("rejects multiple environments without --all", func() {
+		cmd := exec.Command(_binPath,
+			"validate", "--env", "stage,production",
+			"--cache-dir", GinkgoT().TempDir(),
+			testutils.RootDir().TestData().MetadataV1().Legacy(),
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+	})
+})
@@ -0,0 +1,59 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate subcommand", func() {
+	addonDir := filepath.Join(testutils.RootDir().TestData().MetadataV1().Legacy(), "reference-addon")
+
+	It("validates against local bundles with --bundles-dir", func() {
+		cmd := exec.Command(_binPath,
+			"validate",
+			"--env", "stage",
+			"--enabled", "AM0003",
+			"--output", "json",
+			"--cache-dir", GinkgoT().TempDir(),
+			"--bundles-dir", filepath.Join(testutils.RootDir().TestData().Bundles(), "reference-addon"),
+			addonDir,
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit())
+
+		var results validator.ResultList
+		Expect(json.Unmarshal(session.Out.Contents(), &results)).To(Succeed())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].IsError()).To(BeFalse())
+	})
+
+	It("fails when the bundles dir has no bundles for the operator", func() {
+		cmd := exec.Command(_binPath,
+			"validate",
+			"--env", "stage",
+			"--cache-dir", GinkgoT().TempDir(),
+			"--bundles-dir", filepath.Join(testutils.RootDir().TestData().Bundles(), "rhods"),
+			addonDir,
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Out).To(Say("no bundles for package"))
+	})
+})
@@ -0,0 +1,140 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate subcommand", func() {
+	type configTestCase struct {
+		Config           string
+		Args             []string
+		ShouldSucceed    bool
+		ExpectedSnippets []string
+	}
+
+	DescribeTable("--print-config",
+		func(tc configTestCase) {
+			addonDir := GinkgoT().TempDir()
+
+			if tc.Config != "" {
+				err := os.WriteFile(filepath.Join(addonDir, ".mtcli.yaml"), []byte(tc.Config), 0o644)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			args := append([]string{"validate", "--print-config", "--cache-dir", GinkgoT().TempDir()}, tc.Args...)
+
+			cmd := exec.Command(_binPath, append(args, addonDir)...)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			exitCode := 0
+			if !tc.ShouldSucceed {
+				exitCode = 1
+			}
+
+			Eventually(session, "30s").Should(Exit(exitCode))
+
+			for _, snippet := range tc.ExpectedSnippets {
+				Expect(session.Out).To(Say(snippet))
+			}
+		},
+		Entry("defaults without config file",
+			configTestCase{
+				ShouldSucceed: true,
+				ExpectedSnippets: []string{
+					"config file: none",
+					"failOn: error",
+					"output: table",
+				},
+			},
+		),
+		Entry("values loaded from config file",
+			configTestCase{
+				Config: `
+disabled: [AM0005, AM0011]
+output: junit
+validators:
+  excludedNamespaces: [openshift-foo]
+  timeout: 30s
+  parallelism: 2
+retry:
+  maxAttempts: 2
+  delay: 1s
+  backoff: exponential
+  maxDelay: 10s
+  maxElapsedTime: 1m
+`,
+				ShouldSucceed: true,
+				ExpectedSnippets: []string{
+					"disabled:",
+					"- AM0005",
+					"- AM0011",
+					"output: junit",
+					"backoff: exponential",
+					"delay: 1s",
+					"maxAttempts: 2",
+					"maxDelay: 10s",
+					"maxElapsedTime: 1m0s",
+					"- openshift-foo",
+					"parallelism: 2",
+					"timeout: 30s",
+				},
+			},
+		),
+		Entry("flags override config file",
+			configTestCase{
+				Config: `
+disabled: [AM0005]
+output: junit
+`,
+				Args:          []string{"--enabled", "AM0001", "--output", "json"},
+				ShouldSucceed: true,
+				ExpectedSnippets: []string{
+					"enabled:",
+					"- AM0001",
+					"output: json",
+				},
+			},
+		),
+		Entry("retry flags override config file",
+			configTestCase{
+				Config: `
+retry:
+  maxAttempts: 5
+  delay: 1s
+  backoff: exponential
+`,
+				Args:          []string{"--retry-max-attempts", "2", "--retry-backoff", "constant"},
+				ShouldSucceed: true,
+				ExpectedSnippets: []string{
+					"backoff: constant",
+					"delay: 1s",
+					"maxAttempts: 2",
+				},
+			},
+		),
+		Entry("unknown retry backoffs are rejected",
+			configTestCase{
+				Config:        "retry:\n  backoff: linear\n",
+				ShouldSucceed: false,
+			},
+		),
+		Entry("unknown config keys are rejected",
+			configTestCase{
+				Config:        "disabeld: [AM0005]\n",
+				ShouldSucceed: false,
+			},
+		),
+	)
+})
@@ -0,0 +1,79 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	"github.com/mt-sre/addon-metadata-operator/pkg/diff"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("diff subcommand", func() {
+	addonDir := filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+
+	It("reports no changes between imagesets with identical metadata", func() {
+		cmd := exec.Command(_binPath,
+			"diff",
+			"--env", "stage",
+			"--from", "0.0.1",
+			"--to", "0.0.5",
+			"--cache-dir", GinkgoT().TempDir(),
+			"--skip-bundles",
+			addonDir,
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+		Expect(session.Out).To(Say(`Comparing reference-addon \(stage\): 0.0.1 -> 0.0.5`))
+		Expect(session.Out).To(Say("No changes."))
+	})
+
+	It("writes the report as JSON", func() {
+		cmd := exec.Command(_binPath,
+			"diff",
+			"--from", "0.0.2",
+			"--to", "latest",
+			"--cache-dir", GinkgoT().TempDir(),
+			"--skip-bundles",
+			"--output", "json",
+			addonDir,
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+
+		var report diff.Report
+		Expect(json.Unmarshal(session.Out.Contents(), &report)).To(Succeed())
+		Expect(report.Addon).To(Equal("reference-addon"))
+		Expect(report.Changes).To(BeEmpty())
+	})
+
+	It("fails for a missing imageset version", func() {
+		cmd := exec.Command(_binPath,
+			"diff",
+			"--from", "0.0.1",
+			"--to", "9.9.9",
+			"--cache-dir", GinkgoT().TempDir(),
+			"--skip-bundles",
+			addonDir,
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Out).To(Say(`loading addon metadata version "9.9.9"`))
+	})
+})
@@ -21,7 +21,12 @@ var _ = Describe("validate subcommand", func() {
 
 	DescribeTable("AM0002, AM0004, AM0011, AM0015 disabled",
 		func(tc disableTestCase) {
-			cmd := exec.Command(_binPath, "validate", "--env", "stage", "--disabled", "AM0002,AM0004,AM0011,AM0015", tc.MetadataPath)
+			cmd := exec.Command(_binPath,
+				"validate", "--env", "stage",
+				"--disabled", "AM0002,AM0004,AM0011,AM0015",
+				"--cache-dir", GinkgoT().TempDir(),
+				tc.MetadataPath,
+			)
 			cmd.Env = []string{
 				`OCM_TOKEN=""`,
 			}
@@ -0,0 +1,113 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate subcommand with fixes", func() {
+	var (
+		addonDir string
+		metaFile string
+		original string
+	)
+
+	BeforeEach(func() {
+		src := filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+		addonDir = filepath.Join(GinkgoT().TempDir(), "reference-addon")
+
+		for _, dir := range []string{
+			filepath.Join("metadata", "stage"),
+			filepath.Join("addonimagesets", "stage"),
+		} {
+			Expect(os.MkdirAll(filepath.Join(addonDir, dir), 0o755)).To(Succeed())
+
+			entries, err := os.ReadDir(filepath.Join(src, dir))
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, e := range entries {
+				data, err := os.ReadFile(filepath.Join(src, dir, e.Name()))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(os.WriteFile(filepath.Join(addonDir, dir, e.Name()), data, 0o644)).To(Succeed())
+			}
+		}
+
+		metaFile = filepath.Join(addonDir, "metadata", "stage", "addon.yaml")
+
+		data, err := os.ReadFile(metaFile)
+		Expect(err).ToNot(HaveOccurred())
+
+		original = strings.Replace(string(data),
+			"label: api.openshift.com/addon-reference-addon",
+			"label: api.openshift.com/addon-reference # wrong addon",
+			1,
+		)
+		Expect(os.WriteFile(metaFile, []byte(original), 0o644)).To(Succeed())
+	})
+
+	run := func(args ...string) *Session {
+		cmd := exec.Command(_binPath, append([]string{
+			"validate", "--env", "stage",
+			"--enabled", "AM0002",
+			"--output", "json",
+			"--cache-dir", GinkgoT().TempDir(),
+			"--bundles-dir", filepath.Join(testutils.RootDir().TestData().Bundles(), "reference-addon"),
+		}, append(args, addonDir)...)...)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		return session
+	}
+
+	It("prints the suggested fixes as a diff in dry-run mode", func() {
+		session := run("--fix", "--dry-run")
+
+		Eventually(session, "30s").Should(Exit(1))
+
+		Expect(string(session.Err.Contents())).To(ContainSubstring(
+			"-label: api.openshift.com/addon-reference # wrong addon\n+label: api.openshift.com/addon-reference-addon # wrong addon\n",
+		))
+
+		data, err := os.ReadFile(metaFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(original))
+	})
+
+	It("applies the suggested fixes preserving comments", func() {
+		session := run("--fix")
+
+		Eventually(session, "30s").Should(Exit(1))
+
+		Expect(string(session.Err.Contents())).To(ContainSubstring(
+			"Fixed: set label to 'api.openshift.com/addon-reference-addon'",
+		))
+
+		data, err := os.ReadFile(metaFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(strings.Replace(original,
+			"label: api.openshift.com/addon-reference # wrong addon",
+			"label: api.openshift.com/addon-reference-addon # wrong addon",
+			1,
+		)))
+
+		Eventually(run(), "30s").Should(Exit(0))
+	})
+
+	It("requires --fix for --dry-run", func() {
+		session := run("--dry-run")
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(string(session.Out.Contents())).To(ContainSubstring("'--dry-run' can only be used in combination with '--fix'"))
+	})
+})
@@ -21,7 +21,12 @@ var _ = Describe("validate subcommand", func() {
 
 	DescribeTable("AM0002 enabled",
 		func(tc labelTestCase) {
-			cmd := exec.Command(_binPath, "validate", "--env", "stage", "--enabled", "AM0002", tc.MetadataPath)
+			cmd := exec.Command(_binPath,
+				"validate", "--env", "stage",
+				"--enabled", "AM0002",
+				"--cache-dir", GinkgoT().TempDir(),
+				tc.MetadataPath,
+			)
 			cmd.Env = []string{
 				`OCM_TOKEN=""`,
 			}
@@ -27,6 +27,7 @@ var _ = Describe("validate subcommand", func() {
 				_binPath, "validate", "--env", "stage",
 				"--enabled", "AM0008",
 				"--excluded-namespaces", strings.Join(tc.ExcludedNamespaces, ","),
+				"--cache-dir", GinkgoT().TempDir(),
 				tc.MetadataPath,
 			)
 			cmd.Env = []string{
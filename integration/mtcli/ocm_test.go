@@ -0,0 +1,58 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/convert"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("ocm export subcommand", func() {
+	addonDir := filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+
+	It("prints the OCM payload of the reference addon", func() {
+		cmd := exec.Command(_binPath,
+			"ocm", "export",
+			"--env", "stage",
+			"--version", "0.0.2",
+			addonDir,
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+
+		var payload convert.Payload
+		Expect(json.Unmarshal(session.Out.Contents(), &payload)).To(Succeed())
+		Expect(payload.AddOn.ID).To(Equal("reference-addon"))
+		Expect(payload.AddOn.InstallMode).To(Equal(convert.InstallModeOwnNamespace))
+		Expect(payload.AddOn.Version).ToNot(BeNil())
+		Expect(payload.AddOn.Version.ID).To(Equal("0.0.2"))
+		Expect(payload.Version.ID).To(Equal("0.0.2"))
+		Expect(payload.Version.Channel).To(Equal("alpha"))
+	})
+
+	It("fails for a missing imageset version", func() {
+		cmd := exec.Command(_binPath,
+			"ocm", "export",
+			"--version", "9.9.9",
+			addonDir,
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Out).To(Say("loading addon metadata"))
+	})
+})
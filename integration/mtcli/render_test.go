@@ -0,0 +1,55 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("render subcommand", func() {
+	addonDir := filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+
+	It("renders the objects of the reference addon", func() {
+		cmd := exec.Command(_binPath,
+			"render",
+			"--env", "stage",
+			"--version", "latest",
+			addonDir,
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+		Expect(session.Out).To(Say("kind: Namespace\nmetadata:\n  name: redhat-reference-addon\n"))
+		Expect(session.Out).To(Say("---\n"))
+		Expect(session.Out).To(Say("kind: OperatorGroup"))
+		Expect(session.Out).To(Say("targetNamespaces:\n  - redhat-reference-addon\n"))
+		Expect(session.Out).To(Say("kind: CatalogSource"))
+		Expect(session.Out).To(Say("image: quay.io/osd-addons/reference-addon-index@sha256:0c8b02008f2c"))
+		Expect(session.Out).To(Say("kind: Subscription"))
+		Expect(session.Out).To(Say("name: reference-addon\n"))
+	})
+
+	It("fails for an invalid version", func() {
+		cmd := exec.Command(_binPath,
+			"render",
+			"--version", "next",
+			addonDir,
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Out).To(Say(`'next' is not a valid version`))
+	})
+})
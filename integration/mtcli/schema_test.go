@@ -0,0 +1,53 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("schema generate subcommand", func() {
+	schemaDir := filepath.Join(string(testutils.RootDir()), "docs", "schemas")
+
+	DescribeTable("prints the published schema",
+		func(kind, format, file string) {
+			cmd := exec.Command(_binPath,
+				"schema", "generate", kind,
+				"--format", format,
+			)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "10s").Should(Exit(0))
+
+			expected, err := os.ReadFile(filepath.Join(schemaDir, file))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(session.Out.Contents()).To(MatchJSON(expected))
+		},
+		Entry("addon JSON Schema", "addon", "jsonschema", "addon.schema.json"),
+		Entry("imageset OpenAPI", "imageset", "openapi", "imageset.openapi.json"),
+	)
+
+	It("rejects unknown formats", func() {
+		cmd := exec.Command(_binPath,
+			"schema", "generate", "addon",
+			"--format", "protobuf",
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "10s").Should(Exit(1))
+		Expect(session.Out).To(Say(`unknown format "protobuf"`))
+	})
+})
@@ -0,0 +1,93 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate subcommand with strict decoding", func() {
+	var addonDir string
+
+	BeforeEach(func() {
+		src := filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+		addonDir = filepath.Join(GinkgoT().TempDir(), "reference-addon")
+
+		for _, dir := range []string{
+			filepath.Join("metadata", "stage"),
+			filepath.Join("addonimagesets", "stage"),
+		} {
+			Expect(os.MkdirAll(filepath.Join(addonDir, dir), 0o755)).To(Succeed())
+
+			entries, err := os.ReadDir(filepath.Join(src, dir))
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, e := range entries {
+				data, err := os.ReadFile(filepath.Join(src, dir, e.Name()))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(os.WriteFile(filepath.Join(addonDir, dir, e.Name()), data, 0o644)).To(Succeed())
+			}
+		}
+
+		metaFile := filepath.Join(addonDir, "metadata", "stage", "addon.yaml")
+
+		f, err := os.OpenFile(metaFile, os.O_APPEND|os.O_WRONLY, 0o644)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = f.WriteString("\naddonOwnr: MT-SRE Team <sd-mt-sre@redhat.com>\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(f.Close()).To(Succeed())
+	})
+
+	run := func(args ...string) *Session {
+		cmd := exec.Command(_binPath, append([]string{
+			"validate", "--env", "stage",
+			"--enabled", "AM0020",
+			"--output", "json",
+			"--cache-dir", GinkgoT().TempDir(),
+			"--bundles-dir", filepath.Join(testutils.RootDir().TestData().Bundles(), "reference-addon"),
+		}, append(args, addonDir)...)...)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		return session
+	}
+
+	It("ignores unknown fields by default", func() {
+		session := run()
+
+		Eventually(session, "30s").Should(Exit(0))
+	})
+
+	It("reports unknown fields as an invalid result", func() {
+		session := run("--strict")
+
+		Eventually(session, "30s").Should(Exit(1))
+
+		// the results are followed by the 'validation failed' error
+		var results validator.ResultList
+		Expect(json.NewDecoder(bytes.NewReader(session.Out.Contents())).Decode(&results)).To(Succeed())
+		Expect(results).ToNot(BeEmpty())
+
+		invalid := results[0]
+		Expect(invalid.Status()).To(Equal(validator.ResultStatusInvalid))
+		Expect(invalid.FailureMsgs).To(ConsistOf("addonOwnr: unknown field"))
+		Expect(invalid.Findings).To(HaveLen(1))
+		Expect(invalid.Findings[0].Path).To(Equal("addonOwnr"))
+		Expect(invalid.Findings[0].Position).ToNot(BeNil())
+		Expect(invalid.Findings[0].Position.File).To(HaveSuffix("addon.yaml"))
+		Expect(invalid.Findings[0].Position.Column).To(Equal(1))
+	})
+})
@@ -21,7 +21,12 @@ var _ = Describe("validate subcommand", func() {
 
 	DescribeTable("AM0005 enabled",
 		func(tc testHarnessTestCase) {
-			cmd := exec.Command(_binPath, "validate", "--env", "stage", "--enabled", "AM0005", tc.MetadataPath)
+			cmd := exec.Command(_binPath,
+				"validate", "--env", "stage",
+				"--enabled", "AM0005",
+				"--cache-dir", GinkgoT().TempDir(),
+				tc.MetadataPath,
+			)
 			cmd.Env = []string{
 				`OCM_TOKEN=""`,
 			}
@@ -105,6 +105,12 @@ func (fc FieldColor) Apply(s string) string {
 		return red(s)
 	case FieldColorIntenselyBoldRed:
 		return intenselyBoldRed(s)
+	case FieldColorYellow:
+		return yellow(s)
+	case FieldColorCyan:
+		return cyan(s)
+	case FieldColorMagenta:
+		return magenta(s)
 	default:
 		return s
 	}
@@ -114,12 +120,18 @@ const (
 	FieldColorGreen            FieldColor = "green"
 	FieldColorRed              FieldColor = "red"
 	FieldColorIntenselyBoldRed FieldColor = "intenselyBoldRed"
+	FieldColorYellow           FieldColor = "yellow"
+	FieldColorCyan             FieldColor = "cyan"
+	FieldColorMagenta          FieldColor = "magenta"
 )
 
 var (
 	green            = color.New(color.FgGreen).SprintFunc()
 	red              = color.New(color.FgRed).SprintFunc()
 	intenselyBoldRed = color.New(color.Bold, color.FgHiRed).SprintFunc()
+	yellow           = color.New(color.FgYellow).SprintFunc()
+	cyan             = color.New(color.FgCyan).SprintFunc()
+	magenta          = color.New(color.FgMagenta).SprintFunc()
 )
 
 type TableConfig struct {
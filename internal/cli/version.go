@@ -1,6 +1,9 @@
 package cli
 
-import "fmt"
+import (
+	"fmt"
+	"runtime/debug"
+)
 
 // Injected by goreleaser through ldflags (see .goreleaser.yml)
 var (
@@ -13,3 +16,40 @@ var (
 func Version() string {
 	return fmt.Sprintf("mtcli version: %v, commit: %v, builtBy: %v (%v)", version, commit, builtBy, date)
 }
+
+// BuildID identifies the code mtcli was built from. Release builds are
+// identified by their version and commit while other builds fall back
+// to the VCS revision embedded by the go toolchain. An empty string is
+// returned if the code can't be identified, e.g. for builds without VCS
+// information or with uncommitted changes.
+func BuildID() string {
+	if commit != "local" {
+		return version + "-" + commit
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	return buildIDFromSettings(info.Settings)
+}
+
+func buildIDFromSettings(settings []debug.BuildSetting) string {
+	var revision, modified string
+
+	for _, s := range settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision = s.Value
+		case "vcs.modified":
+			modified = s.Value
+		}
+	}
+
+	if revision == "" || modified != "false" {
+		return ""
+	}
+
+	return revision
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildIDFromSettings(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Settings   []debug.BuildSetting
+		ExpectedID string
+	}{
+		"clean checkout": {
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "0123abcd"},
+				{Key: "vcs.modified", Value: "false"},
+			},
+			ExpectedID: "0123abcd",
+		},
+		"uncommitted changes": {
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "0123abcd"},
+				{Key: "vcs.modified", Value: "true"},
+			},
+		},
+		"no vcs information": {},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.ExpectedID, buildIDFromSettings(tc.Settings))
+		})
+	}
+}
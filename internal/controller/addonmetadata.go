@@ -0,0 +1,336 @@
+// Package controller reconciles the addon CRDs by running the
+// validators against them and recording the outcome in their status.
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/internal/imageset"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// codeOCMQuota is the code of the validator checking that a
+// QuotaRule exists in OCM for the ocmQuotaName of an addon.
+const codeOCMQuota validator.Code = 11
+
+// NewAddonMetadataReconciler returns an AddonMetadataReconciler reading
+// and updating objects through 'c' and configured with a variadic slice
+// of options. An error is returned if the default Runner cannot be
+// initialized.
+func NewAddonMetadataReconciler(c client.Client, opts ...AddonMetadataReconcilerOption) (*AddonMetadataReconciler, error) {
+	var cfg AddonMetadataReconcilerConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	if cfg.Runner == nil {
+		runner, err := validator.NewRunner(validator.WithLogger{Logger: cfg.Logger})
+		if err != nil {
+			return nil, fmt.Errorf("initializing validators: %w", err)
+		}
+
+		cfg.Runner = runner
+	}
+
+	return &AddonMetadataReconciler{
+		cfg:    cfg,
+		client: c,
+	}, nil
+}
+
+// AddonMetadataReconciler validates AddonMetadata objects combined with
+// their AddonImageSet against the bundles of the referenced index image.
+type AddonMetadataReconciler struct {
+	cfg    AddonMetadataReconcilerConfig
+	client client.Client
+}
+
+// SetupWithManager registers the reconciler with 'mgr'. AddonMetadata
+// objects are reconciled whenever their spec or one of the AddonImageSets
+// of the addon changes.
+func (r *AddonMetadataReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(
+			&addonsv1alpha1.AddonMetadata{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&addonsv1alpha1.AddonImageSet{},
+			handler.EnqueueRequestsFromMapFunc(r.addonsForImageSet),
+		).
+		Complete(r)
+}
+
+// Reconcile validates the AddonMetadata identified by 'req' and updates
+// its status. Validation is retried after the configured interval if it
+// could not be completed.
+func (r *AddonMetadataReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.cfg.Logger.WithValues("addonmetadata", req.NamespacedName)
+
+	var addon addonsv1alpha1.AddonMetadata
+
+	if err := r.client.Get(ctx, req.NamespacedName, &addon); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	status := addon.Status.DeepCopy()
+	status.ObservedGeneration = addon.Generation
+
+	retry, err := r.reconcileStatus(ctx, &addon, status)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !equality.Semantic.DeepEqual(&addon.Status, status) {
+		addon.Status = *status
+
+		if err := r.client.Status().Update(ctx, &addon); err != nil {
+			return ctrl.Result{}, fmt.Errorf("updating status: %w", err)
+		}
+	}
+
+	if retry {
+		log.Info("validation incomplete; retrying", "after", r.cfg.RetryInterval)
+
+		return ctrl.Result{RequeueAfter: r.cfg.RetryInterval}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileStatus validates 'addon' and records the outcome in 'status'.
+// The returned boolean is 'true' if the outcome is not final because of
+// errors which may resolve themselves. Only errors of the API server are
+// returned.
+func (r *AddonMetadataReconciler) reconcileStatus(
+	ctx context.Context,
+	addon *addonsv1alpha1.AddonMetadata,
+	status *addonsv1alpha1.AddonMetadataStatus,
+) (bool, error) {
+	spec, imageSetVersion, err := imageset.Combine(ctx, r.client, addon)
+	if err != nil {
+		var unresolved *imageset.UnresolvedError
+		if !errors.As(err, &unresolved) {
+			return false, err
+		}
+
+		setCondition(status, addonsv1alpha1.AddonMetadataBundlesResolved, metav1.ConditionFalse,
+			addonsv1alpha1.AddonMetadataReasonImageSetUnresolved, err.Error())
+		setNotValidated(status)
+
+		// A matching AddonImageSet being created triggers a reconcile.
+		return false, nil
+	}
+
+	bundles, err := r.cfg.Extractor.ExtractBundles(ctx, *spec.IndexImage, spec.OperatorName)
+	if err != nil {
+		setCondition(status, addonsv1alpha1.AddonMetadataBundlesResolved, metav1.ConditionFalse,
+			addonsv1alpha1.AddonMetadataReasonExtractionFailed,
+			fmt.Sprintf("extracting bundles from %q: %v", *spec.IndexImage, err))
+		setNotValidated(status)
+
+		return true, nil
+	}
+
+	if len(bundles) == 0 {
+		setCondition(status, addonsv1alpha1.AddonMetadataBundlesResolved, metav1.ConditionFalse,
+			addonsv1alpha1.AddonMetadataReasonNoBundlesFound,
+			fmt.Sprintf("no bundles for package %q found in %q", spec.OperatorName, *spec.IndexImage))
+		setNotValidated(status)
+
+		return false, nil
+	}
+
+	status.Bundles = make([]addonsv1alpha1.BundleReference, 0, len(bundles))
+
+	for _, b := range bundles {
+		status.Bundles = append(status.Bundles, addonsv1alpha1.BundleReference{
+			Name:    b.Name,
+			Version: b.Version,
+			Image:   b.BundleImage,
+		})
+	}
+
+	setCondition(status, addonsv1alpha1.AddonMetadataBundlesResolved, metav1.ConditionTrue,
+		addonsv1alpha1.AddonMetadataReasonBundlesFound,
+		fmt.Sprintf("%d bundle(s) found in %q", len(bundles), *spec.IndexImage))
+
+	var filters []validator.Filter
+
+	if r.cfg.SkipQuotaCheck {
+		filters = append(filters, validator.Not(validator.MatchesCodes(codeOCMQuota)))
+	}
+
+	var results validator.ResultList
+
+	for res := range r.cfg.Runner.Run(ctx, types.MetaBundle{AddonMeta: spec, Bundles: bundles}, filters...) {
+		results = append(results, res)
+	}
+
+	sort.Sort(results)
+
+	setValidCondition(status, results)
+	setQuotaCondition(status, results, r.cfg.SkipQuotaCheck)
+
+	if len(results.Errors()) > 0 {
+		return true, nil
+	}
+
+	if imageSetVersion != "" {
+		status.LastValidatedImageSetVersion = imageSetVersion
+	}
+
+	return false, nil
+}
+
+// addonsForImageSet maps an AddonImageSet to the AddonMetadata objects
+// in its namespace which reference an imageset of the same addon.
+func (r *AddonMetadataReconciler) addonsForImageSet(ctx context.Context, obj client.Object) []reconcile.Request {
+	imageSet, ok := obj.(*addonsv1alpha1.AddonImageSet)
+	if !ok {
+		return nil
+	}
+
+	addonID, _, ok := imageset.SplitName(imageSet.Spec.Name)
+	if !ok {
+		return nil
+	}
+
+	var list addonsv1alpha1.AddonMetadataList
+
+	if err := r.client.List(ctx, &list, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.cfg.Logger.Error(err, "listing addon metadata", "namespace", obj.GetNamespace())
+
+		return nil
+	}
+
+	var reqs []reconcile.Request
+
+	for i := range list.Items {
+		addon := &list.Items[i]
+
+		if addon.Spec.ID != addonID || addon.Spec.ImageSetVersion == nil {
+			continue
+		}
+
+		reqs = append(reqs, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(addon),
+		})
+	}
+
+	return reqs
+}
+
+// setValidCondition derives the Valid condition from 'results'. Like the
+// default '--fail-on' threshold of mtcli, only failures of severity error
+// invalidate an addon while other failures are listed as warnings.
+func setValidCondition(status *addonsv1alpha1.AddonMetadataStatus, results validator.ResultList) {
+	var (
+		succeeded                int
+		failed, errored, warning []string
+	)
+
+	for _, res := range results {
+		switch {
+		case res.IsSuccess():
+			succeeded++
+		case res.IsSkipped():
+		case res.IsError():
+			errored = append(errored, fmt.Sprintf("%s (%v)", res.Code, res.Error))
+		case res.Severity >= validator.SeverityError:
+			failed = append(failed, fmt.Sprintf("%s %s", res.Code, res.Name))
+		default:
+			warning = append(warning, fmt.Sprintf("%s %s", res.Code, res.Name))
+		}
+	}
+
+	switch {
+	case len(failed) > 0:
+		setCondition(status, addonsv1alpha1.AddonMetadataValid, metav1.ConditionFalse,
+			addonsv1alpha1.AddonMetadataReasonValidationFailed,
+			"failed validators: "+strings.Join(failed, ", "))
+	case len(errored) > 0:
+		setCondition(status, addonsv1alpha1.AddonMetadataValid, metav1.ConditionUnknown,
+			addonsv1alpha1.AddonMetadataReasonValidationErrored,
+			"errored validators: "+strings.Join(errored, ", "))
+	case len(warning) > 0:
+		setCondition(status, addonsv1alpha1.AddonMetadataValid, metav1.ConditionTrue,
+			addonsv1alpha1.AddonMetadataReasonValidationSucceeded,
+			fmt.Sprintf("%d validator(s) succeeded; warnings: %s", succeeded, strings.Join(warning, ", ")))
+	default:
+		setCondition(status, addonsv1alpha1.AddonMetadataValid, metav1.ConditionTrue,
+			addonsv1alpha1.AddonMetadataReasonValidationSucceeded,
+			fmt.Sprintf("%d validator(s) succeeded", succeeded))
+	}
+}
+
+// setQuotaCondition derives the OCMQuotaVerified condition from the
+// result of the validator checking for a QuotaRule in OCM. If 'skipped'
+// the validator was excluded as no OCM credentials are configured.
+func setQuotaCondition(status *addonsv1alpha1.AddonMetadataStatus, results validator.ResultList, skipped bool) {
+	for _, res := range results {
+		if res.Code != codeOCMQuota {
+			continue
+		}
+
+		switch {
+		case res.IsSuccess():
+			setCondition(status, addonsv1alpha1.AddonMetadataOCMQuotaVerified, metav1.ConditionTrue,
+				addonsv1alpha1.AddonMetadataReasonQuotaRuleFound, "QuotaRule exists in OCM")
+		case res.IsError():
+			setCondition(status, addonsv1alpha1.AddonMetadataOCMQuotaVerified, metav1.ConditionUnknown,
+				addonsv1alpha1.AddonMetadataReasonValidationErrored, res.Error.Error())
+		default:
+			setCondition(status, addonsv1alpha1.AddonMetadataOCMQuotaVerified, metav1.ConditionFalse,
+				addonsv1alpha1.AddonMetadataReasonQuotaRuleMissing, strings.Join(res.FailureMsgs, "; "))
+		}
+
+		return
+	}
+
+	msg := "the OCM quota validator is not enabled"
+	if skipped {
+		msg = "OCM credentials are not configured"
+	}
+
+	setCondition(status, addonsv1alpha1.AddonMetadataOCMQuotaVerified, metav1.ConditionUnknown,
+		addonsv1alpha1.AddonMetadataReasonQuotaNotChecked, msg)
+}
+
+// setNotValidated marks the conditions which depend on
+// resolved bundles as unknown.
+func setNotValidated(status *addonsv1alpha1.AddonMetadataStatus) {
+	status.Bundles = nil
+
+	const msg = "the bundles of the addon could not be resolved"
+
+	setCondition(status, addonsv1alpha1.AddonMetadataValid, metav1.ConditionUnknown,
+		addonsv1alpha1.AddonMetadataReasonBundlesNotResolved, msg)
+	setCondition(status, addonsv1alpha1.AddonMetadataOCMQuotaVerified, metav1.ConditionUnknown,
+		addonsv1alpha1.AddonMetadataReasonBundlesNotResolved, msg)
+}
+
+func setCondition(status *addonsv1alpha1.AddonMetadataStatus, condType string, condStatus metav1.ConditionStatus, reason, msg string) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               condType,
+		Status:             condStatus,
+		ObservedGeneration: status.ObservedGeneration,
+		Reason:             reason,
+		Message:            msg,
+	})
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetResultConditions(t *testing.T) {
+	t.Parallel()
+
+	quota := newTestBase(t, codeOCMQuota)
+	other := newTestBase(t, 1)
+	warning := newTestBase(t, 15, validator.BaseSeverity(validator.SeverityWarning))
+
+	for name, tc := range map[string]struct {
+		Results       validator.ResultList
+		SkipQuota     bool
+		ExpectedValid metav1.ConditionStatus
+		ExpectedQuota metav1.ConditionStatus
+		QuotaReason   string
+	}{
+		"all succeeded": {
+			Results:       validator.ResultList{other.Success(), quota.Success()},
+			ExpectedValid: metav1.ConditionTrue,
+			ExpectedQuota: metav1.ConditionTrue,
+			QuotaReason:   addonsv1alpha1.AddonMetadataReasonQuotaRuleFound,
+		},
+		"quota missing": {
+			Results:       validator.ResultList{other.Success(), quota.Fail("no QuotaRule")},
+			ExpectedValid: metav1.ConditionFalse,
+			ExpectedQuota: metav1.ConditionFalse,
+			QuotaReason:   addonsv1alpha1.AddonMetadataReasonQuotaRuleMissing,
+		},
+		"quota errored": {
+			Results:       validator.ResultList{other.Success(), quota.Error(errors.New("disconnected"))},
+			ExpectedValid: metav1.ConditionUnknown,
+			ExpectedQuota: metav1.ConditionUnknown,
+			QuotaReason:   addonsv1alpha1.AddonMetadataReasonValidationErrored,
+		},
+		"failures take precedence over errors": {
+			Results:       validator.ResultList{other.Fail("failed"), quota.Error(errors.New("disconnected"))},
+			ExpectedValid: metav1.ConditionFalse,
+			ExpectedQuota: metav1.ConditionUnknown,
+			QuotaReason:   addonsv1alpha1.AddonMetadataReasonValidationErrored,
+		},
+		"warnings only": {
+			Results:       validator.ResultList{other.Success(), warning.Fail("multiple heads"), quota.Success()},
+			ExpectedValid: metav1.ConditionTrue,
+			ExpectedQuota: metav1.ConditionTrue,
+			QuotaReason:   addonsv1alpha1.AddonMetadataReasonQuotaRuleFound,
+		},
+		"warnings and failures": {
+			Results:       validator.ResultList{other.Fail("failed"), warning.Fail("multiple heads"), quota.Success()},
+			ExpectedValid: metav1.ConditionFalse,
+			ExpectedQuota: metav1.ConditionTrue,
+			QuotaReason:   addonsv1alpha1.AddonMetadataReasonQuotaRuleFound,
+		},
+		"quota not checked": {
+			Results:       validator.ResultList{other.Success()},
+			ExpectedValid: metav1.ConditionTrue,
+			ExpectedQuota: metav1.ConditionUnknown,
+			QuotaReason:   addonsv1alpha1.AddonMetadataReasonQuotaNotChecked,
+		},
+		"quota skipped": {
+			Results:       validator.ResultList{other.Success()},
+			SkipQuota:     true,
+			ExpectedValid: metav1.ConditionTrue,
+			ExpectedQuota: metav1.ConditionUnknown,
+			QuotaReason:   addonsv1alpha1.AddonMetadataReasonQuotaNotChecked,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var status addonsv1alpha1.AddonMetadataStatus
+
+			setValidCondition(&status, tc.Results)
+			setQuotaCondition(&status, tc.Results, tc.SkipQuota)
+
+			valid := meta.FindStatusCondition(status.Conditions, addonsv1alpha1.AddonMetadataValid)
+			require.NotNil(t, valid)
+			assert.Equal(t, tc.ExpectedValid, valid.Status)
+
+			quota := meta.FindStatusCondition(status.Conditions, addonsv1alpha1.AddonMetadataOCMQuotaVerified)
+			require.NotNil(t, quota)
+			assert.Equal(t, tc.ExpectedQuota, quota.Status)
+			assert.Equal(t, tc.QuotaReason, quota.Reason)
+		})
+	}
+}
+
+func newTestBase(t *testing.T, code validator.Code, opts ...validator.BaseOption) *validator.Base {
+	t.Helper()
+
+	base, err := validator.NewBase(code, opts...)
+	require.NoError(t, err)
+
+	return base
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+// DefaultRetryInterval is the time after which validation
+// is retried if it could not be completed.
+const DefaultRetryInterval = time.Minute
+
+// BundleExtractor extracts the bundles of package 'pkgName'
+// from an index image.
+type BundleExtractor interface {
+	ExtractBundles(ctx context.Context, indexImage string, pkgName string) ([]operator.Bundle, error)
+}
+
+type AddonMetadataReconcilerConfig struct {
+	Extractor     BundleExtractor
+	Logger        logr.Logger
+	RetryInterval time.Duration
+	Runner        *validator.Runner
+	// SkipQuotaCheck excludes the OCM quota validator from the run
+	// when no OCM credentials are available.
+	SkipQuotaCheck bool
+}
+
+func (c *AddonMetadataReconcilerConfig) Option(opts ...AddonMetadataReconcilerOption) {
+	for _, opt := range opts {
+		opt.ConfigureAddonMetadataReconciler(c)
+	}
+}
+
+func (c *AddonMetadataReconcilerConfig) Default() {
+	if c.Extractor == nil {
+		c.Extractor = extractor.New()
+	}
+
+	if c.Logger.GetSink() == nil {
+		c.Logger = logr.Discard()
+	}
+
+	if c.RetryInterval <= 0 {
+		c.RetryInterval = DefaultRetryInterval
+	}
+}
+
+type AddonMetadataReconcilerOption interface {
+	ConfigureAddonMetadataReconciler(*AddonMetadataReconcilerConfig)
+}
+
+// WithExtractor applies the given BundleExtractor.
+type WithExtractor struct{ BundleExtractor }
+
+func (w WithExtractor) ConfigureAddonMetadataReconciler(c *AddonMetadataReconcilerConfig) {
+	c.Extractor = w.BundleExtractor
+}
+
+// WithLogger applies the given logger.
+type WithLogger struct{ logr.Logger }
+
+func (w WithLogger) ConfigureAddonMetadataReconciler(c *AddonMetadataReconcilerConfig) {
+	c.Logger = w.Logger
+}
+
+// WithRetryInterval applies the given retry interval.
+type WithRetryInterval time.Duration
+
+func (w WithRetryInterval) ConfigureAddonMetadataReconciler(c *AddonMetadataReconcilerConfig) {
+	c.RetryInterval = time.Duration(w)
+}
+
+// WithRunner applies the given validator Runner.
+type WithRunner struct{ *validator.Runner }
+
+func (w WithRunner) ConfigureAddonMetadataReconciler(c *AddonMetadataReconcilerConfig) {
+	c.Runner = w.Runner
+}
+
+// WithSkipQuotaCheck excludes the OCM quota validator from the run.
+type WithSkipQuotaCheck bool
+
+func (w WithSkipQuotaCheck) ConfigureAddonMetadataReconciler(c *AddonMetadataReconcilerConfig) {
+	c.SkipQuotaCheck = bool(w)
+}
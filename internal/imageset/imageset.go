@@ -0,0 +1,131 @@
+// Package imageset resolves the AddonImageSet referenced by an
+// AddonMetadata object and combines the two into the spec which
+// is validated by the controller and the webhook.
+package imageset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"golang.org/x/mod/semver"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// LatestVersion resolves to the AddonImageSet with the highest version.
+const LatestVersion = "latest"
+
+// ErrNotFound is wrapped by the UnresolvedError returned when no
+// AddonImageSet matches the version referenced by an addon.
+var ErrNotFound = errors.New("imageset not found")
+
+// Combine returns the spec of 'addon' combined with the AddonImageSet it
+// references and the version of that AddonImageSet. Addons referencing an
+// index image directly are returned as is with an empty version. An
+// UnresolvedError is returned if the two cannot be combined while all
+// other errors are returned by the API server.
+func Combine(ctx context.Context, c client.Reader, addon *addonsv1alpha1.AddonMetadata) (*addonsv1alpha1.AddonMetadataSpec, string, error) {
+	spec := &addon.Spec
+
+	switch {
+	case spec.IndexImage != nil && spec.ImageSetVersion != nil:
+		return nil, "", &UnresolvedError{
+			msg: "can't set both the 'indexImage' and the 'imageSetVersion' field",
+		}
+	case spec.IndexImage != nil:
+		return spec.DeepCopy(), "", nil
+	case spec.ImageSetVersion == nil:
+		return nil, "", &UnresolvedError{
+			msg: "neither the 'indexImage' nor the 'imageSetVersion' field is set",
+		}
+	}
+
+	imageSet, version, err := Find(ctx, c, addon.Namespace, spec.ID, *spec.ImageSetVersion)
+	if err != nil {
+		return nil, "", err
+	}
+
+	combined, err := spec.CombineWithImageSet(imageSet)
+	if err != nil {
+		return nil, "", &UnresolvedError{
+			msg: fmt.Sprintf("combining metadata and imageset: %v", err),
+		}
+	}
+
+	return combined, version, nil
+}
+
+// Find returns the spec of the AddonImageSet in 'namespace' for version
+// 'version' of the addon with id 'addonID' together with its version in
+// the MAJOR.MINOR.PATCH form. The version 'latest' resolves to the
+// AddonImageSet with the highest version. An UnresolvedError wrapping
+// ErrNotFound is returned if no AddonImageSet matches.
+func Find(ctx context.Context, c client.Reader, namespace, addonID, version string) (*addonsv1alpha1.AddonImageSetSpec, string, error) {
+	var list addonsv1alpha1.AddonImageSetList
+
+	if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, "", fmt.Errorf("listing imagesets: %w", err)
+	}
+
+	want := "v" + strings.TrimPrefix(version, "v")
+
+	var (
+		match        *addonsv1alpha1.AddonImageSetSpec
+		matchVersion string
+	)
+
+	for i := range list.Items {
+		imageSet := &list.Items[i].Spec
+
+		id, v, ok := SplitName(imageSet.Name)
+		if !ok || id != addonID {
+			continue
+		}
+
+		if version == LatestVersion {
+			if match == nil || semver.Compare(v, matchVersion) > 0 {
+				match, matchVersion = imageSet, v
+			}
+
+			continue
+		}
+
+		if semver.Compare(v, want) == 0 {
+			return imageSet, strings.TrimPrefix(v, "v"), nil
+		}
+	}
+
+	if match == nil {
+		return nil, "", &UnresolvedError{
+			msg: fmt.Sprintf("no imageset found for version %q of addon %q", version, addonID),
+			err: ErrNotFound,
+		}
+	}
+
+	return match, strings.TrimPrefix(matchVersion, "v"), nil
+}
+
+// SplitName splits the name of an imageset of the
+// form '<addon id>.v<semver>' into the addon id and version.
+func SplitName(name string) (string, string, bool) {
+	id, version, ok := strings.Cut(name, ".")
+	if !ok || !semver.IsValid(version) {
+		return "", "", false
+	}
+
+	return id, version, true
+}
+
+// UnresolvedError is returned when the metadata of an addon
+// cannot be combined with an imageset. It is resolved by changes
+// to either object rather than by retrying.
+type UnresolvedError struct {
+	msg string
+	err error
+}
+
+func (e *UnresolvedError) Error() string { return e.msg }
+
+func (e *UnresolvedError) Unwrap() error { return e.err }
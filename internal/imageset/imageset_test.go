@@ -0,0 +1,153 @@
+package imageset
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSplitName(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Name            string
+		ExpectedID      string
+		ExpectedVersion string
+		ExpectedOK      bool
+	}{
+		"valid": {
+			Name:            "reference-addon.v0.1.2",
+			ExpectedID:      "reference-addon",
+			ExpectedVersion: "v0.1.2",
+			ExpectedOK:      true,
+		},
+		"missing version": {
+			Name: "reference-addon",
+		},
+		"invalid version": {
+			Name: "reference-addon.v0.1.2.3",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			id, version, ok := SplitName(tc.Name)
+			assert.Equal(t, tc.ExpectedID, id)
+			assert.Equal(t, tc.ExpectedVersion, version)
+			assert.Equal(t, tc.ExpectedOK, ok)
+		})
+	}
+}
+
+func TestCombine(t *testing.T) {
+	t.Parallel()
+
+	reader := imageSetReader{
+		newImageSet("reference-addon.v0.1.0", "quay.io/osd-addons/reference-addon-index:0.1.0"),
+		newImageSet("reference-addon.v0.2.0", "quay.io/osd-addons/reference-addon-index:0.2.0"),
+		newImageSet("other-addon.v1.0.0", "quay.io/osd-addons/other-addon-index:1.0.0"),
+	}
+
+	for name, tc := range map[string]struct {
+		IndexImage         *string
+		ImageSetVersion    *string
+		ExpectedIndexImage string
+		ExpectedVersion    string
+		ExpectedNotFound   bool
+		ExpectedUnresolved bool
+	}{
+		"index image": {
+			IndexImage:         stringPtr("quay.io/osd-addons/reference-addon-index:0.0.1"),
+			ExpectedIndexImage: "quay.io/osd-addons/reference-addon-index:0.0.1",
+		},
+		"exact version": {
+			ImageSetVersion:    stringPtr("0.1.0"),
+			ExpectedIndexImage: "quay.io/osd-addons/reference-addon-index:0.1.0",
+			ExpectedVersion:    "0.1.0",
+		},
+		"latest version": {
+			ImageSetVersion:    stringPtr(LatestVersion),
+			ExpectedIndexImage: "quay.io/osd-addons/reference-addon-index:0.2.0",
+			ExpectedVersion:    "0.2.0",
+		},
+		"missing version": {
+			ImageSetVersion:    stringPtr("0.3.0"),
+			ExpectedUnresolved: true,
+			ExpectedNotFound:   true,
+		},
+		"both set": {
+			IndexImage:         stringPtr("quay.io/osd-addons/reference-addon-index:0.0.1"),
+			ImageSetVersion:    stringPtr("0.1.0"),
+			ExpectedUnresolved: true,
+		},
+		"neither set": {
+			ExpectedUnresolved: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := &addonsv1alpha1.AddonMetadata{
+				Spec: addonsv1alpha1.AddonMetadataSpec{
+					ID:              "reference-addon",
+					IndexImage:      tc.IndexImage,
+					ImageSetVersion: tc.ImageSetVersion,
+				},
+			}
+
+			spec, version, err := Combine(context.Background(), reader, addon)
+			if tc.ExpectedUnresolved {
+				var unresolved *UnresolvedError
+				require.ErrorAs(t, err, &unresolved)
+				assert.Equal(t, tc.ExpectedNotFound, errors.Is(err, ErrNotFound))
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, spec.IndexImage)
+			assert.Equal(t, tc.ExpectedIndexImage, *spec.IndexImage)
+			assert.Equal(t, tc.ExpectedVersion, version)
+		})
+	}
+}
+
+// imageSetReader lists its AddonImageSets regardless of the options.
+type imageSetReader []addonsv1alpha1.AddonImageSet
+
+func (r imageSetReader) Get(context.Context, client.ObjectKey, client.Object, ...client.GetOption) error {
+	return nil
+}
+
+func (r imageSetReader) List(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+	imageSets, ok := list.(*addonsv1alpha1.AddonImageSetList)
+	if !ok {
+		return nil
+	}
+
+	imageSets.Items = append(imageSets.Items, r...)
+
+	return nil
+}
+
+func newImageSet(name, indexImage string) addonsv1alpha1.AddonImageSet {
+	return addonsv1alpha1.AddonImageSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: addonsv1alpha1.AddonImageSetSpec{
+			Name:       name,
+			IndexImage: indexImage,
+		},
+	}
+}
+
+func stringPtr(s string) *string { return &s }
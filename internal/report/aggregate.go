@@ -0,0 +1,110 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+// AddonReport groups the results of validating a single
+// addon against a single environment.
+type AddonReport struct {
+	Addon   string
+	Env     string
+	Results validator.ResultList
+	// Err is set when the addon could not be loaded or its
+	// bundles could not be extracted. Results will be empty.
+	Err     error
+	Timings Timings
+}
+
+// Name returns a label identifying the addon and environment.
+func (r AddonReport) Name() string {
+	return r.Addon + "/" + r.Env
+}
+
+// IsError returns true if the addon could not be validated or
+// if any validator encountered an error.
+func (r AddonReport) IsError() bool {
+	return r.Err != nil || len(r.Results.Errors()) > 0
+}
+
+type serializedAddonReport struct {
+	Addon   string               `json:"addon"`
+	Env     string               `json:"env"`
+	Results validator.ResultList `json:"results"`
+	Error   string               `json:"error,omitempty"`
+}
+
+func (r AddonReport) MarshalJSON() ([]byte, error) {
+	sr := serializedAddonReport{
+		Addon:   r.Addon,
+		Env:     r.Env,
+		Results: nonNil(r.Results),
+	}
+
+	if r.Err != nil {
+		sr.Error = r.Err.Error()
+	}
+
+	return json.Marshal(sr)
+}
+
+func (r *AddonReport) UnmarshalJSON(data []byte) error {
+	var sr serializedAddonReport
+
+	if err := json.Unmarshal(data, &sr); err != nil {
+		return err
+	}
+
+	*r = AddonReport{
+		Addon:   sr.Addon,
+		Env:     sr.Env,
+		Results: sr.Results,
+	}
+
+	if sr.Error != "" {
+		r.Err = errors.New(sr.Error)
+	}
+
+	return nil
+}
+
+// AggregateWriter serializes the reports of several addons
+// grouped by addon and environment.
+type AggregateWriter interface {
+	// WriteAggregate serializes the given reports to 'w'. An error
+	// is returned if the reports cannot be serialized or written.
+	WriteAggregate(w io.Writer, reports []AddonReport) error
+}
+
+// NewAggregateWriter returns an AggregateWriter for the given
+// Format or an error if the format is not supported. Failures
+// are reported like by the Writer returned by NewWriter.
+func NewAggregateWriter(format Format, failOn validator.Severity) (AggregateWriter, error) {
+	switch format {
+	case FormatTable:
+		return TableWriter{}, nil
+	case FormatJSON:
+		return JSONWriter{}, nil
+	case FormatYAML:
+		return YAMLWriter{}, nil
+	case FormatJUnit:
+		return JUnitWriter{FailOn: failOn}, nil
+	case FormatSARIF:
+		return SARIFWriter{}, nil
+	default:
+		return nil, fmt.Errorf("%q: %w", format, ErrUnknownFormat)
+	}
+}
+
+func nonNilReports(reports []AddonReport) []AddonReport {
+	if reports == nil {
+		return []AddonReport{}
+	}
+
+	return reports
+}
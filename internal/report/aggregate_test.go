@@ -0,0 +1,124 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestJSONWriterWriteAggregate(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, JSONWriter{}.WriteAggregate(&buf, testReports(t)))
+
+	var actual []AddonReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+	require.Len(t, actual, 2)
+
+	assert.Equal(t, "reference-addon", actual[0].Addon)
+	assert.Equal(t, "stage", actual[0].Env)
+	assert.Len(t, actual[0].Results, 4)
+	assert.NoError(t, actual[0].Err)
+	assert.Equal(t, "broken-addon", actual[1].Addon)
+	assert.Empty(t, actual[1].Results)
+	assert.EqualError(t, actual[1].Err, "no metadata")
+}
+
+func TestYAMLWriterWriteAggregate(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, YAMLWriter{}.WriteAggregate(&buf, testReports(t)))
+
+	var actual []AddonReport
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &actual))
+	require.Len(t, actual, 2)
+
+	assert.Equal(t, "production", actual[1].Env)
+	assert.EqualError(t, actual[1].Err, "no metadata")
+}
+
+func TestJUnitWriterWriteAggregate(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, JUnitWriter{FailOn: validator.SeverityError}.WriteAggregate(&buf, testReports(t)))
+
+	var actual junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &actual))
+
+	assert.Equal(t, 5, actual.Tests)
+	assert.Equal(t, 1, actual.Failures)
+	assert.Equal(t, 2, actual.Errors)
+	require.Len(t, actual.Suites, 2)
+	assert.Equal(t, "reference-addon/stage", actual.Suites[0].Name)
+	assert.Equal(t, "broken-addon/production", actual.Suites[1].Name)
+	require.Len(t, actual.Suites[1].TestCases, 1)
+	require.NotNil(t, actual.Suites[1].TestCases[0].Error)
+	assert.Equal(t, "no metadata", actual.Suites[1].TestCases[0].Error.Message)
+}
+
+func TestSARIFWriterWriteAggregate(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, SARIFWriter{}.WriteAggregate(&buf, testReports(t)))
+
+	var actual sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+	require.Len(t, actual.Runs, 2)
+
+	require.NotNil(t, actual.Runs[0].AutomationDetails)
+	assert.Equal(t, "reference-addon/stage/", actual.Runs[0].AutomationDetails.ID)
+	assert.Len(t, actual.Runs[0].Results, 4)
+
+	broken := actual.Runs[1]
+	require.NotNil(t, broken.AutomationDetails)
+	assert.Equal(t, "broken-addon/production/", broken.AutomationDetails.ID)
+	require.Len(t, broken.Invocations, 1)
+	assert.False(t, broken.Invocations[0].ExecutionSuccessful)
+	require.Len(t, broken.Invocations[0].Notifications, 1)
+	assert.Equal(t, "no metadata", broken.Invocations[0].Notifications[0].Message.Text)
+}
+
+func TestTableWriterWriteAggregate(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, TableWriter{}.WriteAggregate(&buf, testReports(t)))
+
+	out := buf.String()
+	assert.Contains(t, out, "==> reference-addon (stage)")
+	assert.Contains(t, out, "==> broken-addon (production)")
+	assert.Contains(t, out, "no metadata")
+}
+
+func testReports(t *testing.T) []AddonReport {
+	t.Helper()
+
+	return []AddonReport{
+		{
+			Addon:   "reference-addon",
+			Env:     "stage",
+			Results: testResults(t),
+		},
+		{
+			Addon: "broken-addon",
+			Env:   "production",
+			Err:   errors.New("no metadata"),
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"sigs.k8s.io/yaml"
+)
+
+// JSONWriter serializes results as an indented JSON array.
+type JSONWriter struct{}
+
+func (w JSONWriter) Write(out io.Writer, results validator.ResultList) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(nonNil(results)); err != nil {
+		return fmt.Errorf("encoding results as json: %w", err)
+	}
+
+	return nil
+}
+
+// WriteAggregate serializes reports as an indented JSON array
+// of objects holding the addon, environment and results.
+func (w JSONWriter) WriteAggregate(out io.Writer, reports []AddonReport) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(nonNilReports(reports)); err != nil {
+		return fmt.Errorf("encoding reports as json: %w", err)
+	}
+
+	return nil
+}
+
+// YAMLWriter serializes results as a YAML sequence using
+// the same field names as JSONWriter.
+type YAMLWriter struct{}
+
+func (w YAMLWriter) Write(out io.Writer, results validator.ResultList) error {
+	data, err := yaml.Marshal(nonNil(results))
+	if err != nil {
+		return fmt.Errorf("encoding results as yaml: %w", err)
+	}
+
+	if _, err := out.Write(data); err != nil {
+		return fmt.Errorf("writing yaml: %w", err)
+	}
+
+	return nil
+}
+
+// WriteAggregate serializes reports using the same field
+// names as JSONWriter.WriteAggregate.
+func (w YAMLWriter) WriteAggregate(out io.Writer, reports []AddonReport) error {
+	data, err := yaml.Marshal(nonNilReports(reports))
+	if err != nil {
+		return fmt.Errorf("encoding reports as yaml: %w", err)
+	}
+
+	if _, err := out.Write(data); err != nil {
+		return fmt.Errorf("writing yaml: %w", err)
+	}
+
+	return nil
+}
+
+// nonNil ensures an empty list is serialized as an empty
+// sequence rather than 'null'.
+func nonNil(results validator.ResultList) validator.ResultList {
+	if results == nil {
+		return validator.ResultList{}
+	}
+
+	return results
+}
@@ -0,0 +1,219 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+const junitSuiteName = "mtcli validate"
+
+// JUnitWriter serializes results as a JUnit XML report
+// with one testcase per validator code.
+type JUnitWriter struct {
+	// FailOn is the lowest severity of failed results which are
+	// reported as JUnit failures, matching the '--fail-on' threshold
+	// of mtcli. Failures of lower severities are reported as output
+	// of their testcase so that they do not fail the test run.
+	FailOn validator.Severity
+}
+
+func (w JUnitWriter) Write(out io.Writer, results validator.ResultList) error {
+	return writeJUnit(out, w.suite(junitSuiteName, results))
+}
+
+// WriteAggregate serializes reports as a JUnit XML report with
+// one testsuite per addon and environment. Addons which could
+// not be loaded are reported as a single errored testcase.
+func (w JUnitWriter) WriteAggregate(out io.Writer, reports []AddonReport) error {
+	suites := make([]junitTestSuite, 0, len(reports))
+
+	for _, r := range reports {
+		if r.Err == nil {
+			suites = append(suites, w.suite(r.Name(), r.Results))
+
+			continue
+		}
+
+		suites = append(suites, junitTestSuite{
+			Name:   r.Name(),
+			Tests:  1,
+			Errors: 1,
+			TestCases: []junitTestCase{
+				{
+					Name:      "load",
+					ClassName: r.Name(),
+					Error: &junitMessage{
+						Message: r.Err.Error(),
+						Type:    string(validator.ResultStatusError),
+						Body:    r.Err.Error(),
+					},
+				},
+			},
+		})
+	}
+
+	return writeJUnit(out, suites...)
+}
+
+func (w JUnitWriter) suite(name string, results validator.ResultList) junitTestSuite {
+	suite := junitTestSuite{
+		Name:  name,
+		Tests: len(results),
+	}
+
+	for _, res := range results {
+		tc := junitTestCase{
+			Name:       ruleID(res),
+			ClassName:  res.Name,
+			Properties: junitPropertiesFor(res),
+		}
+
+		switch res.Status() {
+		case validator.ResultStatusError, validator.ResultStatusTimedOut, validator.ResultStatusCancelled:
+			suite.Errors++
+
+			tc.Error = &junitMessage{
+				Message: res.Error.Error(),
+				Type:    string(res.Status()),
+				Body:    withWiki(res, res.Error.Error()),
+			}
+		case validator.ResultStatusFailed:
+			if res.Severity < w.FailOn {
+				tc.SystemOut = fmt.Sprintf("%s: %s", res.Severity, withWiki(res, findingMessages(res)...))
+
+				break
+			}
+
+			suite.Failures++
+
+			tc.Failure = &junitMessage{
+				Message: res.Description,
+				Type:    string(validator.ResultStatusFailed),
+				Body:    withWiki(res, findingMessages(res)...),
+			}
+		case validator.ResultStatusInvalid:
+			suite.Failures++
+
+			tc.Failure = &junitMessage{
+				Message: res.Description,
+				Type:    string(validator.ResultStatusInvalid),
+				Body:    strings.Join(findingMessages(res), "\n"),
+			}
+		case validator.ResultStatusSkipped:
+			suite.Skipped++
+
+			tc.Skipped = &junitSkipped{Message: res.SkipReason}
+		}
+
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	return suite
+}
+
+func writeJUnit(out io.Writer, suites ...junitTestSuite) error {
+	report := junitTestSuites{
+		Name:   junitSuiteName,
+		Suites: suites,
+	}
+
+	for _, s := range suites {
+		report.Tests += s.Tests
+		report.Failures += s.Failures
+		report.Errors += s.Errors
+		report.Skipped += s.Skipped
+	}
+
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return fmt.Errorf("writing xml header: %w", err)
+	}
+
+	enc := xml.NewEncoder(out)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("encoding results as junit: %w", err)
+	}
+
+	if _, err := io.WriteString(out, "\n"); err != nil {
+		return fmt.Errorf("writing junit: %w", err)
+	}
+
+	return nil
+}
+
+// junitPropertiesFor returns the number of attempts of results
+// which were retried, i.e. which ran more than once.
+func junitPropertiesFor(res validator.Result) *junitProperties {
+	if res.Attempts <= 1 {
+		return nil
+	}
+
+	return &junitProperties{
+		Properties: []junitProperty{
+			{Name: "attempts", Value: fmt.Sprint(res.Attempts)},
+		},
+	}
+}
+
+func withWiki(res validator.Result, msgs ...string) string {
+	lines := append([]string{}, msgs...)
+
+	if url := res.Code.WikiURL(); url != "" {
+		lines = append(lines, "See: "+url)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string           `xml:"name,attr"`
+	ClassName  string           `xml:"classname,attr"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	Failure    *junitMessage    `xml:"failure,omitempty"`
+	Error      *junitMessage    `xml:"error,omitempty"`
+	Skipped    *junitSkipped    `xml:"skipped,omitempty"`
+	SystemOut  string           `xml:"system-out,omitempty"`
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
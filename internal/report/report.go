@@ -0,0 +1,143 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+var ErrUnknownFormat = errors.New("unknown output format")
+
+// Format names a serialization of a validator.ResultList.
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+	FormatJUnit Format = "junit"
+	FormatSARIF Format = "sarif"
+)
+
+// Formats returns all supported output formats.
+func Formats() []Format {
+	return []Format{
+		FormatTable,
+		FormatJSON,
+		FormatYAML,
+		FormatJUnit,
+		FormatSARIF,
+	}
+}
+
+// ParseFormat converts the given string to a Format value.
+// An error is returned if the format is not supported.
+func ParseFormat(maybeFormat string) (Format, error) {
+	for _, f := range Formats() {
+		if strings.EqualFold(maybeFormat, string(f)) {
+			return f, nil
+		}
+	}
+
+	return "", fmt.Errorf("%q: %w", maybeFormat, ErrUnknownFormat)
+}
+
+// Writer serializes a validator.ResultList to an io.Writer.
+type Writer interface {
+	// Write serializes the given results to 'w'. An error
+	// is returned if the results cannot be serialized or written.
+	Write(w io.Writer, results validator.ResultList) error
+}
+
+// NewWriter returns a Writer for the given Format or an
+// error if the format is not supported. Formats which tell
+// failures apart from warnings, e.g. junit, report failures
+// of severity 'failOn' or above as failures.
+func NewWriter(format Format, failOn validator.Severity) (Writer, error) {
+	switch format {
+	case FormatTable:
+		return TableWriter{}, nil
+	case FormatJSON:
+		return JSONWriter{}, nil
+	case FormatYAML:
+		return YAMLWriter{}, nil
+	case FormatJUnit:
+		return JUnitWriter{FailOn: failOn}, nil
+	case FormatSARIF:
+		return SARIFWriter{}, nil
+	default:
+		return nil, fmt.Errorf("%q: %w", format, ErrUnknownFormat)
+	}
+}
+
+// ruleID returns the code of the validator which produced a
+// result or its name for results without a code such as those
+// reporting invalid input.
+func ruleID(res validator.Result) string {
+	if res.Code == 0 {
+		return res.Name
+	}
+
+	return res.Code.String()
+}
+
+// findingsOf returns the findings of a failed result. Results which
+// were not created through a validator.Base only carry messages.
+func findingsOf(res validator.Result) []validator.Finding {
+	if len(res.Findings) > 0 {
+		return res.Findings
+	}
+
+	findings := make([]validator.Finding, 0, len(res.FailureMsgs))
+
+	for _, msg := range res.FailureMsgs {
+		findings = append(findings, validator.Finding{Message: msg})
+	}
+
+	return findings
+}
+
+// findingMessages returns the findings of a failed result as
+// strings where files are displayed relative to the working
+// directory.
+func findingMessages(res validator.Result) []string {
+	findings := findingsOf(res)
+	msgs := make([]string, 0, len(findings))
+
+	for _, f := range findings {
+		if f.Position != nil {
+			pos := *f.Position
+			pos.File = DisplayPath(pos.File)
+			f.Position = &pos
+		}
+
+		msgs = append(msgs, f.String())
+	}
+
+	return msgs
+}
+
+// DisplayPath returns 'path' relative to the working directory
+// unless it is located outside of it.
+func DisplayPath(path string) string {
+	if !filepath.IsAbs(path) {
+		return path
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+
+	return rel
+}
@@ -0,0 +1,492 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/schema"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Input          string
+		Expected       Format
+		ErrorAssertion assert.ErrorAssertionFunc
+	}{
+		"table": {
+			Input:          "table",
+			Expected:       FormatTable,
+			ErrorAssertion: assert.NoError,
+		},
+		"upper case sarif": {
+			Input:          "SARIF",
+			Expected:       FormatSARIF,
+			ErrorAssertion: assert.NoError,
+		},
+		"unknown": {
+			Input:          "csv",
+			ErrorAssertion: assert.Error,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			format, err := ParseFormat(tc.Input)
+			tc.ErrorAssertion(t, err)
+
+			assert.Equal(t, tc.Expected, format)
+		})
+	}
+}
+
+func TestJSONWriter(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, JSONWriter{}.Write(&buf, testResults(t)))
+
+	var actual []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+	require.Len(t, actual, 4)
+
+	assert.Equal(t, "AM0001", actual[0]["code"])
+	assert.Equal(t, "Success", actual[0]["status"])
+	assert.Equal(t, "https://github.com/mt-sre/addon-metadata-operator/wiki/AM0001", actual[0]["wiki"])
+	assert.Equal(t, "Failed", actual[1]["status"])
+	assert.Equal(t, []interface{}{"first", "second"}, actual[1]["failureMessages"])
+	assert.Equal(t, "Error", actual[2]["status"])
+	assert.Equal(t, "boom", actual[2]["error"])
+	assert.Equal(t, "warning", actual[3]["severity"])
+}
+
+func TestYAMLWriter(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, YAMLWriter{}.Write(&buf, testResults(t)))
+
+	var actual validator.ResultList
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &actual))
+	require.Len(t, actual, 4)
+
+	assert.True(t, actual[0].IsSuccess())
+	assert.Equal(t, []string{"first", "second"}, actual[1].FailureMsgs)
+	assert.EqualError(t, actual[2].Error, "boom")
+	assert.Equal(t, validator.SeverityWarning, actual[3].Severity)
+}
+
+func TestJUnitWriter(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, JUnitWriter{FailOn: validator.SeverityError}.Write(&buf, testResults(t)))
+
+	var actual junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &actual))
+
+	assert.Equal(t, 4, actual.Tests)
+	assert.Equal(t, 1, actual.Failures)
+	assert.Equal(t, 1, actual.Errors)
+	require.Len(t, actual.Suites, 1)
+	require.Len(t, actual.Suites[0].TestCases, 4)
+
+	cases := actual.Suites[0].TestCases
+	assert.Nil(t, cases[0].Failure)
+	assert.Nil(t, cases[0].Error)
+	require.NotNil(t, cases[1].Failure)
+	assert.Contains(t, cases[1].Failure.Body, "second")
+	assert.Contains(t, cases[1].Failure.Body, "wiki/AM0002")
+	require.NotNil(t, cases[2].Error)
+	assert.Equal(t, "boom", cases[2].Error.Message)
+	assert.Nil(t, cases[3].Failure)
+	assert.Contains(t, cases[3].SystemOut, "warning")
+}
+
+func TestJUnitWriterFailOnWarning(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, JUnitWriter{FailOn: validator.SeverityWarning}.Write(&buf, testResults(t)))
+
+	var actual junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &actual))
+
+	assert.Equal(t, 2, actual.Failures)
+	require.Len(t, actual.Suites, 1)
+	require.Len(t, actual.Suites[0].TestCases, 4)
+
+	warning := actual.Suites[0].TestCases[3]
+	require.NotNil(t, warning.Failure)
+	assert.Contains(t, warning.Failure.Body, "lint")
+	assert.Empty(t, warning.SystemOut)
+}
+
+func TestSARIFWriter(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, SARIFWriter{}.Write(&buf, testResults(t)))
+
+	var actual sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+
+	assert.Equal(t, "2.1.0", actual.Version)
+	require.Len(t, actual.Runs, 1)
+
+	run := actual.Runs[0]
+	assert.Len(t, run.Tool.Driver.Rules, 4)
+	require.Len(t, run.Results, 4)
+	assert.Equal(t, "warning", run.Results[3].Level)
+	require.Len(t, run.Invocations, 1)
+	assert.False(t, run.Invocations[0].ExecutionSuccessful)
+	require.Len(t, run.Invocations[0].Notifications, 1)
+	assert.Equal(t, "AM0003", run.Invocations[0].Notifications[0].AssociatedRule.ID)
+}
+
+func TestWritersReportInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	results := validator.ResultList{
+		validator.NewInvalidInputResult(schema.DecodeIssue{
+			File:    "addon.yaml",
+			Line:    3,
+			Column:  1,
+			Kind:    schema.IssueUnknownField,
+			Path:    "addonOwnr",
+			Message: "unknown field",
+		}),
+	}
+
+	var jsonBuf bytes.Buffer
+
+	require.NoError(t, JSONWriter{}.Write(&jsonBuf, results))
+
+	var restored validator.ResultList
+	require.NoError(t, json.Unmarshal(jsonBuf.Bytes(), &restored))
+	require.Len(t, restored, 1)
+	assert.True(t, restored[0].IsInvalid())
+	assert.Equal(t, validator.ResultStatusInvalid, restored[0].Status())
+	require.Len(t, restored[0].Findings, 1)
+	assert.Equal(t, "addon.yaml:3:1: addonOwnr: unknown field", restored[0].Findings[0].String())
+	assert.NotContains(t, jsonBuf.String(), `"code"`)
+
+	var junitBuf bytes.Buffer
+
+	require.NoError(t, JUnitWriter{FailOn: validator.SeverityError}.Write(&junitBuf, results))
+
+	var suites junitTestSuites
+	require.NoError(t, xml.Unmarshal(junitBuf.Bytes(), &suites))
+	assert.Equal(t, 1, suites.Failures)
+	require.NotNil(t, suites.Suites[0].TestCases[0].Failure)
+	assert.Equal(t, "Invalid", suites.Suites[0].TestCases[0].Failure.Type)
+	assert.Equal(t, "strict_decoding", suites.Suites[0].TestCases[0].Name)
+	assert.NotContains(t, junitBuf.String(), "See: ")
+
+	var sarifBuf bytes.Buffer
+
+	require.NoError(t, SARIFWriter{}.Write(&sarifBuf, results))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(sarifBuf.Bytes(), &log))
+	require.Len(t, log.Runs[0].Results, 1)
+	assert.Equal(t, "error", log.Runs[0].Results[0].Level)
+	assert.Equal(t, "strict_decoding", log.Runs[0].Results[0].RuleID)
+	assert.Empty(t, log.Runs[0].Tool.Driver.Rules[0].HelpURI)
+	assert.True(t, results.HasFailureAtOrAbove(validator.SeverityError))
+}
+
+func TestWritersReportSkipped(t *testing.T) {
+	t.Parallel()
+
+	base, err := validator.NewBase(7, validator.BaseName("dummy"))
+	require.NoError(t, err)
+
+	results := validator.ResultList{
+		validator.NewSkippedResult(dummyValidator{Base: base}, "prerequisite AM0021 failed"),
+	}
+
+	assert.False(t, results.HasFailure())
+
+	var jsonBuf bytes.Buffer
+
+	require.NoError(t, JSONWriter{}.Write(&jsonBuf, results))
+
+	var restored validator.ResultList
+	require.NoError(t, json.Unmarshal(jsonBuf.Bytes(), &restored))
+	require.Len(t, restored, 1)
+	assert.Equal(t, validator.ResultStatusSkipped, restored[0].Status())
+	assert.Equal(t, "prerequisite AM0021 failed", restored[0].SkipReason)
+
+	var junitBuf bytes.Buffer
+
+	require.NoError(t, JUnitWriter{FailOn: validator.SeverityError}.Write(&junitBuf, results))
+
+	var suites junitTestSuites
+	require.NoError(t, xml.Unmarshal(junitBuf.Bytes(), &suites))
+	assert.Equal(t, 0, suites.Failures)
+	assert.Equal(t, 1, suites.Skipped)
+	require.NotNil(t, suites.Suites[0].TestCases[0].Skipped)
+	assert.Equal(t, "prerequisite AM0021 failed", suites.Suites[0].TestCases[0].Skipped.Message)
+
+	var sarifBuf bytes.Buffer
+
+	require.NoError(t, SARIFWriter{}.Write(&sarifBuf, results))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(sarifBuf.Bytes(), &log))
+	require.Len(t, log.Runs[0].Results, 1)
+	assert.Equal(t, "notApplicable", log.Runs[0].Results[0].Kind)
+	assert.True(t, log.Runs[0].Invocations[0].ExecutionSuccessful)
+}
+
+func TestWritersReportIncomplete(t *testing.T) {
+	t.Parallel()
+
+	base, err := validator.NewBase(11, validator.BaseName("dummy"))
+	require.NoError(t, err)
+
+	results := validator.ResultList{
+		validator.NewTimedOutResult(dummyValidator{Base: base}, time.Minute),
+		validator.NewCancelledResult(dummyValidator{Base: base}, context.Canceled),
+	}
+
+	assert.Len(t, results.Errors(), 2)
+
+	var jsonBuf bytes.Buffer
+
+	require.NoError(t, JSONWriter{}.Write(&jsonBuf, results))
+
+	var restored validator.ResultList
+	require.NoError(t, json.Unmarshal(jsonBuf.Bytes(), &restored))
+	require.Len(t, restored, 2)
+	assert.Equal(t, validator.ResultStatusTimedOut, restored[0].Status())
+	assert.Equal(t, validator.ResultStatusCancelled, restored[1].Status())
+
+	var junitBuf bytes.Buffer
+
+	require.NoError(t, JUnitWriter{FailOn: validator.SeverityError}.Write(&junitBuf, results))
+
+	var suites junitTestSuites
+	require.NoError(t, xml.Unmarshal(junitBuf.Bytes(), &suites))
+	assert.Equal(t, 2, suites.Errors)
+	require.NotNil(t, suites.Suites[0].TestCases[0].Error)
+	assert.Equal(t, "TimedOut", suites.Suites[0].TestCases[0].Error.Type)
+	assert.Equal(t, "timed out after 1m0s: context deadline exceeded", suites.Suites[0].TestCases[0].Error.Message)
+	require.NotNil(t, suites.Suites[0].TestCases[1].Error)
+	assert.Equal(t, "Cancelled", suites.Suites[0].TestCases[1].Error.Type)
+
+	var sarifBuf bytes.Buffer
+
+	require.NoError(t, SARIFWriter{}.Write(&sarifBuf, results))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(sarifBuf.Bytes(), &log))
+	assert.False(t, log.Runs[0].Invocations[0].ExecutionSuccessful)
+	assert.Len(t, log.Runs[0].Invocations[0].Notifications, 2)
+}
+
+func TestWritersReportAttempts(t *testing.T) {
+	t.Parallel()
+
+	base, err := validator.NewBase(11, validator.BaseName("dummy"))
+	require.NoError(t, err)
+
+	retried := base.Success()
+	retried.Attempts = 3
+
+	failed := base.Error(errors.New("service unavailable"))
+	failed.Attempts = 5
+
+	results := validator.ResultList{retried, failed}
+
+	var tableBuf bytes.Buffer
+
+	require.NoError(t, TableWriter{}.Write(&tableBuf, results))
+	assert.Contains(t, tableBuf.String(), "Success (3 attempts)")
+	assert.Contains(t, tableBuf.String(), "Error (5 attempts)")
+
+	var jsonBuf bytes.Buffer
+
+	require.NoError(t, JSONWriter{}.Write(&jsonBuf, results))
+
+	var restored validator.ResultList
+	require.NoError(t, json.Unmarshal(jsonBuf.Bytes(), &restored))
+	require.Len(t, restored, 2)
+	assert.Equal(t, 3, restored[0].Attempts)
+	assert.Equal(t, 5, restored[1].Attempts)
+
+	var junitBuf bytes.Buffer
+
+	require.NoError(t, JUnitWriter{FailOn: validator.SeverityError}.Write(&junitBuf, results))
+
+	var suites junitTestSuites
+	require.NoError(t, xml.Unmarshal(junitBuf.Bytes(), &suites))
+	require.NotNil(t, suites.Suites[0].TestCases[0].Properties)
+	assert.Equal(t, []junitProperty{{Name: "attempts", Value: "3"}}, suites.Suites[0].TestCases[0].Properties.Properties)
+
+	var sarifBuf bytes.Buffer
+
+	require.NoError(t, SARIFWriter{}.Write(&sarifBuf, results))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(sarifBuf.Bytes(), &log))
+	assert.Equal(t, float64(3), log.Runs[0].Results[0].Properties["attempts"])
+	assert.Equal(t, float64(5), log.Runs[0].Invocations[0].Notifications[0].Properties["attempts"])
+}
+
+func TestTableWriterReportsCachedResults(t *testing.T) {
+	t.Parallel()
+
+	base, err := validator.NewBase(11, validator.BaseName("dummy"))
+	require.NoError(t, err)
+
+	cached := base.Fail("failed")
+	cached.Cached = true
+
+	var buf bytes.Buffer
+
+	require.NoError(t, TableWriter{}.Write(&buf, validator.ResultList{cached, base.Success()}))
+	assert.Contains(t, buf.String(), "Failed (cached)")
+	assert.NotContains(t, buf.String(), "Success (cached)")
+}
+
+func TestWriteTimings(t *testing.T) {
+	t.Parallel()
+
+	newBase := func(code validator.Code, name string) *validator.Base {
+		base, err := validator.NewBase(code, validator.BaseName(name))
+		require.NoError(t, err)
+
+		return base
+	}
+
+	fast := newBase(1, "fast").Success()
+	fast.Attempts = 1
+	fast.Duration = 20 * time.Millisecond
+
+	slow := newBase(2, "slow").Success()
+	slow.Attempts = 3
+	slow.Duration = 1500 * time.Millisecond
+
+	results := validator.ResultList{
+		fast,
+		slow,
+		validator.NewSkippedResult(dummyValidator{Base: newBase(3, "skipped")}, "prerequisite AM0002 failed"),
+	}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, WriteTimings(&buf, results, Timings{
+		Extraction: 4 * time.Second,
+		Validation: 1600 * time.Millisecond,
+	}))
+
+	out := buf.String()
+
+	assert.Less(t, strings.Index(out, "slow"), strings.Index(out, "fast"))
+	assert.NotContains(t, out, "skipped")
+	assert.Contains(t, out, "1.5s")
+	assert.Contains(t, out, "4s")
+	assert.Contains(t, out, "1.6s")
+	assert.Contains(t, out, "1.52s")
+}
+
+func TestWritersLocateFindings(t *testing.T) {
+	t.Parallel()
+
+	base, err := validator.NewBase(1,
+		validator.BaseName("dummy"),
+		validator.BaseDesc("dummy validator"),
+	)
+	require.NoError(t, err)
+
+	results := validator.ResultList{
+		base.FailWith(
+			validator.NewFinding("defaultChannel", "channel %q is not defined", "beta"),
+			validator.Finding{Message: "unlocated"},
+		).Locate(schema.Positions{
+			"defaultChannel": {File: "addon.yaml", Line: 4, Column: 1},
+		}),
+	}
+
+	var tableBuf bytes.Buffer
+
+	require.NoError(t, TableWriter{}.Write(&tableBuf, results))
+	assert.Contains(t, tableBuf.String(), `addon.yaml:4:1: channel "beta" is not defined`)
+
+	var sarifBuf bytes.Buffer
+
+	require.NoError(t, SARIFWriter{}.Write(&sarifBuf, results))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(sarifBuf.Bytes(), &log))
+	require.Len(t, log.Runs[0].Results, 2)
+
+	located := log.Runs[0].Results[0]
+	require.Len(t, located.Locations, 1)
+	require.NotNil(t, located.Locations[0].PhysicalLocation)
+	assert.Equal(t, "addon.yaml", located.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 4, located.Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Equal(t, "defaultChannel", located.Locations[0].LogicalLocations[0].FullyQualifiedName)
+	assert.Empty(t, log.Runs[0].Results[1].Locations)
+
+	var jsonBuf bytes.Buffer
+
+	require.NoError(t, JSONWriter{}.Write(&jsonBuf, results))
+
+	var restored validator.ResultList
+	require.NoError(t, json.Unmarshal(jsonBuf.Bytes(), &restored))
+	require.Len(t, restored[0].Findings, 2)
+	assert.Equal(t, "defaultChannel", restored[0].Findings[0].Path)
+	assert.Equal(t, 4, restored[0].Findings[0].Position.Line)
+	assert.Equal(t, []string{`channel "beta" is not defined`, "unlocated"}, restored[0].FailureMsgs)
+}
+
+func testResults(t *testing.T) validator.ResultList {
+	t.Helper()
+
+	newBase := func(code validator.Code) *validator.Base {
+		base, err := validator.NewBase(code,
+			validator.BaseName("dummy"),
+			validator.BaseDesc("dummy validator"),
+		)
+		require.NoError(t, err)
+
+		return base
+	}
+
+	return validator.ResultList{
+		newBase(1).Success(),
+		newBase(2).Fail("first", "second"),
+		newBase(3).Error(errors.New("boom")),
+		newBase(4).Fail("lint").WithSeverity(validator.SeverityWarning),
+	}
+}
+
+type dummyValidator struct {
+	*validator.Base
+}
+
+func (v dummyValidator) Run(context.Context, types.MetaBundle) validator.Result {
+	return v.Success()
+}
@@ -0,0 +1,294 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	sarifToolURI = "https://github.com/mt-sre/addon-metadata-operator"
+)
+
+// SARIFWriter serializes results as a SARIF v2.1.0 log. Each
+// validator is described as a rule and every failure message
+// produces a result which is located in the addon metadata files
+// if its position is known. Validator errors are reported as tool
+// execution notifications since they do not describe a finding.
+type SARIFWriter struct{}
+
+func (w SARIFWriter) Write(out io.Writer, results validator.ResultList) error {
+	return writeSARIF(out, sarifRunFor(results))
+}
+
+// WriteAggregate serializes reports as a SARIF v2.1.0 log with
+// one run per addon and environment. Each run is identified by
+// its automation details so that consumers can tell them apart.
+// Addons which could not be loaded produce a failed invocation.
+func (w SARIFWriter) WriteAggregate(out io.Writer, reports []AddonReport) error {
+	runs := make([]sarifRun, 0, len(reports))
+
+	for _, r := range reports {
+		var run sarifRun
+
+		if r.Err == nil {
+			run = sarifRunFor(r.Results)
+		} else {
+			run = newSARIFRun()
+			run.Invocations = []sarifInvocation{
+				{
+					ExecutionSuccessful: false,
+					Notifications: []sarifNotification{
+						{
+							Level:   "error",
+							Message: sarifMessage{Text: r.Err.Error()},
+						},
+					},
+				},
+			}
+		}
+
+		// a trailing slash marks the id as a category without a run id
+		run.AutomationDetails = &sarifAutomationDetails{ID: r.Name() + "/"}
+
+		runs = append(runs, run)
+	}
+
+	return writeSARIF(out, runs...)
+}
+
+func newSARIFRun() sarifRun {
+	return sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "mtcli",
+				InformationURI: sarifToolURI,
+				Rules:          []sarifRule{},
+			},
+		},
+		Results: []sarifResult{},
+	}
+}
+
+func sarifRunFor(results validator.ResultList) sarifRun {
+	run := newSARIFRun()
+
+	invocation := sarifInvocation{ExecutionSuccessful: true}
+
+	for i, res := range results {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               ruleID(res),
+			Name:             res.Name,
+			ShortDescription: sarifMessage{Text: res.Description},
+			HelpURI:          res.Code.WikiURL(),
+		})
+
+		switch res.Status() {
+		case validator.ResultStatusSuccess:
+			run.Results = append(run.Results, sarifResult{
+				RuleID:     ruleID(res),
+				RuleIndex:  i,
+				Kind:       "pass",
+				Level:      "none",
+				Message:    sarifMessage{Text: "None"},
+				Properties: sarifPropertiesFor(res),
+			})
+		case validator.ResultStatusFailed, validator.ResultStatusInvalid:
+			for _, f := range findingsOf(res) {
+				run.Results = append(run.Results, sarifResult{
+					RuleID:     ruleID(res),
+					RuleIndex:  i,
+					Kind:       "fail",
+					Level:      sarifLevel(res.Severity),
+					Message:    sarifMessage{Text: f.Message},
+					Locations:  sarifLocationsFor(f),
+					Properties: sarifPropertiesFor(res),
+				})
+			}
+		case validator.ResultStatusSkipped:
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    ruleID(res),
+				RuleIndex: i,
+				Kind:      "notApplicable",
+				Level:     "none",
+				Message:   sarifMessage{Text: res.SkipReason},
+			})
+		case validator.ResultStatusError, validator.ResultStatusTimedOut, validator.ResultStatusCancelled:
+			invocation.ExecutionSuccessful = false
+			invocation.Notifications = append(invocation.Notifications, sarifNotification{
+				Level:   "error",
+				Message: sarifMessage{Text: res.Error.Error()},
+				AssociatedRule: &sarifRuleReference{
+					ID:    ruleID(res),
+					Index: i,
+				},
+				Properties: sarifPropertiesFor(res),
+			})
+		}
+	}
+
+	run.Invocations = []sarifInvocation{invocation}
+
+	return run
+}
+
+// sarifPropertiesFor returns the number of attempts of results
+// which were retried, i.e. which ran more than once.
+func sarifPropertiesFor(res validator.Result) map[string]interface{} {
+	if res.Attempts <= 1 {
+		return nil
+	}
+
+	return map[string]interface{}{"attempts": res.Attempts}
+}
+
+func sarifLocationsFor(f validator.Finding) []sarifLocation {
+	if f.Position == nil && f.Path == "" {
+		return nil
+	}
+
+	var loc sarifLocation
+
+	if f.Position != nil {
+		loc.PhysicalLocation = &sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{
+				URI: filepath.ToSlash(DisplayPath(f.Position.File)),
+			},
+			Region: sarifRegion{
+				StartLine:   f.Position.Line,
+				StartColumn: f.Position.Column,
+			},
+		}
+	}
+
+	if f.Path != "" {
+		loc.LogicalLocations = []sarifLogicalLocation{
+			{FullyQualifiedName: f.Path},
+		}
+	}
+
+	return []sarifLocation{loc}
+}
+
+func writeSARIF(out io.Writer, runs ...sarifRun) error {
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    runs,
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(log); err != nil {
+		return fmt.Errorf("encoding results as sarif: %w", err)
+	}
+
+	return nil
+}
+
+func sarifLevel(sev validator.Severity) string {
+	switch sev {
+	case validator.SeverityInfo:
+		return "note"
+	case validator.SeverityWarning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool              sarifTool               `json:"tool"`
+	AutomationDetails *sarifAutomationDetails `json:"automationDetails,omitempty"`
+	Invocations       []sarifInvocation       `json:"invocations"`
+	Results           []sarifResult           `json:"results"`
+}
+
+type sarifAutomationDetails struct {
+	ID string `json:"id"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	HelpURI          string       `json:"helpUri,omitempty"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful bool                `json:"executionSuccessful"`
+	Notifications       []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level          string              `json:"level"`
+	Message        sarifMessage        `json:"message"`
+	AssociatedRule *sarifRuleReference `json:"associatedRule,omitempty"`
+	// Properties holds the number of attempts of retried validators.
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifRuleReference struct {
+	ID    string `json:"id"`
+	Index int    `json:"index"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Kind      string          `json:"kind"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+	// Properties holds the number of attempts of retried validators.
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
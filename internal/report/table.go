@@ -0,0 +1,205 @@
+package report
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+// TableWriter renders results as a colored table intended
+// to be read by humans.
+type TableWriter struct{}
+
+func (w TableWriter) Write(out io.Writer, results validator.ResultList) error {
+	table, err := cli.NewTable(
+		cli.WithHeaders{"STATUS", "CODE", "NAME", "DESCRIPTION", "FAILURE MESSAGE"},
+	)
+	if err != nil {
+		return fmt.Errorf("initializing table: %w", err)
+	}
+
+	for _, res := range results {
+		writeResult(table, res)
+	}
+
+	fmt.Fprintln(out, table.String())
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, wikiFooter)
+
+	return nil
+}
+
+const wikiFooter = "Please consult corresponding validator wikis: https://github.com/mt-sre/addon-metadata-operator/wiki/<code>."
+
+// WriteAggregate renders one table per addon and environment
+// followed by a summary table with one row per addon.
+func (w TableWriter) WriteAggregate(out io.Writer, reports []AddonReport) error {
+	summary, err := cli.NewTable(
+		cli.WithHeaders{"ADDON", "ENV", "STATUS", "FAILURES"},
+	)
+	if err != nil {
+		return fmt.Errorf("initializing table: %w", err)
+	}
+
+	for _, r := range reports {
+		fmt.Fprintf(out, "==> %s (%s)\n", r.Addon, r.Env)
+
+		if r.Err != nil {
+			fmt.Fprintf(out, "%s %s\n\n", cli.FieldColorIntenselyBoldRed.Apply("Error:"), r.Err)
+		} else {
+			table, err := cli.NewTable(
+				cli.WithHeaders{"STATUS", "CODE", "NAME", "DESCRIPTION", "FAILURE MESSAGE"},
+			)
+			if err != nil {
+				return fmt.Errorf("initializing table: %w", err)
+			}
+
+			for _, res := range r.Results {
+				writeResult(table, res)
+			}
+
+			fmt.Fprintln(out, table.String())
+			fmt.Fprintln(out)
+		}
+
+		summary.WriteRow(summaryRow(r))
+	}
+
+	fmt.Fprintln(out, summary.String())
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, wikiFooter)
+
+	return nil
+}
+
+func summaryRow(r AddonReport) cli.TableRow {
+	var (
+		status   cli.Field
+		failures int
+	)
+
+	for _, res := range r.Results {
+		if res.IsSuccess() || res.IsError() || res.IsSkipped() {
+			continue
+		}
+
+		failures++
+	}
+
+	switch {
+	case r.IsError():
+		status = cli.Field{
+			Value: "Error",
+			Color: cli.FieldColorIntenselyBoldRed,
+		}
+	case failures > 0:
+		status = cli.Field{
+			Value: "Failed",
+			Color: cli.FieldColorRed,
+		}
+	default:
+		status = cli.Field{
+			Value: "Success",
+			Color: cli.FieldColorGreen,
+		}
+	}
+
+	return cli.TableRow{
+		cli.Field{Value: r.Addon},
+		cli.Field{Value: r.Env},
+		status,
+		cli.Field{Value: fmt.Sprint(failures)},
+	}
+}
+
+func writeResult(t *cli.Table, res validator.Result) {
+	row := resultToRow(res)
+
+	if res.IsSuccess() {
+		t.WriteRow(append(row, cli.Field{Value: "None"}))
+	} else if res.IsError() {
+		t.WriteRow(append(row, cli.Field{Value: res.Error.Error()}))
+	} else if res.IsSkipped() {
+		t.WriteRow(append(row, cli.Field{Value: res.SkipReason}))
+	} else {
+		for _, msg := range findingMessages(res) {
+			t.WriteRow(append(row, cli.Field{Value: msg}))
+		}
+	}
+}
+
+func resultToRow(res validator.Result) cli.TableRow {
+	var status cli.Field
+
+	if res.IsSuccess() {
+		status = cli.Field{
+			Value: "Success",
+			Color: cli.FieldColorGreen,
+		}
+	} else if res.IsTimedOut() {
+		status = cli.Field{
+			Value: "Timed out",
+			Color: cli.FieldColorIntenselyBoldRed,
+		}
+	} else if res.IsCancelled() {
+		status = cli.Field{
+			Value: "Cancelled",
+			Color: cli.FieldColorIntenselyBoldRed,
+		}
+	} else if res.IsError() {
+		status = cli.Field{
+			Value: "Error",
+			Color: cli.FieldColorIntenselyBoldRed,
+		}
+	} else if res.IsInvalid() {
+		status = cli.Field{
+			Value: "Invalid",
+			Color: cli.FieldColorMagenta,
+		}
+	} else if res.IsSkipped() {
+		status = cli.Field{
+			Value: "Skipped",
+			Color: cli.FieldColorCyan,
+		}
+	} else {
+		status = failedStatusField(res.Severity)
+	}
+
+	// repeated attempts hint at flaky services such as Quay or OCM
+	if res.Attempts > 1 {
+		status.Value = fmt.Sprintf("%s (%d attempts)", status.Value, res.Attempts)
+	}
+
+	if res.Cached {
+		status.Value = fmt.Sprintf("%s (cached)", status.Value)
+	}
+
+	return cli.TableRow{
+		status,
+		cli.Field{Value: res.Code.String()},
+		cli.Field{Value: res.Name},
+		cli.Field{Value: res.Description},
+	}
+}
+
+func failedStatusField(sev validator.Severity) cli.Field {
+	switch sev {
+	case validator.SeverityInfo:
+		return cli.Field{
+			Value: "Info",
+			Color: cli.FieldColorCyan,
+		}
+	case validator.SeverityWarning:
+		return cli.Field{
+			Value: "Warning",
+			Color: cli.FieldColorYellow,
+		}
+	default:
+		return cli.Field{
+			Value: "Failed",
+			Color: cli.FieldColorRed,
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+// Timings holds the time spent validating a single
+// addon against a single environment.
+type Timings struct {
+	// Extraction is the time spent extracting and parsing bundles.
+	Extraction time.Duration
+	// Validation is the time spent running validators.
+	Validation time.Duration
+}
+
+// WriteTimings renders one row per validator which was run ordered by
+// duration, longest first, followed by the time spent on each phase.
+func WriteTimings(out io.Writer, results validator.ResultList, timings Timings) error {
+	table, err := cli.NewTable(
+		cli.WithHeaders{"CODE", "NAME", "STATUS", "ATTEMPTS", "DURATION"},
+	)
+	if err != nil {
+		return fmt.Errorf("initializing table: %w", err)
+	}
+
+	run := make(validator.ResultList, 0, len(results))
+
+	for _, res := range results {
+		if res.Attempts > 0 {
+			run = append(run, res)
+		}
+	}
+
+	sort.SliceStable(run, func(i, j int) bool { return run[i].Duration > run[j].Duration })
+
+	var total time.Duration
+
+	for _, res := range run {
+		total += res.Duration
+
+		table.WriteRow(cli.TableRow{
+			cli.Field{Value: res.Code.String()},
+			cli.Field{Value: res.Name},
+			cli.Field{Value: string(res.Status())},
+			cli.Field{Value: fmt.Sprint(res.Attempts)},
+			cli.Field{Value: formatDuration(res.Duration)},
+		})
+	}
+
+	phases, err := cli.NewTable(
+		cli.WithHeaders{"PHASE", "DURATION"},
+	)
+	if err != nil {
+		return fmt.Errorf("initializing table: %w", err)
+	}
+
+	phases.WriteRow(cli.TableRow{
+		cli.Field{Value: "extraction"},
+		cli.Field{Value: formatDuration(timings.Extraction)},
+	})
+	phases.WriteRow(cli.TableRow{
+		cli.Field{Value: "validation"},
+		cli.Field{Value: formatDuration(timings.Validation)},
+	})
+	// validators run concurrently so their total
+	// usually exceeds the validation phase
+	phases.WriteRow(cli.TableRow{
+		cli.Field{Value: "validators (total)"},
+		cli.Field{Value: formatDuration(total)},
+	})
+
+	fmt.Fprintln(out, table.String())
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, phases.String())
+	fmt.Fprintln(out)
+
+	return nil
+}
+
+// WriteAggregateTimings renders the timings of every addon
+// and environment which could be validated.
+func WriteAggregateTimings(out io.Writer, reports []AddonReport) error {
+	for _, r := range reports {
+		if r.Err != nil {
+			continue
+		}
+
+		fmt.Fprintf(out, "==> %s (%s)\n", r.Addon, r.Env)
+
+		if err := WriteTimings(out, r.Results, r.Timings); err != nil {
+			return fmt.Errorf("writing timings of %s: %w", r.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Millisecond).String()
+}
@@ -51,6 +51,10 @@ func (t TestDataTree) Bundles() string {
 	return filepath.Join(string(t), "bundles")
 }
 
+func (t TestDataTree) Catalogs() string {
+	return filepath.Join(string(t), "catalogs")
+}
+
 func (t TestDataTree) Validators() string {
 	return filepath.Join(string(t), "validators")
 }
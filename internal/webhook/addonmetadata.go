@@ -0,0 +1,190 @@
+// Package webhook admits addon CRDs by running the validators
+// which only depend on the addon metadata against them after
+// combining them with the AddonImageSet they reference.
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/internal/imageset"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/validate-addonsflow-redhat-openshift-io-v1alpha1-addonmetadata,mutating=false,failurePolicy=fail,sideEffects=None,groups=addonsflow.redhat.openshift.io,resources=addonmetadata,verbs=create;update,versions=v1alpha1,name=vaddonmetadata.addonsflow.redhat.openshift.io,admissionReviewVersions=v1
+
+// ValidatingPath is the path at which AddonMetadata objects are admitted.
+const ValidatingPath = "/validate-addonsflow-redhat-openshift-io-v1alpha1-addonmetadata"
+
+// DefaultValidatorTimeout bounds the validators run for a single
+// admission request. It stays well below the 10s after which the API
+// server gives up on the webhook so that a denial is always returned
+// in time.
+const DefaultValidatorTimeout = 5 * time.Second
+
+// metadataOnly excludes Validators which cannot run within the time
+// an admission request may take. They are run by the controller once
+// the object has been admitted.
+var metadataOnly = validator.Not(validator.Requires(
+	validator.RequiresBundles,
+	validator.RequiresNetwork,
+))
+
+// NewAddonMetadataWebhook returns an AddonMetadataWebhook resolving
+// AddonImageSets through 'c' and configured with a variadic slice of
+// options. An error is returned if the default Runner cannot be
+// initialized.
+func NewAddonMetadataWebhook(c client.Reader, opts ...AddonMetadataWebhookOption) (*AddonMetadataWebhook, error) {
+	cfg := AddonMetadataWebhookConfig{
+		DenyAt: validator.SeverityError,
+	}
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	if cfg.Runner == nil {
+		runner, err := validator.NewRunner(
+			validator.WithLogger{Logger: cfg.Logger},
+			validator.WithTimeout(DefaultValidatorTimeout),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("initializing validators: %w", err)
+		}
+
+		cfg.Runner = runner
+	}
+
+	return &AddonMetadataWebhook{
+		cfg:    cfg,
+		client: c,
+	}, nil
+}
+
+// AddonMetadataWebhook denies AddonMetadata objects failing validators
+// at or above the configured Severity and warns about all other failures.
+type AddonMetadataWebhook struct {
+	cfg    AddonMetadataWebhookConfig
+	client client.Reader
+}
+
+var _ admission.CustomValidator = (*AddonMetadataWebhook)(nil)
+
+// SetupWithManager registers the webhook with the webhook server of 'mgr'.
+func (w *AddonMetadataWebhook) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&addonsv1alpha1.AddonMetadata{}).
+		WithValidator(w).
+		Complete()
+}
+
+func (w *AddonMetadataWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return w.validate(ctx, obj)
+}
+
+func (w *AddonMetadataWebhook) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return w.validate(ctx, newObj)
+}
+
+func (w *AddonMetadataWebhook) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (w *AddonMetadataWebhook) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	addon, ok := obj.(*addonsv1alpha1.AddonMetadata)
+	if !ok {
+		return nil, fmt.Errorf("expected an AddonMetadata but got %T", obj)
+	}
+
+	spec, _, err := imageset.Combine(ctx, w.client, addon)
+	if err != nil {
+		var unresolved *imageset.UnresolvedError
+
+		switch {
+		case errors.Is(err, imageset.ErrNotFound):
+			// The controller validates the addon once the imageset is created.
+			return admission.Warnings{
+				fmt.Sprintf("%v: validation is deferred until the imageset exists", err),
+			}, nil
+		case errors.As(err, &unresolved):
+			return nil, fmt.Errorf("%w: %v", ErrDenied, err)
+		default:
+			return nil, fmt.Errorf("resolving imageset: %w", err)
+		}
+	}
+
+	var results validator.ResultList
+
+	for res := range w.cfg.Runner.Run(ctx, types.MetaBundle{AddonMeta: spec}, metadataOnly) {
+		results = append(results, res)
+	}
+
+	sort.Sort(results)
+
+	return Review(results, w.cfg.DenyAt)
+}
+
+// ErrDenied is returned by Review if any result leads to a denial.
+var ErrDenied = errors.New("addon metadata failed validation")
+
+// Review decides whether to admit an object from the results of the
+// validators run against it. Failures at or above 'denyAt' deny the
+// object, wrapping ErrDenied, while all other failures as well as
+// validator errors are returned as warnings.
+func Review(results validator.ResultList, denyAt validator.Severity) (admission.Warnings, error) {
+	var (
+		warnings admission.Warnings
+		denials  []string
+	)
+
+	for _, res := range results {
+		switch {
+		case res.IsSuccess(), res.IsSkipped():
+		case res.IsError():
+			warnings = append(warnings, fmt.Sprintf("%s %s: errored: %v", res.Code, res.Name, res.Error))
+		case res.Severity >= denyAt:
+			denials = append(denials, describe(res)...)
+		default:
+			warnings = append(warnings, describe(res)...)
+		}
+	}
+
+	if len(denials) > 0 {
+		return warnings, fmt.Errorf("%w: %s", ErrDenied, strings.Join(denials, "; "))
+	}
+
+	return warnings, nil
+}
+
+// describe returns one message per finding of 'res' prefixed
+// with the code and name of the validator and the field path.
+func describe(res validator.Result) []string {
+	prefix := fmt.Sprintf("%s %s", res.Code, res.Name)
+
+	if len(res.Findings) == 0 {
+		return []string{prefix + ": failed"}
+	}
+
+	msgs := make([]string, 0, len(res.Findings))
+
+	for _, f := range res.Findings {
+		if f.Path == "" {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", prefix, f.Message))
+
+			continue
+		}
+
+		msgs = append(msgs, fmt.Sprintf("%s: spec.%s: %s", prefix, f.Path, f.Message))
+	}
+
+	return msgs
+}
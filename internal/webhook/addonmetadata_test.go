@@ -0,0 +1,96 @@
+package webhook
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReview(t *testing.T) {
+	t.Parallel()
+
+	errorBase := newTestBase(t, 2, validator.SeverityError)
+	warningBase := newTestBase(t, 6, validator.SeverityWarning)
+
+	for name, tc := range map[string]struct {
+		Results          validator.ResultList
+		DenyAt           validator.Severity
+		ExpectedWarnings []string
+		ExpectedDenial   string
+	}{
+		"all succeeded": {
+			Results: validator.ResultList{errorBase.Success(), warningBase.Success()},
+			DenyAt:  validator.SeverityError,
+		},
+		"warning below threshold": {
+			Results: validator.ResultList{
+				errorBase.Success(),
+				warningBase.FailWith(validator.NewFinding("dms.snitchNamePostFix", "must not start with 'hive-'")),
+			},
+			DenyAt: validator.SeverityError,
+			ExpectedWarnings: []string{
+				"AM0006 warning_validator: spec.dms.snitchNamePostFix: must not start with 'hive-'",
+			},
+		},
+		"error at threshold": {
+			Results: validator.ResultList{
+				errorBase.FailWith(validator.NewFinding("label", "invalid label")),
+				warningBase.Fail("unlocated"),
+			},
+			DenyAt:           validator.SeverityError,
+			ExpectedWarnings: []string{"AM0006 warning_validator: unlocated"},
+			ExpectedDenial:   "AM0002 error_validator: spec.label: invalid label",
+		},
+		"lowered threshold": {
+			Results: validator.ResultList{
+				errorBase.Success(),
+				warningBase.Fail("unlocated"),
+			},
+			DenyAt:         validator.SeverityWarning,
+			ExpectedDenial: "AM0006 warning_validator: unlocated",
+		},
+		"errors are warnings": {
+			Results: validator.ResultList{
+				errorBase.Error(errors.New("boom")),
+			},
+			DenyAt:           validator.SeverityError,
+			ExpectedWarnings: []string{"AM0002 error_validator: errored: boom"},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			warnings, err := Review(tc.Results, tc.DenyAt)
+
+			assert.Equal(t, tc.ExpectedWarnings, []string(warnings))
+
+			if tc.ExpectedDenial == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, ErrDenied)
+			assert.Contains(t, err.Error(), tc.ExpectedDenial)
+		})
+	}
+}
+
+func newTestBase(t *testing.T, code validator.Code, sev validator.Severity) *validator.Base {
+	t.Helper()
+
+	name := "error_validator"
+	if sev == validator.SeverityWarning {
+		name = "warning_validator"
+	}
+
+	base, err := validator.NewBase(code, validator.BaseName(name), validator.BaseSeverity(sev))
+	require.NoError(t, err)
+
+	return base
+}
@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+type AddonMetadataWebhookConfig struct {
+	DenyAt validator.Severity
+	Logger logr.Logger
+	Runner *validator.Runner
+}
+
+func (c *AddonMetadataWebhookConfig) Option(opts ...AddonMetadataWebhookOption) {
+	for _, opt := range opts {
+		opt.ConfigureAddonMetadataWebhook(c)
+	}
+}
+
+func (c *AddonMetadataWebhookConfig) Default() {
+	if c.Logger.GetSink() == nil {
+		c.Logger = logr.Discard()
+	}
+}
+
+type AddonMetadataWebhookOption interface {
+	ConfigureAddonMetadataWebhook(*AddonMetadataWebhookConfig)
+}
+
+// WithDenyAt applies the minimum Severity of
+// failures which deny an object.
+type WithDenyAt validator.Severity
+
+func (w WithDenyAt) ConfigureAddonMetadataWebhook(c *AddonMetadataWebhookConfig) {
+	c.DenyAt = validator.Severity(w)
+}
+
+// WithLogger applies the given logger.
+type WithLogger struct{ logr.Logger }
+
+func (w WithLogger) ConfigureAddonMetadataWebhook(c *AddonMetadataWebhookConfig) {
+	c.Logger = w.Logger
+}
+
+// WithRunner applies the given validator Runner.
+type WithRunner struct{ *validator.Runner }
+
+func (w WithRunner) ConfigureAddonMetadataWebhook(c *AddonMetadataWebhookConfig) {
+	c.Runner = w.Runner
+}
@@ -325,6 +325,24 @@ func (Generate) Boilerplate(ctx context.Context) error {
 	return fmt.Errorf("generating boilerplate: %w", generate.Error())
 }
 
+func (Generate) Markers(ctx context.Context) error {
+	generate := gocmd(
+		command.WithArgs{"generate", "./pkg/schema"},
+		command.WithConsoleOut(mg.Verbose()),
+		command.WithContext{Context: ctx},
+	)
+
+	if err := generate.Run(); err != nil {
+		return fmt.Errorf("starting to generate markers: %w", err)
+	}
+
+	if generate.Success() {
+		return nil
+	}
+
+	return fmt.Errorf("generating markers: %w", generate.Error())
+}
+
 var controllergen = command.NewCommandAlias(filepath.Join(_depBin, "controller-gen"))
 
 func (Generate) Clean(ctx context.Context) error {
@@ -0,0 +1,201 @@
+// Package diff computes semantic changes between two versions of an
+// addon's metadata and the head bundles they resolve to.
+package diff
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	ocmv1 "github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1"
+)
+
+// Section identifies the part of the addon a change applies to.
+type Section string
+
+const (
+	SectionAddOnParameters    Section = "addOnParameters"
+	SectionAddOnRequirements  Section = "addOnRequirements"
+	SectionSubOperators       Section = "subOperators"
+	SectionClusterPermissions Section = "clusterPermissions"
+	SectionPermissions        Section = "permissions"
+)
+
+// Sections lists all sections in the order they are reported.
+var Sections = []Section{
+	SectionAddOnParameters,
+	SectionAddOnRequirements,
+	SectionSubOperators,
+	SectionClusterPermissions,
+	SectionPermissions,
+}
+
+// Kind describes how an item changed between two versions.
+type Kind string
+
+const (
+	KindAdded   Kind = "added"
+	KindRemoved Kind = "removed"
+	KindChanged Kind = "changed"
+)
+
+// Change is a single semantic difference between two versions.
+type Change struct {
+	Section Section `json:"section"`
+	Kind    Kind    `json:"kind"`
+	// ID identifies the changed item within its section.
+	ID string `json:"id"`
+	// Fields lists the fields of a changed item which differ.
+	Fields []string `json:"fields,omitempty"`
+	// Added lists values such as RBAC verbs which were added to the item.
+	Added []string `json:"added,omitempty"`
+	// Removed lists values such as RBAC verbs which were removed from the item.
+	Removed []string `json:"removed,omitempty"`
+}
+
+// Metadata returns the changes to the addOnParameters, addOnRequirements
+// and subOperators between two combined addon metadata specs.
+func Metadata(from, to *addonsv1alpha1.AddonMetadataSpec) []Change {
+	var res []Change
+
+	res = append(res, diffItems(SectionAddOnParameters,
+		parametersByID(from.AddOnParameters), parametersByID(to.AddOnParameters),
+	)...)
+	res = append(res, diffItems(SectionAddOnRequirements,
+		requirementsByID(from.AddOnRequirements), requirementsByID(to.AddOnRequirements),
+	)...)
+	res = append(res, diffItems(SectionSubOperators,
+		subOperatorsByName(from.SubOperators), subOperatorsByName(to.SubOperators),
+	)...)
+
+	return res
+}
+
+func parametersByID(params *[]ocmv1.AddOnParameter) map[string]interface{} {
+	res := make(map[string]interface{})
+
+	if params == nil {
+		return res
+	}
+
+	for _, p := range *params {
+		res[p.ID] = p
+	}
+
+	return res
+}
+
+func requirementsByID(reqs *[]ocmv1.AddOnRequirement) map[string]interface{} {
+	res := make(map[string]interface{})
+
+	if reqs == nil {
+		return res
+	}
+
+	for _, r := range *reqs {
+		res[r.ID] = r
+	}
+
+	return res
+}
+
+func subOperatorsByName(ops *[]ocmv1.AddOnSubOperator) map[string]interface{} {
+	res := make(map[string]interface{})
+
+	if ops == nil {
+		return res
+	}
+
+	for _, op := range *ops {
+		res[op.OperatorName] = op
+	}
+
+	return res
+}
+
+// diffItems compares two sets of items keyed by their ID and returns
+// the added, removed and changed items ordered by ID.
+func diffItems(section Section, from, to map[string]interface{}) []Change {
+	var res []Change
+
+	for _, id := range sortedKeys(from, to) {
+		before, inFrom := from[id]
+		after, inTo := to[id]
+
+		switch {
+		case !inFrom:
+			res = append(res, Change{Section: section, Kind: KindAdded, ID: id})
+		case !inTo:
+			res = append(res, Change{Section: section, Kind: KindRemoved, ID: id})
+		default:
+			if fields := changedFields(before, after); len(fields) > 0 {
+				res = append(res, Change{Section: section, Kind: KindChanged, ID: id, Fields: fields})
+			}
+		}
+	}
+
+	return res
+}
+
+// changedFields returns the sorted JSON field names which differ
+// between two values of the same type.
+func changedFields(before, after interface{}) []string {
+	b, errB := toFields(before)
+	a, errA := toFields(after)
+
+	if errB != nil || errA != nil {
+		if reflect.DeepEqual(before, after) {
+			return nil
+		}
+
+		return []string{"*"}
+	}
+
+	var res []string
+
+	for _, field := range sortedKeys(b, a) {
+		if !reflect.DeepEqual(b[field], a[field]) {
+			res = append(res, field)
+		}
+	}
+
+	return res
+}
+
+func toFields(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var res map[string]interface{}
+
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func sortedKeys[V any](maps ...map[string]V) []string {
+	seen := make(map[string]struct{})
+
+	var res []string
+
+	for _, m := range maps {
+		for k := range m {
+			if _, ok := seen[k]; ok {
+				continue
+			}
+
+			seen[k] = struct{}{}
+
+			res = append(res, k)
+		}
+	}
+
+	sort.Strings(res)
+
+	return res
+}
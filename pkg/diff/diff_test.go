@@ -0,0 +1,198 @@
+package diff
+
+import (
+	"bytes"
+	"testing"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	ocmv1 "github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	opsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbac "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestMetadata(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		From     *addonsv1alpha1.AddonMetadataSpec
+		To       *addonsv1alpha1.AddonMetadataSpec
+		Expected []Change
+	}{
+		"no changes": {
+			From: &addonsv1alpha1.AddonMetadataSpec{
+				AddOnParameters: &[]ocmv1.AddOnParameter{{ID: "size", Name: "Size"}},
+			},
+			To: &addonsv1alpha1.AddonMetadataSpec{
+				AddOnParameters: &[]ocmv1.AddOnParameter{{ID: "size", Name: "Size"}},
+			},
+		},
+		"nil and empty lists": {
+			From: &addonsv1alpha1.AddonMetadataSpec{},
+			To: &addonsv1alpha1.AddonMetadataSpec{
+				AddOnParameters: &[]ocmv1.AddOnParameter{},
+				SubOperators:    &[]ocmv1.AddOnSubOperator{},
+			},
+		},
+		"parameters": {
+			From: &addonsv1alpha1.AddonMetadataSpec{
+				AddOnParameters: &[]ocmv1.AddOnParameter{
+					{ID: "size", Name: "Size", Required: false},
+					{ID: "legacy", Name: "Legacy"},
+				},
+			},
+			To: &addonsv1alpha1.AddonMetadataSpec{
+				AddOnParameters: &[]ocmv1.AddOnParameter{
+					{ID: "size", Name: "Size", Required: true, DefaultValue: stringPtr("small")},
+					{ID: "region", Name: "Region"},
+				},
+			},
+			Expected: []Change{
+				{Section: SectionAddOnParameters, Kind: KindRemoved, ID: "legacy"},
+				{Section: SectionAddOnParameters, Kind: KindAdded, ID: "region"},
+				{Section: SectionAddOnParameters, Kind: KindChanged, ID: "size", Fields: []string{"default_value", "required"}},
+			},
+		},
+		"requirements and sub operators": {
+			From: &addonsv1alpha1.AddonMetadataSpec{
+				AddOnRequirements: &[]ocmv1.AddOnRequirement{
+					{
+						ID:       "nodes",
+						Resource: ocmv1.AddOnRequirementResourceTypeCluster,
+						Data:     ocmv1.AddOnRequirementData{"compute.nodes": apiextensionsv1.JSON{Raw: []byte("3")}},
+					},
+				},
+			},
+			To: &addonsv1alpha1.AddonMetadataSpec{
+				AddOnRequirements: &[]ocmv1.AddOnRequirement{
+					{
+						ID:       "nodes",
+						Resource: ocmv1.AddOnRequirementResourceTypeCluster,
+						Data:     ocmv1.AddOnRequirementData{"compute.nodes": apiextensionsv1.JSON{Raw: []byte("5")}},
+					},
+				},
+				SubOperators: &[]ocmv1.AddOnSubOperator{
+					{OperatorName: "reference-addon-dependency", OperatorNamespace: "reference-addon", Enabled: true},
+				},
+			},
+			Expected: []Change{
+				{Section: SectionAddOnRequirements, Kind: KindChanged, ID: "nodes", Fields: []string{"data"}},
+				{Section: SectionSubOperators, Kind: KindAdded, ID: "reference-addon-dependency"},
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.Expected, Metadata(tc.From, tc.To))
+		})
+	}
+}
+
+func TestRBAC(t *testing.T) {
+	t.Parallel()
+
+	from := []operator.Bundle{
+		newBundle("0.1.0",
+			[]rbac.PolicyRule{
+				{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get", "list", "delete"}},
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}},
+			},
+			[]rbac.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"}},
+			},
+		),
+	}
+
+	to := []operator.Bundle{
+		from[0],
+		newBundle("0.2.0",
+			[]rbac.PolicyRule{
+				// split into two rules which must not be reported
+				{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get", "list"}},
+				{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"create"}},
+				{APIGroups: []string{""}, Resources: []string{"namespaces"}, Verbs: []string{"get", "list"}},
+				{NonResourceURLs: []string{"/metrics"}, Verbs: []string{"get"}},
+			},
+			[]rbac.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"}},
+			},
+		),
+	}
+
+	changes, err := RBAC(from, to)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Change{
+		{Section: SectionClusterPermissions, Kind: KindAdded, ID: "reference-addon: /metrics", Added: []string{"get"}},
+		{Section: SectionClusterPermissions, Kind: KindChanged, ID: "reference-addon: deployments.apps", Added: []string{"create"}, Removed: []string{"delete"}},
+		{Section: SectionClusterPermissions, Kind: KindAdded, ID: "reference-addon: namespaces", Added: []string{"get", "list"}},
+		{Section: SectionClusterPermissions, Kind: KindRemoved, ID: "reference-addon: secrets", Removed: []string{"get"}},
+	}, changes)
+}
+
+func TestReportWriteText(t *testing.T) {
+	t.Parallel()
+
+	report := Report{
+		Addon: "reference-addon",
+		Env:   "stage",
+		From:  "1.2.0",
+		To:    "1.3.0",
+		Changes: []Change{
+			{Section: SectionAddOnParameters, Kind: KindAdded, ID: "region"},
+			{Section: SectionAddOnParameters, Kind: KindChanged, ID: "size", Fields: []string{"default_value", "required"}},
+			{Section: SectionClusterPermissions, Kind: KindChanged, ID: "reference-addon: deployments.apps", Added: []string{"create"}, Removed: []string{"delete"}},
+		},
+	}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, report.WriteText(&buf))
+
+	assert.Equal(t, `Comparing reference-addon (stage): 1.2.0 -> 1.3.0
+
+addOnParameters:
+  + region
+  ~ size (fields: default_value, required)
+
+clusterPermissions:
+  ~ reference-addon: deployments.apps (verbs: +create -delete)
+`, buf.String())
+
+	buf.Reset()
+
+	report.Changes = nil
+
+	require.NoError(t, report.WriteText(&buf))
+	assert.Contains(t, buf.String(), "No changes.")
+}
+
+func newBundle(version string, clusterRules, rules []rbac.PolicyRule) operator.Bundle {
+	return operator.Bundle{
+		Name:    "reference-addon",
+		Version: version,
+		ClusterServiceVersion: operator.ClusterServiceVersion{
+			Name: "reference-addon.v" + version,
+			Spec: opsv1alpha1.ClusterServiceVersionSpec{
+				InstallStrategy: opsv1alpha1.NamedInstallStrategy{
+					StrategySpec: opsv1alpha1.StrategyDetailsDeployment{
+						ClusterPermissions: []opsv1alpha1.StrategyDeploymentPermissions{
+							{ServiceAccountName: "reference-addon", Rules: clusterRules},
+						},
+						Permissions: []opsv1alpha1.StrategyDeploymentPermissions{
+							{ServiceAccountName: "reference-addon", Rules: rules},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func stringPtr(s string) *string { return &s }
@@ -0,0 +1,132 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/utils/csvutils"
+)
+
+// RBAC returns the changes to the cluster and namespaced permissions
+// requested by the head bundles of two bundle sets. Rules are compared
+// per service account and resource so that rules which are merely
+// split or reordered do not show up as changes.
+func RBAC(from, to []operator.Bundle) ([]Change, error) {
+	before, err := headPermissions(from)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving permissions of previous head bundle: %w", err)
+	}
+
+	after, err := headPermissions(to)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving permissions of current head bundle: %w", err)
+	}
+
+	var res []Change
+
+	res = append(res, diffVerbs(SectionClusterPermissions,
+		verbsByResource(before.ClusterPermissions), verbsByResource(after.ClusterPermissions),
+	)...)
+	res = append(res, diffVerbs(SectionPermissions,
+		verbsByResource(before.Permissions), verbsByResource(after.Permissions),
+	)...)
+
+	return res, nil
+}
+
+func headPermissions(bundles []operator.Bundle) (*types.CSVPermissions, error) {
+	head, ok := operator.HeadBundle(bundles...)
+	if !ok {
+		return &types.CSVPermissions{}, nil
+	}
+
+	return csvutils.GetPermissions(head.ClusterServiceVersion)
+}
+
+// verbsByResource flattens permissions into the set of verbs granted
+// to each service account for each resource or non-resource URL.
+func verbsByResource(perms []types.Permission) map[string]map[string]struct{} {
+	res := make(map[string]map[string]struct{})
+
+	add := func(key string, verbs []string) {
+		if _, ok := res[key]; !ok {
+			res[key] = make(map[string]struct{})
+		}
+
+		for _, v := range verbs {
+			res[key][v] = struct{}{}
+		}
+	}
+
+	for _, perm := range perms {
+		for _, rule := range perm.Rules {
+			for _, url := range rule.NonResourceURLs {
+				add(fmt.Sprintf("%s: %s", perm.ServiceAccountName, url), rule.Verbs)
+			}
+
+			for _, group := range rule.APIGroups {
+				for _, resource := range rule.Resources {
+					add(resourceKey(perm.ServiceAccountName, group, resource, rule.ResourceNames), rule.Verbs)
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+func resourceKey(sa, group, resource string, names []string) string {
+	key := fmt.Sprintf("%s: %s", sa, resource)
+
+	if group != "" {
+		key += "." + group
+	}
+
+	if len(names) > 0 {
+		sorted := append([]string{}, names...)
+		sort.Strings(sorted)
+
+		key += fmt.Sprintf(" [%s]", strings.Join(sorted, ", "))
+	}
+
+	return key
+}
+
+func diffVerbs(section Section, from, to map[string]map[string]struct{}) []Change {
+	var res []Change
+
+	for _, key := range sortedKeys(from, to) {
+		added := sortedKeys(to[key])
+		removed := sortedKeys(from[key])
+
+		switch {
+		case len(from[key]) == 0:
+			res = append(res, Change{Section: section, Kind: KindAdded, ID: key, Added: added})
+		case len(to[key]) == 0:
+			res = append(res, Change{Section: section, Kind: KindRemoved, ID: key, Removed: removed})
+		default:
+			added, removed = subtract(added, from[key]), subtract(removed, to[key])
+
+			if len(added) > 0 || len(removed) > 0 {
+				res = append(res, Change{Section: section, Kind: KindChanged, ID: key, Added: added, Removed: removed})
+			}
+		}
+	}
+
+	return res
+}
+
+func subtract(list []string, set map[string]struct{}) []string {
+	var res []string
+
+	for _, item := range list {
+		if _, ok := set[item]; !ok {
+			res = append(res, item)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,114 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Report is the set of changes between two versions of an addon.
+type Report struct {
+	Addon   string   `json:"addon"`
+	Env     string   `json:"env"`
+	From    string   `json:"from"`
+	To      string   `json:"to"`
+	Changes []Change `json:"changes"`
+}
+
+// HasChanges returns true if the report contains any change.
+func (r Report) HasChanges() bool {
+	return len(r.Changes) > 0
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r Report) WriteJSON(w io.Writer) error {
+	if r.Changes == nil {
+		r.Changes = []Change{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(r)
+}
+
+// WriteText writes a human readable summary of the report grouped
+// by section. Added, removed and changed items are prefixed with
+// '+', '-' and '~' respectively.
+func (r Report) WriteText(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Comparing %s (%s): %s -> %s\n", r.Addon, r.Env, r.From, r.To)
+
+	if !r.HasChanges() {
+		b.WriteString("\nNo changes.\n")
+
+		_, err := io.WriteString(w, b.String())
+
+		return err
+	}
+
+	for _, section := range Sections {
+		var changes []Change
+
+		for _, c := range r.Changes {
+			if c.Section == section {
+				changes = append(changes, c)
+			}
+		}
+
+		if len(changes) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n%s:\n", section)
+
+		for _, c := range changes {
+			fmt.Fprintf(&b, "  %s %s%s\n", kindSymbol(c.Kind), c.ID, changeDetails(c))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+func kindSymbol(k Kind) string {
+	switch k {
+	case KindAdded:
+		return "+"
+	case KindRemoved:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+func changeDetails(c Change) string {
+	var details []string
+
+	if len(c.Fields) > 0 {
+		details = append(details, "fields: "+strings.Join(c.Fields, ", "))
+	}
+
+	if len(c.Added) > 0 || len(c.Removed) > 0 {
+		var verbs []string
+
+		for _, v := range c.Added {
+			verbs = append(verbs, "+"+v)
+		}
+
+		for _, v := range c.Removed {
+			verbs = append(verbs, "-"+v)
+		}
+
+		details = append(details, "verbs: "+strings.Join(verbs, " "))
+	}
+
+	if len(details) == 0 {
+		return ""
+	}
+
+	return " (" + strings.Join(details, "; ") + ")"
+}
@@ -0,0 +1,321 @@
+package extractor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+)
+
+const (
+	// DefaultIndexTTL is the default duration for which bundle images
+	// listed from a tag-based index image are cached.
+	DefaultIndexTTL = time.Hour
+	// DefaultResultTTL is the default duration for which validator
+	// results are cached. It bounds how long results of validators
+	// which depend on remote services, e.g. OCM, may be outdated.
+	DefaultResultTTL = 24 * time.Hour
+
+	DiskCacheKindBundle = "bundle"
+	DiskCacheKindIndex  = "index"
+	DiskCacheKindResult = "result"
+)
+
+// bundleFormatVersion identifies the encoding of cached bundles. It must
+// be increased whenever the data extracted into an operator.Bundle
+// changes, e.g. when fields are added, so that bundles cached by earlier
+// versions are extracted again rather than silently missing data.
+const bundleFormatVersion = 1
+
+type diskBundle struct {
+	FormatVersion int             `json:"formatVersion"`
+	Bundle        operator.Bundle `json:"bundle"`
+}
+
+// DefaultCacheDir returns the 'mtcli' directory within the user cache
+// directory ($XDG_CACHE_HOME on Linux).
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("determining user cache dir: %w", err)
+	}
+
+	return filepath.Join(dir, "mtcli"), nil
+}
+
+// NewDiskCache returns a DiskCache rooted in the configured directory
+// or in DefaultCacheDir if no directory is given. A variadic slice of
+// options can be used to alter the behavior of the cache.
+func NewDiskCache(opts ...DiskCacheOption) (*DiskCache, error) {
+	var cfg DiskCacheConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	if cfg.Dir == "" {
+		dir, err := DefaultCacheDir()
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.Dir = dir
+	}
+
+	bundles, err := NewDiskStore(filepath.Join(cfg.Dir, "bundles"))
+	if err != nil {
+		return nil, fmt.Errorf("initializing bundle store: %w", err)
+	}
+
+	indexes, err := NewDiskStore(filepath.Join(cfg.Dir, "indexes"))
+	if err != nil {
+		return nil, fmt.Errorf("initializing index store: %w", err)
+	}
+
+	results, err := NewDiskStore(filepath.Join(cfg.Dir, "results"))
+	if err != nil {
+		return nil, fmt.Errorf("initializing result store: %w", err)
+	}
+
+	return &DiskCache{
+		cfg:       cfg,
+		bundles:   bundles,
+		indexes:   indexes,
+		results:   results,
+		tagBundle: NewBundleCacheImpl(),
+	}, nil
+}
+
+// DiskCache implements both the 'BundleCache' and 'IndexCache' interfaces
+// by persisting data to disk so that it may be reused across runs.
+// Bundles are stored by image digest and are only invalidated when the
+// format of cached bundles changes. Bundles
+// referenced by tag may change at any time and are therefore only
+// cached in memory. Bundle images listed from an index image referenced
+// by tag expire once the configured IndexTTL has elapsed. Serialized
+// validator results expire once the configured ResultTTL has elapsed.
+type DiskCache struct {
+	cfg       DiskCacheConfig
+	bundles   *DiskStore
+	indexes   *DiskStore
+	results   *DiskStore
+	tagBundle *BundleCacheImpl
+}
+
+// DiskCacheEntry describes a single entry of a DiskCache.
+type DiskCacheEntry struct {
+	DiskEntry
+	// Kind is one of DiskCacheKindBundle, DiskCacheKindIndex
+	// or DiskCacheKindResult.
+	Kind string
+}
+
+// Dir returns the root directory of the cache.
+func (c *DiskCache) Dir() string { return c.cfg.Dir }
+
+func (c *DiskCache) GetBundle(img string) (*operator.Bundle, error) {
+	digest, ok := imageDigest(img)
+	if !ok {
+		return c.tagBundle.GetBundle(img)
+	}
+
+	data, ok := c.bundles.Read(digest)
+	if !ok {
+		return nil, nil
+	}
+
+	var entry diskBundle
+
+	if err := json.Unmarshal(data.(json.RawMessage), &entry); err != nil {
+		return nil, fmt.Errorf("decoding bundle: %w", ErrInvalidBundleData)
+	}
+
+	// bundles cached in another format may lack data
+	if entry.FormatVersion != bundleFormatVersion {
+		return nil, nil
+	}
+
+	bundle := entry.Bundle
+
+	// the same digest may be referenced through different repositories
+	bundle.BundleImage = img
+
+	return &bundle, nil
+}
+
+func (c *DiskCache) SetBundle(img string, bundle operator.Bundle) error {
+	digest, ok := imageDigest(img)
+	if !ok {
+		return c.tagBundle.SetBundle(img, bundle)
+	}
+
+	entry := diskBundle{
+		FormatVersion: bundleFormatVersion,
+		Bundle:        bundle,
+	}
+
+	if err := c.bundles.Write(digest, entry); err != nil {
+		return fmt.Errorf("writing bundle data: %w", err)
+	}
+
+	return nil
+}
+
+func (c *DiskCache) GetBundleImages(indexImage string, cacheKey string) ([]string, error) {
+	data, ok := c.indexes.Read(indexImage)
+	if !ok {
+		return nil, nil
+	}
+
+	var pkgs map[string][]string
+
+	if err := json.Unmarshal(data.(json.RawMessage), &pkgs); err != nil {
+		return nil, fmt.Errorf("decoding index data: %w", ErrInvalidIndexData)
+	}
+
+	return bundleImagesForKey(pkgs, cacheKey), nil
+}
+
+func (c *DiskCache) SetBundleImages(indexImage string, pkgBundlesMap map[string][]string) error {
+	var ttl time.Duration
+
+	if _, ok := imageDigest(indexImage); !ok {
+		ttl = c.cfg.IndexTTL
+	}
+
+	if err := c.indexes.WriteWithTTL(indexImage, pkgBundlesMap, ttl); err != nil {
+		return fmt.Errorf("writing data: %w", err)
+	}
+
+	return nil
+}
+
+// ReadResult returns the serialized validator result stored
+// for 'key' unless it does not exist or expired.
+func (c *DiskCache) ReadResult(key string) ([]byte, bool) {
+	data, ok := c.results.Read(key)
+	if !ok {
+		return nil, false
+	}
+
+	return data.(json.RawMessage), true
+}
+
+// WriteResult stores the serialized validator result 'data' for 'key'.
+func (c *DiskCache) WriteResult(key string, data []byte) error {
+	if err := c.results.WriteWithTTL(key, json.RawMessage(data), c.cfg.ResultTTL); err != nil {
+		return fmt.Errorf("writing result data: %w", err)
+	}
+
+	return nil
+}
+
+// List returns all bundle entries followed by all index
+// entries and all result entries.
+func (c *DiskCache) List() ([]DiskCacheEntry, error) {
+	var res []DiskCacheEntry
+
+	for _, ks := range c.stores() {
+		entries, err := ks.store.List()
+		if err != nil {
+			return nil, fmt.Errorf("listing %s entries: %w", ks.kind, err)
+		}
+
+		for _, e := range entries {
+			res = append(res, DiskCacheEntry{
+				DiskEntry: e,
+				Kind:      ks.kind,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+// Prune removes expired entries and, if maxAge is greater than 0,
+// entries created more than maxAge ago. The number of removed
+// entries is returned.
+func (c *DiskCache) Prune(maxAge time.Duration) (int, error) {
+	var total int
+
+	for _, ks := range c.stores() {
+		n, err := ks.store.Prune(maxAge)
+		total += n
+
+		if err != nil {
+			return total, fmt.Errorf("pruning %s entries: %w", ks.kind, err)
+		}
+	}
+
+	return total, nil
+}
+
+// Clear removes all entries and returns the number of removed entries.
+func (c *DiskCache) Clear() (int, error) {
+	var total int
+
+	for _, ks := range c.stores() {
+		n, err := ks.store.Clear()
+		total += n
+
+		if err != nil {
+			return total, fmt.Errorf("clearing %s entries: %w", ks.kind, err)
+		}
+	}
+
+	return total, nil
+}
+
+type kindStore struct {
+	kind  string
+	store *DiskStore
+}
+
+// stores returns the underlying stores in a stable order.
+func (c *DiskCache) stores() []kindStore {
+	return []kindStore{
+		{kind: DiskCacheKindBundle, store: c.bundles},
+		{kind: DiskCacheKindIndex, store: c.indexes},
+		{kind: DiskCacheKindResult, store: c.results},
+	}
+}
+
+// imageDigest returns the digest of an image reference
+// of the form '<repository>@<algorithm>:<hex>'.
+func imageDigest(img string) (string, bool) {
+	_, digest, ok := strings.Cut(img, "@")
+	if !ok || !strings.Contains(digest, ":") {
+		return "", false
+	}
+
+	return digest, true
+}
+
+type DiskCacheConfig struct {
+	Dir       string
+	IndexTTL  time.Duration
+	ResultTTL time.Duration
+}
+
+func (c *DiskCacheConfig) Option(opts ...DiskCacheOption) {
+	for _, opt := range opts {
+		opt.ConfigureDiskCache(c)
+	}
+}
+
+func (c *DiskCacheConfig) Default() {
+	if c.IndexTTL == 0 {
+		c.IndexTTL = DefaultIndexTTL
+	}
+
+	if c.ResultTTL == 0 {
+		c.ResultTTL = DefaultResultTTL
+	}
+}
+
+type DiskCacheOption interface {
+	ConfigureDiskCache(*DiskCacheConfig)
+}
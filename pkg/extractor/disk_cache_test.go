@@ -0,0 +1,167 @@
+package extractor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskCacheInterfaces(t *testing.T) {
+	t.Parallel()
+
+	require.Implements(t, new(BundleCache), new(DiskCache))
+	require.Implements(t, new(IndexCache), new(DiskCache))
+	require.Implements(t, new(validator.ResultStore), new(DiskCache))
+}
+
+func TestDiskCacheBundles(t *testing.T) {
+	t.Parallel()
+
+	const (
+		digest   = "sha256:0123456789abcdef"
+		pinned   = "quay.io/osd-addons/reference-addon-bundle@" + digest
+		mirrored = "registry.example.com/reference-addon-bundle@" + digest
+		tagged   = "quay.io/osd-addons/reference-addon-bundle:latest"
+	)
+
+	dir := t.TempDir()
+
+	cache, err := NewDiskCache(WithCacheDir(dir))
+	require.NoError(t, err)
+
+	bundle := operator.Bundle{
+		Name:    "reference-addon",
+		Version: "0.1.6",
+	}
+
+	require.NoError(t, cache.SetBundle(pinned, bundle))
+	require.NoError(t, cache.SetBundle(tagged, bundle))
+
+	// a new cache in the same directory simulates a later run
+	cache, err = NewDiskCache(WithCacheDir(dir))
+	require.NoError(t, err)
+
+	cached, err := cache.GetBundle(mirrored)
+	require.NoError(t, err)
+	require.NotNil(t, cached)
+	assert.Equal(t, "reference-addon", cached.Name)
+	assert.Equal(t, mirrored, cached.BundleImage)
+
+	cached, err = cache.GetBundle(tagged)
+	require.NoError(t, err)
+	assert.Nil(t, cached, "bundles referenced by tag must not be persisted")
+
+	entries, err := cache.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, DiskCacheKindBundle, entries[0].Kind)
+	assert.Equal(t, digest, entries[0].Key)
+}
+
+func TestDiskCacheBundleFormatVersion(t *testing.T) {
+	t.Parallel()
+
+	const (
+		digest = "sha256:0123456789abcdef"
+		pinned = "quay.io/osd-addons/reference-addon-bundle@" + digest
+	)
+
+	cache, err := NewDiskCache(WithCacheDir(t.TempDir()))
+	require.NoError(t, err)
+
+	// bundles cached before entries were versioned
+	require.NoError(t, cache.bundles.Write(digest, operator.Bundle{Name: "reference-addon"}))
+
+	cached, err := cache.GetBundle(pinned)
+	require.NoError(t, err)
+	assert.Nil(t, cached, "bundles cached in another format must be extracted again")
+
+	require.NoError(t, cache.SetBundle(pinned, operator.Bundle{Name: "reference-addon"}))
+
+	cached, err = cache.GetBundle(pinned)
+	require.NoError(t, err)
+	require.NotNil(t, cached)
+	assert.Equal(t, "reference-addon", cached.Name)
+}
+
+func TestDiskCacheIndexes(t *testing.T) {
+	t.Parallel()
+
+	const (
+		tagged = "quay.io/osd-addons/reference-addon-index:v0.1.6"
+		pinned = "quay.io/osd-addons/reference-addon-index@sha256:0123456789abcdef"
+	)
+
+	cache, err := NewDiskCache(
+		WithCacheDir(t.TempDir()),
+		WithIndexTTL(time.Minute),
+	)
+	require.NoError(t, err)
+
+	pkgs := map[string][]string{
+		"reference-addon": {"bundle-a", "bundle-b"},
+		"other":           {"bundle-c"},
+	}
+
+	require.NoError(t, cache.SetBundleImages(tagged, pkgs))
+	require.NoError(t, cache.SetBundleImages(pinned, pkgs))
+
+	images, err := cache.GetBundleImages(tagged, "reference-addon")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"bundle-a", "bundle-b"}, images)
+
+	images, err = cache.GetBundleImages(tagged, allBundlesKey)
+	require.NoError(t, err)
+	assert.Len(t, images, 3)
+
+	later := time.Now().Add(2 * time.Minute)
+	cache.indexes.now = func() time.Time { return later }
+
+	images, err = cache.GetBundleImages(tagged, "reference-addon")
+	require.NoError(t, err)
+	assert.Nil(t, images, "tag-based listings must expire")
+
+	images, err = cache.GetBundleImages(pinned, "reference-addon")
+	require.NoError(t, err)
+	assert.Len(t, images, 2, "digest-based listings must not expire")
+
+	removed, err := cache.Prune(0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+}
+
+func TestDiskCacheResults(t *testing.T) {
+	t.Parallel()
+
+	cache, err := NewDiskCache(
+		WithCacheDir(t.TempDir()),
+		WithResultTTL(time.Minute),
+	)
+	require.NoError(t, err)
+
+	_, ok := cache.ReadResult("AM0001-0123456789abcdef")
+	assert.False(t, ok)
+
+	data := []byte(`{"code":"AM0001","success":true}`)
+
+	require.NoError(t, cache.WriteResult("AM0001-0123456789abcdef", data))
+
+	stored, ok := cache.ReadResult("AM0001-0123456789abcdef")
+	require.True(t, ok)
+	assert.JSONEq(t, string(data), string(stored))
+
+	entries, err := cache.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, DiskCacheKindResult, entries[0].Kind)
+
+	later := time.Now().Add(2 * time.Minute)
+	cache.results.now = func() time.Time { return later }
+
+	_, ok = cache.ReadResult("AM0001-0123456789abcdef")
+	assert.False(t, ok, "results must expire")
+}
@@ -0,0 +1,293 @@
+package extractor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	diskEntryExt = ".json"
+	// diskTempPrefix marks partially written entries.
+	diskTempPrefix = ".tmp-"
+	// staleTempAge is the age after which a temporary file is assumed
+	// to belong to a process which exited before finishing a write.
+	staleTempAge = time.Hour
+)
+
+var ErrInvalidDiskStoreData = errors.New("invalid disk store data")
+
+// NewDiskStore returns a Store which persists entries as files in the
+// given directory. The directory is created if it does not exist.
+//
+// Entries are written to a temporary file and then renamed into place
+// so that several processes may share the same directory without
+// observing partially written data.
+func NewDiskStore(dir string) (*DiskStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating store directory %q: %w", dir, err)
+	}
+
+	return &DiskStore{
+		dir: dir,
+		now: time.Now,
+	}, nil
+}
+
+// DiskStore implements the 'Store' interface for string ids and JSON
+// serializable data. Read returns the data as a json.RawMessage which
+// must be decoded by the caller.
+type DiskStore struct {
+	dir string
+	now func() time.Time
+}
+
+// DiskEntry describes a single entry of a DiskStore.
+type DiskEntry struct {
+	Key       string
+	Size      int64
+	CreatedAt time.Time
+	// ExpiresAt is the zero value for entries which never expire.
+	ExpiresAt time.Time
+}
+
+// Expired returns true if the entry expired before the given time.
+func (e DiskEntry) Expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && now.After(e.ExpiresAt)
+}
+
+type diskEntryData struct {
+	Key       string          `json:"key"`
+	CreatedAt time.Time       `json:"createdAt"`
+	ExpiresAt time.Time       `json:"expiresAt,omitempty"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func (s *DiskStore) Read(id interface{}) (interface{}, bool) {
+	key, ok := id.(string)
+	if !ok {
+		return nil, false
+	}
+
+	entry, err := s.readEntry(s.path(key))
+	if err != nil || entry.Key != key {
+		return nil, false
+	}
+
+	if entry.info(0).Expired(s.now()) {
+		return nil, false
+	}
+
+	return entry.Data, true
+}
+
+// Write stores data which never expires for the given id.
+func (s *DiskStore) Write(id, data interface{}) error {
+	return s.WriteWithTTL(id, data, 0)
+}
+
+// WriteWithTTL stores data for the given id which expires once
+// the ttl has elapsed. A ttl of 0 means the data never expires.
+func (s *DiskStore) WriteWithTTL(id, data interface{}, ttl time.Duration) error {
+	key, ok := id.(string)
+	if !ok {
+		return fmt.Errorf("id of type %T: %w", id, ErrInvalidDiskStoreData)
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("encoding data: %w", err)
+	}
+
+	entry := diskEntryData{
+		Key:       key,
+		CreatedAt: s.now().UTC(),
+		Data:      raw,
+	}
+
+	if ttl > 0 {
+		entry.ExpiresAt = entry.CreatedAt.Add(ttl)
+	}
+
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encoding entry: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(s.dir, diskTempPrefix+"*")
+	if err != nil {
+		return fmt.Errorf("creating temporary file: %w", err)
+	}
+
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("writing temporary file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing temporary file: %w", err)
+	}
+
+	// rename is atomic so concurrent readers observe either
+	// the previous or the new entry but never a partial one
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		return fmt.Errorf("moving entry into place: %w", err)
+	}
+
+	return nil
+}
+
+// List returns all entries in the store ordered by key. Entries
+// which cannot be decoded are skipped.
+func (s *DiskStore) List() ([]DiskEntry, error) {
+	paths, err := s.entryPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]DiskEntry, 0, len(paths))
+
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil {
+			continue
+		}
+
+		entry, err := s.readEntry(p)
+		if err != nil {
+			continue
+		}
+
+		res = append(res, entry.info(fi.Size()))
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Key < res[j].Key })
+
+	return res, nil
+}
+
+// Prune removes expired and unreadable entries as well as temporary
+// files left behind by interrupted writes. If maxAge is greater than 0
+// entries created more than maxAge ago are also removed. The number of
+// removed entries is returned.
+func (s *DiskStore) Prune(maxAge time.Duration) (int, error) {
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return 0, fmt.Errorf("reading store directory: %w", err)
+	}
+
+	now := s.now()
+
+	var removed int
+
+	for _, f := range files {
+		path := filepath.Join(s.dir, f.Name())
+
+		if strings.HasPrefix(f.Name(), diskTempPrefix) {
+			if fi, err := f.Info(); err == nil && now.Sub(fi.ModTime()) > staleTempAge {
+				if err := removeIfExists(path); err != nil {
+					return removed, err
+				}
+			}
+
+			continue
+		}
+
+		if filepath.Ext(f.Name()) != diskEntryExt {
+			continue
+		}
+
+		entry, err := s.readEntry(path)
+		if err == nil {
+			info := entry.info(0)
+
+			if !info.Expired(now) && (maxAge <= 0 || now.Sub(info.CreatedAt) <= maxAge) {
+				continue
+			}
+		}
+
+		if err := removeIfExists(path); err != nil {
+			return removed, err
+		}
+
+		removed++
+	}
+
+	return removed, nil
+}
+
+// Clear removes all entries from the store and returns the
+// number of removed entries.
+func (s *DiskStore) Clear() (int, error) {
+	paths, err := s.entryPaths()
+	if err != nil {
+		return 0, err
+	}
+
+	for i, p := range paths {
+		if err := removeIfExists(p); err != nil {
+			return i, err
+		}
+	}
+
+	return len(paths), nil
+}
+
+func (s *DiskStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+diskEntryExt)
+}
+
+func (s *DiskStore) entryPaths() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*"+diskEntryExt))
+	if err != nil {
+		return nil, fmt.Errorf("listing store directory: %w", err)
+	}
+
+	return paths, nil
+}
+
+func (s *DiskStore) readEntry(path string) (diskEntryData, error) {
+	var entry diskEntryData
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return entry, err
+	}
+
+	if err := json.Unmarshal(content, &entry); err != nil {
+		return entry, fmt.Errorf("decoding entry %q: %w", path, err)
+	}
+
+	return entry, nil
+}
+
+func (e diskEntryData) info(size int64) DiskEntry {
+	return DiskEntry{
+		Key:       e.Key,
+		Size:      size,
+		CreatedAt: e.CreatedAt,
+		ExpiresAt: e.ExpiresAt,
+	}
+}
+
+// removeIfExists ignores files which were already removed
+// by another process.
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing %q: %w", path, err)
+	}
+
+	return nil
+}
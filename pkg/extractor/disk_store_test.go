@@ -0,0 +1,133 @@
+package extractor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskStoreInterfaces(t *testing.T) {
+	t.Parallel()
+
+	require.Implements(t, new(Store), new(DiskStore))
+}
+
+func TestDiskStore(t *testing.T) {
+	t.Parallel()
+
+	const numWorkers = 10
+
+	dir := t.TempDir()
+
+	// separate instances simulate separate processes sharing the directory
+	stores := make([]*DiskStore, 2)
+
+	for i := range stores {
+		store, err := NewDiskStore(dir)
+		require.NoError(t, err)
+
+		stores[i] = store
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < numWorkers; i++ {
+		i := i
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			store := stores[i%len(stores)]
+
+			err := store.Write("shared", []int{i})
+			require.NoError(t, err)
+
+			data, ok := store.Read("shared")
+			require.True(t, ok)
+
+			var val []int
+			require.NoError(t, json.Unmarshal(data.(json.RawMessage), &val))
+			assert.Len(t, val, 1)
+		}()
+	}
+
+	wg.Wait()
+
+	entries, err := stores[0].List()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "shared", entries[0].Key)
+
+	tmps, err := filepath.Glob(filepath.Join(dir, diskTempPrefix+"*"))
+	require.NoError(t, err)
+	assert.Empty(t, tmps)
+}
+
+func TestDiskStoreExpiry(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewDiskStore(t.TempDir())
+	require.NoError(t, err)
+
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	require.NoError(t, store.WriteWithTTL("expiring", "data", time.Minute))
+	require.NoError(t, store.Write("persistent", "data"))
+
+	_, ok := store.Read("expiring")
+	assert.True(t, ok)
+
+	store.now = func() time.Time { return now.Add(2 * time.Minute) }
+
+	_, ok = store.Read("expiring")
+	assert.False(t, ok)
+
+	_, ok = store.Read("persistent")
+	assert.True(t, ok)
+
+	removed, err := store.Prune(0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	store.now = func() time.Time { return now.Add(48 * time.Hour) }
+
+	removed, err = store.Prune(24 * time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	entries, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestDiskStorePruneRemovesCorruptEntries(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	store, err := NewDiskStore(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "corrupt"+diskEntryExt), []byte("{"), 0o644))
+	require.NoError(t, store.Write("valid", "data"))
+
+	removed, err := store.Prune(0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	cleared, err := store.Clear()
+	require.NoError(t, err)
+	assert.Equal(t, 1, cleared)
+
+	_, ok := store.Read("valid")
+	assert.False(t, ok)
+}
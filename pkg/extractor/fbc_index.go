@@ -0,0 +1,119 @@
+package extractor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/model"
+	"github.com/sirupsen/logrus"
+)
+
+// FBCIndexExtractor implements the 'IndexExtractor' interface by reading
+// a local file-based catalog directory made up of olm.package, olm.channel
+// and olm.bundle blobs. The catalog directory is the only index source,
+// so the 'indexImage' passed to its methods is only used for logging.
+type FBCIndexExtractor struct {
+	Log logrus.FieldLogger
+	Dir string
+
+	once    sync.Once
+	bundles []model.Bundle
+	err     error
+}
+
+// NewFBCIndexExtractor returns an index extractor for the file-based catalog
+// in 'dir'. A variadic slice of options can be used to alter its behavior.
+func NewFBCIndexExtractor(dir string, opts ...FBCIndexExtractorOpt) *FBCIndexExtractor {
+	extractor := FBCIndexExtractor{
+		Dir: dir,
+	}
+
+	for _, opt := range opts {
+		opt(&extractor)
+	}
+
+	if extractor.Log == nil {
+		extractor.Log = logrus.New()
+	}
+
+	extractor.Log = extractor.Log.WithField("source", "fbcIndexExtractor")
+
+	return &extractor
+}
+
+type FBCIndexExtractorOpt func(e *FBCIndexExtractor)
+
+func WithFBCIndexLog(log logrus.FieldLogger) FBCIndexExtractorOpt {
+	return func(e *FBCIndexExtractor) {
+		e.Log = log
+	}
+}
+
+// ExtractBundleImages - returns a sorted list of bundles for a given pkg
+func (e *FBCIndexExtractor) ExtractBundleImages(ctx context.Context, indexImage string, pkgName string) ([]string, error) {
+	e.Log.Debugf("extracting bundles from catalog '%s' in place of '%s', matching pkgName '%s'", e.Dir, indexImage, pkgName)
+
+	return e.extractBundleImages(ctx, pkgName)
+}
+
+// ExtractAllBundleImages - returns a sorted list of all bundles for all pkgs
+func (e *FBCIndexExtractor) ExtractAllBundleImages(ctx context.Context, indexImage string) ([]string, error) {
+	e.Log.Debugf("extracting all bundles from catalog '%s' in place of '%s'", e.Dir, indexImage)
+
+	return e.extractBundleImages(ctx, allBundlesKey)
+}
+
+func (e *FBCIndexExtractor) extractBundleImages(ctx context.Context, cacheKey string) ([]string, error) {
+	e.once.Do(func() {
+		e.bundles, e.err = loadCatalogBundles(ctx, e.Dir)
+	})
+
+	if e.err != nil {
+		return nil, fmt.Errorf("loading catalog %q: %w", e.Dir, e.err)
+	}
+
+	_, bundleImagesMap := parseBundles(cacheKey, e.bundles)
+
+	return sortedBundleImages(bundleImagesForKey(bundleImagesMap, cacheKey)), nil
+}
+
+// loadCatalogBundles reads and validates the file-based catalog in 'dir'
+// and returns every bundle once, regardless of how many channels it is in.
+func loadCatalogBundles(ctx context.Context, dir string) ([]model.Bundle, error) {
+	cfg, err := declcfg.LoadFS(ctx, os.DirFS(dir))
+	if err != nil {
+		return nil, fmt.Errorf("loading declarative config: %w", err)
+	}
+
+	m, err := declcfg.ConvertToModel(*cfg)
+	if err != nil {
+		return nil, fmt.Errorf("converting declarative config: %w", err)
+	}
+
+	type bundleKey struct{ pkg, name string }
+
+	seen := make(map[bundleKey]struct{})
+
+	var res []model.Bundle
+
+	for _, pkg := range m {
+		for _, ch := range pkg.Channels {
+			for _, b := range ch.Bundles {
+				key := bundleKey{pkg: pkg.Name, name: b.Name}
+
+				if _, ok := seen[key]; ok {
+					continue
+				}
+
+				seen[key] = struct{}{}
+
+				res = append(res, *b)
+			}
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,85 @@
+package extractor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFBCIndexExtractorImplements(t *testing.T) {
+	t.Parallel()
+
+	require.Implements(t, new(IndexExtractor), &FBCIndexExtractor{})
+}
+
+func TestFBCIndexExtractor(t *testing.T) {
+	t.Parallel()
+
+	// testutils cannot be imported here without an import cycle
+	extractor := NewFBCIndexExtractor(filepath.Join("..", "..", "internal", "testdata", "catalogs", "reference-addon"))
+
+	for name, tc := range map[string]struct {
+		PkgName              string
+		ExpectedBundleImages []string
+	}{
+		"package in multiple channels": {
+			PkgName: "reference-addon",
+			ExpectedBundleImages: []string{
+				"quay.io/osd-addons/reference-addon-bundle:0.1.5-c15cedb",
+				"quay.io/osd-addons/reference-addon-bundle:0.1.6-single",
+			},
+		},
+		"package in single channel": {
+			PkgName: "addon-operator",
+			ExpectedBundleImages: []string{
+				"quay.io/osd-addons/addon-operator-bundle:0.3.0",
+			},
+		},
+		"unknown package": {
+			PkgName: "unknown",
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			bundleImages, err := extractor.ExtractBundleImages(context.Background(), "ignored", tc.PkgName)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.ExpectedBundleImages, bundleImages)
+		})
+	}
+
+	t.Run("all packages", func(t *testing.T) {
+		t.Parallel()
+
+		bundleImages, err := extractor.ExtractAllBundleImages(context.Background(), "ignored")
+		require.NoError(t, err)
+
+		assert.Len(t, bundleImages, 3)
+	})
+}
+
+func TestFBCIndexExtractorInvalidCatalog(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	catalog := []byte(`
+schema: olm.bundle
+name: orphan.v0.1.0
+package: orphan
+image: quay.io/osd-addons/orphan-bundle:0.1.0
+`)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "catalog.yaml"), catalog, 0o644))
+
+	extractor := NewFBCIndexExtractor(dir)
+
+	_, err := extractor.ExtractBundleImages(context.Background(), "ignored", "reference-addon")
+	require.Error(t, err)
+}
@@ -36,6 +36,12 @@ func (c *IndexCacheImpl) GetBundleImages(indexImage string, cacheKey string) ([]
 		return nil, ErrInvalidIndexData
 	}
 
+	return bundleImagesForKey(pkgs, cacheKey), nil
+}
+
+// bundleImagesForKey returns the bundle images of the package matching
+// 'cacheKey' or of all packages if 'cacheKey' is allBundlesKey.
+func bundleImagesForKey(pkgs map[string][]string, cacheKey string) []string {
 	var res []string
 
 	for pkgName, bundles := range pkgs {
@@ -46,7 +52,7 @@ func (c *IndexCacheImpl) GetBundleImages(indexImage string, cacheKey string) ([]
 		res = append(res, bundles...)
 	}
 
-	return res, nil
+	return res
 }
 
 func (c *IndexCacheImpl) SetBundleImages(indexImage string, pkgBundlesMap map[string][]string) error {
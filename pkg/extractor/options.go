@@ -1,5 +1,7 @@
 package extractor
 
+import "time"
+
 // WithStore provides the given Store implementation
 // to cache implementation for object storage.
 type WithStore struct{ Store }
@@ -11,3 +13,26 @@ func (w WithStore) ConfigureIndexCacheImpl(c *IndexCacheImplConfig) {
 func (w WithStore) ConfigureBundleCacheImpl(c *BundleCacheImplConfig) {
 	c.Store = w.Store
 }
+
+// WithCacheDir sets the root directory of a DiskCache.
+type WithCacheDir string
+
+func (w WithCacheDir) ConfigureDiskCache(c *DiskCacheConfig) {
+	c.Dir = string(w)
+}
+
+// WithIndexTTL sets the duration for which a DiskCache stores
+// bundle images listed from a tag-based index image.
+type WithIndexTTL time.Duration
+
+func (w WithIndexTTL) ConfigureDiskCache(c *DiskCacheConfig) {
+	c.IndexTTL = time.Duration(w)
+}
+
+// WithResultTTL sets the duration for which a
+// DiskCache stores serialized validator results.
+type WithResultTTL time.Duration
+
+func (w WithResultTTL) ConfigureDiskCache(c *DiskCacheConfig) {
+	c.ResultTTL = time.Duration(w)
+}
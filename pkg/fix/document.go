@@ -0,0 +1,452 @@
+package fix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// document is the text of a YAML document which is edited in place
+// using the positions of its parsed nodes. Only the edited lines
+// change so that comments and formatting are preserved.
+type document struct {
+	lines []string
+}
+
+func (d document) bytes() []byte {
+	return []byte(strings.Join(d.lines, "\n"))
+}
+
+// insertLines inserts 'lines' after the 1-based line 'after'.
+func (d *document) insertLines(after int, lines ...string) {
+	res := make([]string, 0, len(d.lines)+len(lines))
+	res = append(res, d.lines[:after]...)
+	res = append(res, lines...)
+	res = append(res, d.lines[after:]...)
+
+	d.lines = res
+}
+
+// lastLine returns the last line of the document
+// ignoring the empty line after a final newline.
+func (d document) lastLine() int {
+	if n := len(d.lines); n > 0 && d.lines[n-1] == "" {
+		return n - 1
+	}
+
+	return len(d.lines)
+}
+
+// insertKey adds the field 'key' holding 'value' after the
+// last field of the block mapping 'parent'. A nil parent
+// stands for an empty document.
+func (d document) insertKey(parent *yaml.Node, key string, value interface{}) ([]byte, error) {
+	var indent, after int
+
+	switch {
+	case parent == nil:
+		after = d.lastLine()
+	case parent.Kind != yaml.MappingNode:
+		return nil, fmt.Errorf("cannot add %q to a non-object: %w", key, ErrUnsupported)
+	case parent.Style&yaml.FlowStyle != 0 || len(parent.Content) == 0:
+		return nil, fmt.Errorf("cannot add %q to a flow style object: %w", key, ErrUnsupported)
+	default:
+		indent = parent.Content[0].Column - 1
+		after = endLine(parent)
+	}
+
+	lines, err := render(map[string]interface{}{key: value}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	d.insertLines(after, indented(indent, lines)...)
+
+	return d.bytes(), nil
+}
+
+// replace replaces the value 'n' of the field 'key' with 'value'.
+// The key may be nil if 'n' is a list item.
+func (d document) replace(key, n *yaml.Node, value interface{}) ([]byte, error) {
+	var style yaml.Style
+	if n.Kind == yaml.ScalarNode {
+		style = n.Style & (yaml.DoubleQuotedStyle | yaml.SingleQuotedStyle)
+	}
+
+	lines, err := render(value, style)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 1 && !isBlock(value) {
+		line, start, end, err := d.span(key, n)
+		if err != nil {
+			return nil, err
+		}
+
+		text := d.lines[line]
+
+		replacement := lines[0]
+		if start > 0 && text[start-1] == ':' {
+			replacement = " " + replacement
+		}
+
+		d.lines[line] = text[:start] + replacement + text[end:]
+
+		return d.bytes(), nil
+	}
+
+	if key == nil {
+		return nil, fmt.Errorf("cannot replace list item with a block: %w", ErrUnsupported)
+	}
+
+	indent := key.Column - 1 + 2
+
+	if n.Kind == yaml.ScalarNode {
+		line, start, end, err := d.span(key, n)
+		if err != nil {
+			return nil, err
+		}
+
+		text := d.lines[line]
+		d.lines[line] = strings.TrimRight(text[:start], " ") + text[end:]
+		d.insertLines(line+1, indented(indent, lines)...)
+
+		return d.bytes(), nil
+	}
+
+	if n.Style&yaml.FlowStyle != 0 || n.Line == key.Line {
+		return nil, fmt.Errorf("cannot replace flow style value with a block: %w", ErrUnsupported)
+	}
+
+	// drop the lines of the block value
+	first, last := n.Line-1, endLine(n)
+	d.lines = append(d.lines[:first], d.lines[last:]...)
+	d.insertLines(first, indented(indent, lines)...)
+
+	return d.bytes(), nil
+}
+
+// append adds 'value' to the list 'n' of the field 'key'
+// unless the list already contains it.
+func (d document) append(key, n *yaml.Node, value interface{}) ([]byte, error) {
+	if isNull(n) {
+		if key == nil {
+			return nil, fmt.Errorf("cannot append to null list item: %w", ErrUnsupported)
+		}
+
+		return d.replace(key, n, []interface{}{value})
+	}
+
+	if n.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("cannot append to a non-list: %w", ErrUnsupported)
+	}
+
+	for _, item := range n.Content {
+		if equal(item, value) {
+			return d.bytes(), nil
+		}
+	}
+
+	if n.Style&yaml.FlowStyle != 0 {
+		return d.appendFlow(n, value)
+	}
+
+	last := n.Content[len(n.Content)-1]
+	text := d.lines[last.Line-1]
+
+	dash := strings.LastIndex(text[:last.Column-1], "-")
+	if dash < 0 {
+		return nil, fmt.Errorf("cannot locate the last list item: %w", ErrUnsupported)
+	}
+
+	lines, err := render(value, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	item := make([]string, 0, len(lines))
+
+	for i, l := range lines {
+		if i == 0 {
+			item = append(item, "- "+l)
+		} else {
+			item = append(item, "  "+l)
+		}
+	}
+
+	d.insertLines(endLine(n), indented(dash, item)...)
+
+	return d.bytes(), nil
+}
+
+func (d document) appendFlow(n *yaml.Node, value interface{}) ([]byte, error) {
+	line, _, end, err := d.span(nil, n)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := render(value, yaml.FlowStyle)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lines) != 1 {
+		return nil, fmt.Errorf("cannot append a block to a flow style list: %w", ErrUnsupported)
+	}
+
+	item := lines[0]
+	if len(n.Content) > 0 {
+		item = ", " + item
+	}
+
+	text := d.lines[line]
+	// insert before the closing bracket
+	d.lines[line] = text[:end-1] + item + text[end-1:]
+
+	return d.bytes(), nil
+}
+
+// remove deletes the field 'key' holding 'n' from the block mapping
+// 'owner'. Continuation lines of multi-line plain scalars and comments
+// indented below the key are removed along with the value.
+func (d document) remove(owner, key, n *yaml.Node) ([]byte, error) {
+	if key == nil {
+		return nil, fmt.Errorf("cannot remove list item: %w", ErrUnsupported)
+	}
+
+	if owner.Style&yaml.FlowStyle != 0 {
+		return nil, fmt.Errorf("cannot remove %q from a flow style object: %w", key.Value, ErrUnsupported)
+	}
+
+	first := key.Line - 1
+	indent := key.Column - 1
+
+	if strings.TrimSpace(d.lines[first][:indent]) != "" {
+		return nil, fmt.Errorf("cannot remove %q sharing a line with a list item: %w", key.Value, ErrUnsupported)
+	}
+
+	last := endLine(n)
+
+	for last < d.lastLine() {
+		text := d.lines[last]
+		if strings.TrimSpace(text) == "" || len(text)-len(strings.TrimLeft(text, " ")) <= indent {
+			break
+		}
+
+		last++
+	}
+
+	d.lines = append(d.lines[:first], d.lines[last:]...)
+
+	return d.bytes(), nil
+}
+
+// span returns the 0-based line and the byte offsets on that line
+// of the value 'n' of the field 'key' which must not span lines.
+func (d document) span(key, n *yaml.Node) (int, int, int, error) {
+	if isNull(n) && n.Value == "" && key != nil {
+		// the value is empty, e.g. 'label:'
+		line := key.Line - 1
+		text := d.lines[line]
+
+		colon := strings.Index(text[key.Column-1:], ":")
+		if colon < 0 {
+			return 0, 0, 0, fmt.Errorf("cannot locate value of %q: %w", key.Value, ErrUnsupported)
+		}
+
+		pos := key.Column - 1 + colon + 1
+
+		return line, pos, pos, nil
+	}
+
+	line := n.Line - 1
+	text := d.lines[line]
+	start := n.Column - 1
+
+	if start >= len(text) {
+		return 0, 0, 0, fmt.Errorf("cannot locate value at line %d: %w", n.Line, ErrUnsupported)
+	}
+
+	var end int
+
+	switch {
+	case n.Kind != yaml.ScalarNode && n.Style&yaml.FlowStyle != 0:
+		end = closingBracket(text, start)
+	case n.Kind != yaml.ScalarNode:
+		return 0, 0, 0, fmt.Errorf("cannot replace block value at line %d inline: %w", n.Line, ErrUnsupported)
+	case n.Style&yaml.DoubleQuotedStyle != 0:
+		end = closingQuote(text[start:])
+		if end >= 0 {
+			end += start + 1
+		}
+	case n.Style&yaml.SingleQuotedStyle != 0:
+		end = closingSingleQuote(text, start)
+	case n.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+		return 0, 0, 0, fmt.Errorf("cannot replace multi-line value at line %d: %w", n.Line, ErrUnsupported)
+	default:
+		end = -1
+		if strings.HasPrefix(text[start:], n.Value) {
+			end = start + len(n.Value)
+		}
+	}
+
+	if end < 0 {
+		return 0, 0, 0, fmt.Errorf("cannot locate value at line %d: %w", n.Line, ErrUnsupported)
+	}
+
+	return line, start, end, nil
+}
+
+// closingBracket returns the offset after the bracket closing the
+// flow collection starting at 'start' or -1 if it is on another line.
+func closingBracket(text string, start int) int {
+	var (
+		depth int
+		quote byte
+	)
+
+	for i := start; i < len(text); i++ {
+		c := text[i]
+
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+
+	return -1
+}
+
+// closingSingleQuote returns the offset after the single quoted
+// string starting at 'start' where quotes are escaped by doubling.
+func closingSingleQuote(text string, start int) int {
+	for i := start + 1; i < len(text); i++ {
+		if text[i] != '\'' {
+			continue
+		}
+
+		if i+1 < len(text) && text[i+1] == '\'' {
+			i++
+
+			continue
+		}
+
+		return i + 1
+	}
+
+	return -1
+}
+
+// endLine returns the last line of the node 'n' and its children.
+func endLine(n *yaml.Node) int {
+	end := n.Line
+
+	if n.Kind == yaml.ScalarNode && n.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		end += strings.Count(strings.TrimRight(n.Value, "\n"), "\n") + 1
+	}
+
+	for _, c := range n.Content {
+		if e := endLine(c); e > end {
+			end = e
+		}
+	}
+
+	return end
+}
+
+// isBlock reports whether 'value' encodes to a non-empty
+// object or list which is rendered in block style.
+func isBlock(value interface{}) bool {
+	v, err := normalize(value)
+	if err != nil {
+		return false
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return len(v) > 0
+	case []interface{}:
+		return len(v) > 0
+	default:
+		return false
+	}
+}
+
+// render encodes 'value' as YAML lines. The style is applied to
+// the top-level node while nested nodes use the default style
+// unless the flow style is requested.
+func render(value interface{}, style yaml.Style) ([]string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("encoding value: %w", err)
+	}
+
+	var doc yaml.Node
+
+	// JSON is a subset of YAML which preserves the order of keys
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decoding value: %w", err)
+	}
+
+	n := doc.Content[0]
+	resetStyle(n, style&yaml.FlowStyle)
+
+	if n.Kind == yaml.ScalarNode && n.ShortTag() == "!!str" {
+		n.Style = style &^ yaml.FlowStyle
+	}
+
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(n); err != nil {
+		return nil, fmt.Errorf("encoding value as yaml: %w", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("encoding value as yaml: %w", err)
+	}
+
+	return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"), nil
+}
+
+func resetStyle(n *yaml.Node, style yaml.Style) {
+	n.Style = 0
+	if n.Kind != yaml.ScalarNode {
+		n.Style = style
+	}
+
+	for _, c := range n.Content {
+		resetStyle(c, style)
+	}
+}
+
+func indented(indent int, lines []string) []string {
+	prefix := strings.Repeat(" ", indent)
+	res := make([]string, 0, len(lines))
+
+	for _, l := range lines {
+		res = append(res, prefix+l)
+	}
+
+	return res
+}
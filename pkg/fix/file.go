@@ -0,0 +1,60 @@
+package fix
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WriteFile atomically replaces the existing file at 'path' with
+// 'data' keeping its permissions, so that the file is never left
+// partially written if writing fails or is interrupted.
+func WriteFile(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("reading file info: %w", err)
+	}
+
+	tmp, err := WriteTemp(path, data, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+
+		return fmt.Errorf("moving file into place: %w", err)
+	}
+
+	return nil
+}
+
+// WriteTemp writes 'data' to a new temporary file next to 'path'
+// with the permissions 'perm' and returns the path of that file.
+func WriteTemp(path string, data []byte, perm os.FileMode) (string, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return "", fmt.Errorf("creating temporary file: %w", err)
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+
+		return "", fmt.Errorf("writing temporary file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return "", fmt.Errorf("closing temporary file: %w", err)
+	}
+
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return "", fmt.Errorf("setting permissions of temporary file: %w", err)
+	}
+
+	return tmp.Name(), nil
+}
@@ -0,0 +1,44 @@
+package fix
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "addon.yaml")
+
+	require.NoError(t, os.WriteFile(path, []byte("id: reference-addon\n"), 0o600))
+	require.NoError(t, WriteFile(path, []byte("id: fixed-addon\n")))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "id: fixed-addon\n", string(data))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary files must be left behind")
+}
+
+func TestWriteFileMissing(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	require.Error(t, WriteFile(filepath.Join(dir, "addon.yaml"), []byte("id: reference-addon\n")))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
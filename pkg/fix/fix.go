@@ -0,0 +1,257 @@
+// Package fix applies the edits suggested by validators to YAML files
+// such as the addon metadata. Edits only rewrite the lines they touch
+// so that comments, key order and formatting are preserved.
+package fix
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnsupported is returned for edits which cannot be applied
+// without rewriting more than the lines they touch.
+var ErrUnsupported = errors.New("unsupported edit")
+
+// Op is the kind of change an Edit makes.
+type Op string
+
+const (
+	// OpSet replaces the value of the field at the path of an
+	// Edit. The field and its missing parents are created if
+	// necessary.
+	OpSet Op = "set"
+	// OpAppend adds the value of an Edit to the list at its path
+	// unless the list already contains it. The list and its missing
+	// parents are created if necessary.
+	OpAppend Op = "append"
+	// OpRemove deletes the field at the path of an Edit together
+	// with the lines of its value. Missing fields are ignored.
+	OpRemove Op = "remove"
+)
+
+// Edit is a change to a single field of a YAML document.
+type Edit struct {
+	// Description summarizes the change for humans.
+	Description string `json:"description"`
+	Op          Op     `json:"op"`
+	// Path is the JSON path of the field to change,
+	// e.g. 'config.secrets' or 'namespaces[0]'.
+	Path string `json:"path"`
+	// Value is encoded to YAML through its JSON encoding.
+	Value interface{} `json:"value"`
+}
+
+func (e Edit) String() string {
+	return e.Description
+}
+
+// Apply applies the edits in order to the YAML document 'data' and
+// returns the resulting document. Edits which are already satisfied
+// by the document are skipped so that applying them twice is safe.
+func Apply(data []byte, edits ...Edit) ([]byte, error) {
+	for _, e := range edits {
+		var err error
+
+		data, err = apply(data, e)
+		if err != nil {
+			return nil, fmt.Errorf("applying edit %q: %w", e.Description, err)
+		}
+	}
+
+	return data, nil
+}
+
+// Diff returns the unified diff between two versions of 'file'
+// or an empty string if they are equal.
+func Diff(file string, before, after []byte) (string, error) {
+	if bytes.Equal(before, after) {
+		return "", nil
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(before),
+		B:        splitLines(after),
+		FromFile: "a/" + file,
+		ToFile:   "b/" + file,
+		Context:  3,
+	})
+}
+
+// splitLines splits 'data' into lines which all
+// end with a newline as expected by difflib.
+func splitLines(data []byte) []string {
+	lines := strings.SplitAfter(string(data), "\n")
+
+	if last := len(lines) - 1; lines[last] == "" {
+		lines = lines[:last]
+	} else {
+		lines[last] += "\n"
+	}
+
+	return lines
+}
+
+func apply(data []byte, e Edit) ([]byte, error) {
+	segs, err := parsePath(e.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(segs) == 0 {
+		return nil, fmt.Errorf("empty path: %w", ErrUnsupported)
+	}
+
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing document: %w", err)
+	}
+
+	d := document{lines: strings.Split(string(data), "\n")}
+
+	var parent *yaml.Node
+	if len(doc.Content) > 0 {
+		parent = doc.Content[0]
+	}
+
+	var key, owner *yaml.Node
+
+	for i, seg := range segs {
+		switch {
+		case e.Op == OpRemove && (parent == nil || isNull(parent)):
+			// nothing to remove
+			return data, nil
+		case seg.isIndex() && (parent == nil || isNull(parent)):
+			return nil, fmt.Errorf("%s: index %d out of range: %w", e.Path, seg.index, ErrUnsupported)
+		case parent == nil:
+			// empty document
+			return d.insertKey(nil, seg.key, wrap(e, segs[i+1:]))
+		case isNull(parent) && key != nil:
+			return d.replace(key, parent, wrap(e, segs[i:]))
+		}
+
+		k, child, err := lookup(parent, seg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Path, err)
+		}
+
+		if child == nil {
+			if e.Op == OpRemove {
+				return data, nil
+			}
+
+			if seg.isIndex() {
+				return nil, fmt.Errorf("%s: index %d out of range: %w", e.Path, seg.index, ErrUnsupported)
+			}
+
+			return d.insertKey(parent, seg.key, wrap(e, segs[i+1:]))
+		}
+
+		owner, key, parent = parent, k, child
+	}
+
+	switch e.Op {
+	case OpSet:
+		if equal(parent, e.Value) {
+			return data, nil
+		}
+
+		return d.replace(key, parent, e.Value)
+	case OpAppend:
+		return d.append(key, parent, e.Value)
+	case OpRemove:
+		return d.remove(owner, key, parent)
+	default:
+		return nil, fmt.Errorf("unknown op %q: %w", e.Op, ErrUnsupported)
+	}
+}
+
+// wrap returns the value to insert for the missing
+// field which is the parent of the fields 'rest'.
+func wrap(e Edit, rest []segment) interface{} {
+	value := e.Value
+
+	if e.Op == OpAppend {
+		value = []interface{}{value}
+	}
+
+	for i := len(rest) - 1; i >= 0; i-- {
+		value = map[string]interface{}{rest[i].key: value}
+	}
+
+	return value
+}
+
+func lookup(n *yaml.Node, seg segment) (*yaml.Node, *yaml.Node, error) {
+	if seg.isIndex() {
+		if n.Kind != yaml.SequenceNode {
+			return nil, nil, fmt.Errorf("expected list: %w", ErrUnsupported)
+		}
+
+		if seg.index >= len(n.Content) {
+			return nil, nil, nil
+		}
+
+		return nil, n.Content[seg.index], nil
+	}
+
+	if n.Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("expected object at %q: %w", seg.key, ErrUnsupported)
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == seg.key {
+			return n.Content[i], n.Content[i+1], nil
+		}
+	}
+
+	return nil, nil, nil
+}
+
+func isNull(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.ShortTag() == "!!null"
+}
+
+// equal reports whether the node 'n' holds 'value'
+// by comparing their JSON encodings.
+func equal(n *yaml.Node, value interface{}) bool {
+	var decoded interface{}
+
+	if err := n.Decode(&decoded); err != nil {
+		return false
+	}
+
+	a, err := normalize(decoded)
+	if err != nil {
+		return false
+	}
+
+	b, err := normalize(value)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func normalize(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var res interface{}
+
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
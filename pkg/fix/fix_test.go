@@ -0,0 +1,299 @@
+package fix
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	metadata := []string{
+		"# reference addon",
+		"id: reference-addon",
+		"label: api.openshift.com/addon-reference # keep me",
+		`defaultChannel: "alpha"`,
+		"namespaces:",
+		"  - redhat-reference-addon",
+		"config:",
+		"  env:",
+		"    - name: FOO",
+		"      value: bar",
+		"  secrets:",
+		"  - name: addon-pullsecret",
+		"    type: kubernetes.io/dockerconfigjson",
+		"    vaultPath: mt-sre/tenants/reference-addon/secrets/pull-secret",
+		"# trailing comment",
+		"",
+	}
+
+	for name, tc := range map[string]struct {
+		Data     []string
+		Edits    []Edit
+		Expected []string
+	}{
+		"set plain scalar keeping comments": {
+			Data: metadata,
+			Edits: []Edit{
+				{Op: OpSet, Path: "label", Value: "api.openshift.com/addon-reference-addon"},
+			},
+			Expected: replaceLine(metadata, 2, "label: api.openshift.com/addon-reference-addon # keep me"),
+		},
+		"set quoted scalar keeping quotes": {
+			Data: metadata,
+			Edits: []Edit{
+				{Op: OpSet, Path: "defaultChannel", Value: "stable"},
+			},
+			Expected: replaceLine(metadata, 3, `defaultChannel: "stable"`),
+		},
+		"set to the current value": {
+			Data: metadata,
+			Edits: []Edit{
+				{Op: OpSet, Path: "id", Value: "reference-addon"},
+			},
+			Expected: metadata,
+		},
+		"set missing field": {
+			Data: metadata,
+			Edits: []Edit{
+				{Op: OpSet, Path: "pullSecretName", Value: "addon-pullsecret"},
+			},
+			Expected: insertLines(metadata, 14, "pullSecretName: addon-pullsecret"),
+		},
+		"set empty field": {
+			Data: []string{"id: reference-addon", "label:", "name: Reference"},
+			Edits: []Edit{
+				{Op: OpSet, Path: "label", Value: "api.openshift.com/addon-reference-addon"},
+			},
+			Expected: []string{"id: reference-addon", "label: api.openshift.com/addon-reference-addon", "name: Reference"},
+		},
+		"append to block list": {
+			Data: metadata,
+			Edits: []Edit{
+				{Op: OpAppend, Path: "namespaces", Value: "redhat-reference-addon-monitoring"},
+			},
+			Expected: insertLines(metadata, 6, "  - redhat-reference-addon-monitoring"),
+		},
+		"append object to nested list": {
+			Data: metadata,
+			Edits: []Edit{
+				{Op: OpAppend, Path: "config.secrets", Value: map[string]string{
+					"name":      "other",
+					"type":      "Opaque",
+					"vaultPath": "mt-sre/tenants/reference-addon/secrets/other",
+				}},
+			},
+			Expected: insertLines(metadata, 14,
+				"  - name: other",
+				"    type: Opaque",
+				"    vaultPath: mt-sre/tenants/reference-addon/secrets/other",
+			),
+		},
+		"append present value": {
+			Data: metadata,
+			Edits: []Edit{
+				{Op: OpAppend, Path: "namespaces", Value: "redhat-reference-addon"},
+			},
+			Expected: metadata,
+		},
+		"append to flow list": {
+			Data: []string{"namespaces: [redhat-a] # flow", "id: a"},
+			Edits: []Edit{
+				{Op: OpAppend, Path: "namespaces", Value: "redhat-b"},
+			},
+			Expected: []string{"namespaces: [redhat-a, redhat-b] # flow", "id: a"},
+		},
+		"append to empty flow list": {
+			Data: []string{"namespaces: []"},
+			Edits: []Edit{
+				{Op: OpAppend, Path: "namespaces", Value: "redhat-a"},
+			},
+			Expected: []string{"namespaces: [redhat-a]"},
+		},
+		"append to missing nested list": {
+			Data: []string{"id: a", "config:", "  env: []", "name: A"},
+			Edits: []Edit{
+				{Op: OpAppend, Path: "config.secrets", Value: map[string]string{"name": "pull"}},
+			},
+			Expected: []string{"id: a", "config:", "  env: []", "  secrets:", "    - name: pull", "name: A"},
+		},
+		"append to empty field": {
+			Data: []string{"namespaces:", "id: a"},
+			Edits: []Edit{
+				{Op: OpAppend, Path: "namespaces", Value: "redhat-a"},
+			},
+			Expected: []string{"namespaces:", "  - redhat-a", "id: a"},
+		},
+		"multiple edits": {
+			Data: []string{"label: foo", "namespaces:", "- redhat-a"},
+			Edits: []Edit{
+				{Op: OpAppend, Path: "namespaces", Value: "redhat-b"},
+				{Op: OpSet, Path: "label", Value: "bar"},
+			},
+			Expected: []string{"label: bar", "namespaces:", "- redhat-a", "- redhat-b"},
+		},
+		"remove scalar keeping comments": {
+			Data: metadata,
+			Edits: []Edit{
+				{Op: OpRemove, Path: "label"},
+			},
+			Expected: append(append([]string{}, metadata[:2]...), metadata[3:]...),
+		},
+		"remove block": {
+			Data: metadata,
+			Edits: []Edit{
+				{Op: OpRemove, Path: "config"},
+			},
+			Expected: append(append([]string{}, metadata[:6]...), metadata[14:]...),
+		},
+		"remove nested field": {
+			Data: metadata,
+			Edits: []Edit{
+				{Op: OpRemove, Path: "config.env"},
+			},
+			Expected: append(append([]string{}, metadata[:7]...), metadata[10:]...),
+		},
+		"remove multi-line plain scalar": {
+			Data: []string{"id: a", "description: foo", "  bar", "  baz", "name: A"},
+			Edits: []Edit{
+				{Op: OpRemove, Path: "description"},
+			},
+			Expected: []string{"id: a", "name: A"},
+		},
+		"remove missing field": {
+			Data: metadata,
+			Edits: []Edit{
+				{Op: OpRemove, Path: "subOperators"},
+				{Op: OpRemove, Path: "config.secrets[3].name"},
+			},
+			Expected: metadata,
+		},
+		"values which need quoting": {
+			Data: []string{"defaultChannel: beta"},
+			Edits: []Edit{
+				{Op: OpSet, Path: "defaultChannel", Value: "1.10"},
+			},
+			Expected: []string{`defaultChannel: "1.10"`},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := Apply([]byte(strings.Join(tc.Data, "\n")), tc.Edits...)
+			require.NoError(t, err)
+
+			assert.Equal(t, strings.Join(tc.Expected, "\n"), string(actual))
+
+			again, err := Apply(actual, tc.Edits...)
+			require.NoError(t, err)
+
+			assert.Equal(t, string(actual), string(again), "edits must be idempotent")
+		})
+	}
+}
+
+func TestApplyUnsupported(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Data string
+		Edit Edit
+	}{
+		"index out of range": {
+			Data: "namespaces: [a]",
+			Edit: Edit{Op: OpSet, Path: "namespaces[1]", Value: "b"},
+		},
+		"append to object": {
+			Data: "config:\n  env: []",
+			Edit: Edit{Op: OpAppend, Path: "config", Value: "b"},
+		},
+		"multi-line value": {
+			Data: "description: |\n  foo\n  bar",
+			Edit: Edit{Op: OpSet, Path: "description", Value: "baz"},
+		},
+		"unknown op": {
+			Data: "id: a",
+			Edit: Edit{Op: "move", Path: "id"},
+		},
+		"remove from flow object": {
+			Data: "config: {env: []}",
+			Edit: Edit{Op: OpRemove, Path: "config.env"},
+		},
+		"remove list item": {
+			Data: "namespaces: [a]",
+			Edit: Edit{Op: OpRemove, Path: "namespaces[0]"},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Apply([]byte(tc.Data), tc.Edit)
+			assert.True(t, errors.Is(err, ErrUnsupported), err)
+		})
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	t.Parallel()
+
+	segs, err := parsePath(`config.secrets[0].labels["a.b/c"]`)
+	require.NoError(t, err)
+
+	var actual []string
+	for _, s := range segs {
+		actual = append(actual, s.String())
+	}
+
+	assert.Equal(t, []string{"config", "secrets", "[0]", "labels", "a.b/c"}, actual)
+
+	for _, path := range []string{"a[", "a[x]", `a["b]`, "a..b"} {
+		_, err := parsePath(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	before := []byte("id: a\nlabel: foo\n")
+	after := []byte("id: a\nlabel: bar\n")
+
+	diff, err := Diff("addon.yaml", before, after)
+	require.NoError(t, err)
+
+	assert.Equal(t, strings.Join([]string{
+		"--- a/addon.yaml",
+		"+++ b/addon.yaml",
+		"@@ -1,2 +1,2 @@",
+		" id: a",
+		"-label: foo",
+		"+label: bar",
+		"",
+	}, "\n"), diff)
+
+	diff, err = Diff("addon.yaml", before, before)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+}
+
+func replaceLine(lines []string, i int, line string) []string {
+	res := append([]string{}, lines...)
+	res[i] = line
+
+	return res
+}
+
+func insertLines(lines []string, after int, inserted ...string) []string {
+	res := append([]string{}, lines[:after]...)
+	res = append(res, inserted...)
+
+	return append(res, lines[after:]...)
+}
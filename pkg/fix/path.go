@@ -0,0 +1,92 @@
+package fix
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// segment is a single step of a JSON path which is
+// either an object key or a list index.
+type segment struct {
+	key   string
+	index int
+}
+
+func (s segment) isIndex() bool { return s.index >= 0 }
+
+func (s segment) String() string {
+	if s.isIndex() {
+		return fmt.Sprintf("[%d]", s.index)
+	}
+
+	return s.key
+}
+
+// parsePath splits paths such as 'config.secrets[0].name' or
+// 'namespaceLabels["foo.bar"]' into their segments.
+func parsePath(path string) ([]segment, error) {
+	var segs []segment
+
+	for rest := path; rest != ""; {
+		switch {
+		case strings.HasPrefix(rest, `["`):
+			end := closingQuote(rest[1:])
+			if end < 0 || !strings.HasPrefix(rest[end+2:], "]") {
+				return nil, fmt.Errorf("invalid path %q: unterminated key", path)
+			}
+
+			key, err := strconv.Unquote(rest[1 : end+2])
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q: %w", path, err)
+			}
+
+			segs = append(segs, segment{key: key, index: -1})
+			rest = rest[end+3:]
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: unterminated index", path)
+			}
+
+			i, err := strconv.Atoi(rest[1:end])
+			if err != nil || i < 0 {
+				return nil, fmt.Errorf("invalid path %q: invalid index %q", path, rest[1:end])
+			}
+
+			segs = append(segs, segment{index: i})
+			rest = rest[end+1:]
+		default:
+			rest = strings.TrimPrefix(rest, ".")
+
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+
+			if end == 0 {
+				return nil, fmt.Errorf("invalid path %q: empty key", path)
+			}
+
+			segs = append(segs, segment{key: rest[:end], index: -1})
+			rest = rest[end:]
+		}
+	}
+
+	return segs, nil
+}
+
+// closingQuote returns the index of the quote terminating
+// the quoted string at the start of 's' or -1.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,374 @@
+// Package migrate moves addons which reference a static index image,
+// or none at all, to the layout where the index image and the fields
+// bound to it are versioned in imagesets.
+package migrate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/fix"
+	"github.com/mt-sre/addon-metadata-operator/pkg/utils"
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrAlreadyMigrated is returned for addons which
+	// already reference an imageset version.
+	ErrAlreadyMigrated = errors.New("addon already references an imageset version")
+	// ErrNoIndexImage is returned for addons which do not reference
+	// an index image unless one is given with WithIndexImage.
+	ErrNoIndexImage = errors.New("addon does not reference an index image")
+	// ErrUnknownVersion is returned if the imageset version cannot be
+	// derived from the index image and none is given with WithVersion.
+	ErrUnknownVersion = errors.New("imageset version cannot be derived from the index image")
+	// ErrImageSetExists is returned if the imageset to
+	// be created already exists.
+	ErrImageSetExists = errors.New("imageset already exists")
+)
+
+// movedFields are the fields of the addon metadata
+// which are moved to the imageset in order.
+var movedFields = []string{
+	"addOnParameters",
+	"addOnRequirements",
+	"subOperators",
+	"config",
+	"pullSecretName",
+	"additionalCatalogSources",
+}
+
+// deprecatedFields are legacy fields of the addon metadata which
+// are not migrated together with how to replace each of them.
+var deprecatedFields = []struct {
+	Name        string
+	Replacement string
+}{
+	{Name: "channels", Replacement: "the channels are read from the bundles of the index image; remove it"},
+	{Name: "bundleParameters", Replacement: "it is replaced by 'subscriptionConfig'; move its values there"},
+}
+
+// Migration holds the files of an addon after moving its
+// metadata for one environment to the imageset layout.
+type Migration struct {
+	// Dir is the directory of the addon.
+	Dir string
+	Env string
+	// Version is the version of the created imageset.
+	Version string
+	// MetadataPath is the path of the metadata file relative to Dir.
+	MetadataPath     string
+	OriginalMetadata []byte
+	Metadata         []byte
+	// ImageSetPath is the path of the created imageset relative to Dir.
+	ImageSetPath string
+	ImageSet     []byte
+	// Moved lists the fields moved from the metadata to the imageset.
+	Moved []string
+	// Notes lists what could not be migrated and must
+	// be completed by hand.
+	Notes []string
+}
+
+// ImageSet migrates the metadata of the addon in 'addonDir' for the
+// environment 'env' to the imageset layout. The index image and the
+// fields in 'movedFields' are moved to a new imageset which is then
+// referenced by the metadata. No files are written until Write is
+// called on the returned Migration.
+func ImageSet(addonDir, env string, opts ...ImageSetOption) (*Migration, error) {
+	var cfg ImageSetConfig
+
+	cfg.Option(opts...)
+
+	metaPath := utils.MetadataPath(addonDir, env)
+
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading metadata: %w", err)
+	}
+
+	var meta addonsv1alpha1.AddonMetadataSpec
+
+	if err := meta.FromYAML(data); err != nil {
+		return nil, fmt.Errorf("decoding metadata: %w", err)
+	}
+
+	if meta.ImageSetVersion != nil {
+		return nil, ErrAlreadyMigrated
+	}
+
+	root, err := parseMapping(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing metadata: %w", err)
+	}
+
+	indexImage := value(root, "indexImage")
+
+	if cfg.IndexImage != "" {
+		indexImage = &yaml.Node{Kind: yaml.ScalarNode, Value: cfg.IndexImage}
+	}
+
+	if indexImage == nil || indexImage.Value == "" {
+		return nil, ErrNoIndexImage
+	}
+
+	version, err := imageSetVersion(cfg.Version, indexImage.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("%s.v%s", filepath.Base(addonDir), version)
+	imageSetPath := filepath.Join("addonimagesets", env, name+".yaml")
+
+	if _, err := os.Stat(filepath.Join(addonDir, imageSetPath)); err == nil {
+		return nil, fmt.Errorf("%s: %w", imageSetPath, ErrImageSetExists)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("checking for existing imageset: %w", err)
+	}
+
+	m := &Migration{
+		Dir:              addonDir,
+		Env:              env,
+		Version:          version,
+		MetadataPath:     filepath.Join("metadata", env, "addon.yaml"),
+		OriginalMetadata: data,
+		ImageSetPath:     imageSetPath,
+	}
+
+	imageSet := &yaml.Node{Kind: yaml.MappingNode}
+
+	addField(imageSet, "name", &yaml.Node{Kind: yaml.ScalarNode, Value: name})
+	addField(imageSet, "indexImage", indexImage)
+	addField(imageSet, "relatedImages", &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle})
+
+	m.Notes = append(m.Notes, fmt.Sprintf(
+		"'relatedImages' cannot be derived from the index image; list the images of related operators in %s", imageSetPath,
+	))
+
+	for _, field := range deprecatedFields {
+		if value(root, field.Name) == nil {
+			continue
+		}
+
+		m.Notes = append(m.Notes, fmt.Sprintf(
+			"'%s' in %s is deprecated and was not migrated: %s", field.Name, m.MetadataPath, field.Replacement,
+		))
+	}
+
+	edits := []fix.Edit{
+		{
+			Description: "reference imageset version " + version,
+			Op:          fix.OpSet,
+			Path:        "addonImageSetVersion",
+			Value:       version,
+		},
+	}
+
+	if value(root, "indexImage") != nil {
+		edits = append(edits, removal("indexImage"))
+	}
+
+	for _, field := range movedFields {
+		n := value(root, field)
+		if n == nil {
+			continue
+		}
+
+		addField(imageSet, field, n)
+		edits = append(edits, removal(field))
+
+		m.Moved = append(m.Moved, field)
+	}
+
+	if m.ImageSet, err = encode(imageSet); err != nil {
+		return nil, fmt.Errorf("encoding imageset: %w", err)
+	}
+
+	m.Metadata = data
+
+	for _, e := range edits {
+		res, err := fix.Apply(m.Metadata, e)
+		if errors.Is(err, fix.ErrUnsupported) {
+			m.Notes = append(m.Notes, fmt.Sprintf("could not %s; edit %s by hand: %v", e.Description, m.MetadataPath, err))
+
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("rewriting metadata: %w", err)
+		}
+
+		m.Metadata = res
+	}
+
+	return m, nil
+}
+
+// Diff returns the unified diff of the files changed
+// and created by the Migration.
+func (m *Migration) Diff() (string, error) {
+	metaDiff, err := fix.Diff(m.MetadataPath, m.OriginalMetadata, m.Metadata)
+	if err != nil {
+		return "", fmt.Errorf("computing metadata diff: %w", err)
+	}
+
+	imageSetDiff, err := fix.Diff(m.ImageSetPath, nil, m.ImageSet)
+	if err != nil {
+		return "", fmt.Errorf("computing imageset diff: %w", err)
+	}
+
+	return metaDiff + imageSetDiff, nil
+}
+
+// Write creates the imageset and rewrites the metadata file. Both
+// files are written to temporary files first and the imageset is
+// removed again if the metadata cannot be moved into place so that
+// a failed Write can be rerun.
+func (m *Migration) Write() error {
+	metaPath := filepath.Join(m.Dir, m.MetadataPath)
+
+	info, err := os.Stat(metaPath)
+	if err != nil {
+		return fmt.Errorf("reading metadata file info: %w", err)
+	}
+
+	imageSetPath := filepath.Join(m.Dir, m.ImageSetPath)
+
+	if err := os.MkdirAll(filepath.Dir(imageSetPath), 0o755); err != nil {
+		return fmt.Errorf("creating imageset directory: %w", err)
+	}
+
+	imageSetTmp, err := fix.WriteTemp(imageSetPath, m.ImageSet, 0o644)
+	if err != nil {
+		return fmt.Errorf("writing imageset: %w", err)
+	}
+
+	defer func() { _ = os.Remove(imageSetTmp) }()
+
+	metaTmp, err := fix.WriteTemp(metaPath, m.Metadata, info.Mode().Perm())
+	if err != nil {
+		return fmt.Errorf("writing metadata: %w", err)
+	}
+
+	defer func() { _ = os.Remove(metaTmp) }()
+
+	if err := os.Rename(imageSetTmp, imageSetPath); err != nil {
+		return fmt.Errorf("moving imageset into place: %w", err)
+	}
+
+	if err := os.Rename(metaTmp, metaPath); err != nil {
+		_ = os.Remove(imageSetPath)
+
+		return fmt.Errorf("moving metadata into place: %w", err)
+	}
+
+	return nil
+}
+
+// imageSetVersion returns the version 'version' if set and
+// otherwise the version of the tag of 'indexImage'.
+func imageSetVersion(version, indexImage string) (string, error) {
+	if version != "" {
+		res, ok := parseVersion(version)
+		if !ok {
+			return "", fmt.Errorf("'%s' is not a valid version; must match 'MAJOR.MINOR.PATCH'", version)
+		}
+
+		return res, nil
+	}
+
+	// digests do not carry a version
+	if strings.Contains(indexImage, "@") {
+		return "", fmt.Errorf("%s: %w", indexImage, ErrUnknownVersion)
+	}
+
+	i := strings.LastIndex(indexImage, ":")
+	if i < 0 || strings.Contains(indexImage[i:], "/") {
+		return "", fmt.Errorf("%s: %w", indexImage, ErrUnknownVersion)
+	}
+
+	res, ok := parseVersion(indexImage[i+1:])
+	if !ok {
+		return "", fmt.Errorf("%s: %w", indexImage, ErrUnknownVersion)
+	}
+
+	return res, nil
+}
+
+// parseVersion returns 'v' in the 'MAJOR.MINOR.PATCH' format if
+// it is a complete semantic version optionally prefixed with 'v'.
+func parseVersion(v string) (string, bool) {
+	canonical := "v" + strings.TrimPrefix(v, "v")
+
+	if !semver.IsValid(canonical) || semver.Canonical(canonical) != canonical {
+		return "", false
+	}
+
+	return strings.TrimPrefix(canonical, "v"), true
+}
+
+func removal(field string) fix.Edit {
+	return fix.Edit{
+		Description: fmt.Sprintf("remove '%s'", field),
+		Op:          fix.OpRemove,
+		Path:        field,
+	}
+}
+
+// parseMapping returns the top-level mapping of the YAML document
+// 'data' or an empty mapping if the document is empty.
+func parseMapping(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.MappingNode}, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, errors.New("expected an object")
+	}
+
+	return root, nil
+}
+
+// value returns the value of the field 'key' of
+// the mapping 'n' or nil if it is not present.
+func value(n *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func addField(n *yaml.Node, key string, value *yaml.Node) {
+	n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+}
+
+func encode(n *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(n); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
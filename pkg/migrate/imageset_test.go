@@ -0,0 +1,217 @@
+package migrate
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestImageSetGolden(t *testing.T) {
+	t.Parallel()
+
+	addonDir := filepath.Join(t.TempDir(), "legacy-addon")
+	metaPath := utils.MetadataPath(addonDir, "stage")
+
+	data, err := os.ReadFile(utils.MetadataPath(filepath.Join("testdata", "legacy-addon"), "stage"))
+	require.NoError(t, err)
+
+	writeFile(t, metaPath, string(data))
+
+	var original addonsv1alpha1.AddonMetadataSpec
+
+	require.NoError(t, original.FromYAML(data))
+
+	m, err := ImageSet(addonDir, "stage")
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.2.0", m.Version)
+	assert.Equal(t, []string{
+		"addOnParameters",
+		"addOnRequirements",
+		"subOperators",
+		"config",
+		"pullSecretName",
+		"additionalCatalogSources",
+	}, m.Moved)
+	assert.Len(t, m.Notes, 1)
+
+	diff, err := m.Diff()
+	require.NoError(t, err)
+
+	golden := filepath.Join("testdata", "legacy-addon.golden.diff")
+
+	if *update {
+		require.NoError(t, os.WriteFile(golden, []byte(diff), 0o644))
+	}
+
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expected), diff,
+		"%s is out of date; run 'go test ./pkg/migrate -run TestImageSetGolden -update'", golden,
+	)
+
+	require.NoError(t, m.Write())
+
+	migrated, err := utils.NewMetaLoader(addonDir, "stage", "").Load()
+	require.NoError(t, err)
+
+	// the combined metadata only differs by the imageset version
+	original.ImageSetVersion = &m.Version
+
+	assert.Equal(t, &original, migrated)
+
+	_, err = ImageSet(addonDir, "stage")
+	assert.ErrorIs(t, err, ErrAlreadyMigrated)
+}
+
+func TestImageSetErrors(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Metadata  string
+		ImageSets []string
+		Options   []ImageSetOption
+		Expected  error
+	}{
+		"already migrated": {
+			Metadata: "id: addon\naddonImageSetVersion: 1.0.0\n",
+			Expected: ErrAlreadyMigrated,
+		},
+		"no index image": {
+			Metadata: "id: addon\n",
+			Expected: ErrNoIndexImage,
+		},
+		"digest without version": {
+			Metadata: "id: addon\nindexImage: quay.io/osd-addons/addon-index@sha256:0c8b02008f2c\n",
+			Expected: ErrUnknownVersion,
+		},
+		"tag which is not a version": {
+			Metadata: "id: addon\nindexImage: quay.io/osd-addons/addon-index:latest\n",
+			Expected: ErrUnknownVersion,
+		},
+		"existing imageset": {
+			Metadata:  "id: addon\nindexImage: quay.io/osd-addons/addon-index:v1.0.0\n",
+			ImageSets: []string{"addon.v1.0.0.yaml"},
+			Expected:  ErrImageSetExists,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addonDir := filepath.Join(t.TempDir(), "addon")
+
+			writeFile(t, utils.MetadataPath(addonDir, "stage"), tc.Metadata)
+
+			for _, is := range tc.ImageSets {
+				writeFile(t, filepath.Join(addonDir, "addonimagesets", "stage", is), "name: "+strings.TrimSuffix(is, ".yaml"))
+			}
+
+			_, err := ImageSet(addonDir, "stage", tc.Options...)
+			assert.ErrorIs(t, err, tc.Expected)
+		})
+	}
+}
+
+func TestImageSetOptions(t *testing.T) {
+	t.Parallel()
+
+	addonDir := filepath.Join(t.TempDir(), "addon")
+
+	writeFile(t, utils.MetadataPath(addonDir, "stage"), "id: addon\n")
+
+	m, err := ImageSet(addonDir, "stage",
+		WithIndexImage("quay.io/osd-addons/addon-index@sha256:0c8b02008f2c"),
+		WithVersion("v0.3.1"),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "id: addon\naddonImageSetVersion: 0.3.1\n", string(m.Metadata))
+	assert.Equal(t, strings.Join([]string{
+		"name: addon.v0.3.1",
+		"indexImage: quay.io/osd-addons/addon-index@sha256:0c8b02008f2c",
+		"relatedImages: []",
+		"",
+	}, "\n"), string(m.ImageSet))
+	assert.Equal(t, filepath.Join("addonimagesets", "stage", "addon.v0.3.1.yaml"), m.ImageSetPath)
+
+	_, err = ImageSet(addonDir, "stage",
+		WithIndexImage("quay.io/osd-addons/addon-index@sha256:0c8b02008f2c"),
+		WithVersion("1.0"),
+	)
+	assert.Error(t, err)
+}
+
+func TestImageSetDeprecatedFields(t *testing.T) {
+	t.Parallel()
+
+	addonDir := filepath.Join(t.TempDir(), "addon")
+
+	writeFile(t, utils.MetadataPath(addonDir, "stage"), strings.Join([]string{
+		"id: addon",
+		"indexImage: quay.io/osd-addons/addon-index:v1.0.0",
+		"channels:",
+		"  - name: alpha",
+		"    currentCSV: addon.v1.0.0",
+		"bundleParameters: {}",
+		"",
+	}, "\n"))
+
+	m, err := ImageSet(addonDir, "stage")
+	require.NoError(t, err)
+
+	metaPath := filepath.Join("metadata", "stage", "addon.yaml")
+
+	assert.Contains(t, m.Notes, "'channels' in "+metaPath+" is deprecated and was not migrated: "+
+		"the channels are read from the bundles of the index image; remove it")
+	assert.Contains(t, m.Notes, "'bundleParameters' in "+metaPath+" is deprecated and was not migrated: "+
+		"it is replaced by 'subscriptionConfig'; move its values there")
+}
+
+func TestMigrationWriteFailure(t *testing.T) {
+	t.Parallel()
+
+	addonDir := filepath.Join(t.TempDir(), "addon")
+	metaPath := utils.MetadataPath(addonDir, "stage")
+
+	writeFile(t, metaPath, "id: addon\nindexImage: quay.io/osd-addons/addon-index:v1.0.0\n")
+
+	m, err := ImageSet(addonDir, "stage")
+	require.NoError(t, err)
+
+	// a non-empty directory cannot be replaced by the rewritten metadata
+	require.NoError(t, os.Remove(metaPath))
+	writeFile(t, filepath.Join(metaPath, "blocker"), "")
+
+	require.Error(t, m.Write())
+
+	assert.NoFileExists(t, filepath.Join(addonDir, m.ImageSetPath))
+
+	entries, err := os.ReadDir(filepath.Join(addonDir, "addonimagesets", "stage"))
+	require.NoError(t, err)
+	assert.Empty(t, entries, "temporary files must be removed")
+
+	require.NoError(t, os.RemoveAll(metaPath))
+	writeFile(t, metaPath, string(m.OriginalMetadata))
+
+	_, err = ImageSet(addonDir, "stage")
+	assert.NoError(t, err)
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+}
@@ -0,0 +1,33 @@
+package migrate
+
+type ImageSetConfig struct {
+	IndexImage string
+	Version    string
+}
+
+func (c *ImageSetConfig) Option(opts ...ImageSetOption) {
+	for _, opt := range opts {
+		opt.ConfigureImageSet(c)
+	}
+}
+
+type ImageSetOption interface {
+	ConfigureImageSet(*ImageSetConfig)
+}
+
+// WithIndexImage applies the index image of the imageset. It is
+// required for addons which do not reference an index image and
+// replaces the index image of all others.
+type WithIndexImage string
+
+func (w WithIndexImage) ConfigureImageSet(c *ImageSetConfig) {
+	c.IndexImage = string(w)
+}
+
+// WithVersion applies the version of the imageset. It is required
+// if the version cannot be derived from the tag of the index image.
+type WithVersion string
+
+func (w WithVersion) ConfigureImageSet(c *ImageSetConfig) {
+	c.Version = string(w)
+}
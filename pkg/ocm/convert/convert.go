@@ -0,0 +1,230 @@
+// Package convert builds the representations of an addon in the
+// clusters_mgmt API of OCM from its combined metadata and imageset.
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	ocmv1 "github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1"
+)
+
+const addonsPath = "/api/clusters_mgmt/v1/addons"
+
+// Convert returns the AddOn and AddOnVersion payloads
+// for the addon described by 'meta'.
+func Convert(meta *addonsv1alpha1.AddonMetadataSpec) (Payload, error) {
+	addon, err := ToAddOn(meta)
+	if err != nil {
+		return Payload{}, err
+	}
+
+	version, err := ToAddOnVersion(meta)
+	if err != nil {
+		return Payload{}, err
+	}
+
+	return Payload{
+		AddOn:   addon,
+		Version: version,
+	}, nil
+}
+
+// WriteJSON writes the payload as indented JSON.
+func (p Payload) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(p)
+}
+
+// ToAddOn returns the AddOn representation of 'meta'.
+func ToAddOn(meta *addonsv1alpha1.AddonMetadataSpec) (AddOn, error) {
+	installMode, err := toInstallMode(meta.InstallMode)
+	if err != nil {
+		return AddOn{}, err
+	}
+
+	addon := AddOn{
+		Kind:                 "AddOn",
+		ID:                   meta.ID,
+		HREF:                 addonsPath + "/" + meta.ID,
+		Name:                 meta.Name,
+		Description:          meta.Description,
+		DocsLink:             meta.Link,
+		Label:                meta.Label,
+		Icon:                 meta.Icon,
+		Enabled:              meta.Enabled,
+		ResourceName:         meta.OcmQuotaName,
+		ResourceCost:         float64(meta.OcmQuotaCost),
+		OperatorName:         meta.OperatorName,
+		InstallMode:          installMode,
+		TargetNamespace:      meta.TargetNamespace,
+		HasExternalResources: meta.HasExternalResources != nil && *meta.HasExternalResources,
+		ManagedService:       meta.ManagedService != nil && *meta.ManagedService,
+	}
+
+	for _, ns := range meta.Namespaces {
+		addon.Namespaces = append(addon.Namespaces, AddOnNamespace{
+			Name:        ns,
+			Labels:      copyMap(meta.NamespaceLabels),
+			Annotations: copyMap(meta.NamespaceAnnotations),
+		})
+	}
+
+	if meta.CommonLabels != nil {
+		addon.CommonLabels = copyMap(*meta.CommonLabels)
+	}
+
+	if meta.CommonAnnotations != nil {
+		addon.CommonAnnotations = copyMap(*meta.CommonAnnotations)
+	}
+
+	if meta.CredentialsRequests != nil {
+		for _, cr := range *meta.CredentialsRequests {
+			permissions := []string{}
+			if cr.PolicyPermissions != nil {
+				permissions = append(permissions, *cr.PolicyPermissions...)
+			}
+
+			addon.CredentialsRequests = append(addon.CredentialsRequests, CredentialRequest{
+				Name:              cr.Name,
+				Namespace:         cr.Namespace,
+				ServiceAccount:    cr.ServiceAccount,
+				PolicyPermissions: permissions,
+			})
+		}
+	}
+
+	if meta.ImageSetVersion != nil {
+		addon.Version = &AddOnVersionLink{
+			Kind: "AddOnVersionLink",
+			ID:   *meta.ImageSetVersion,
+			HREF: versionHREF(meta.ID, *meta.ImageSetVersion),
+		}
+	}
+
+	return addon, nil
+}
+
+// ToAddOnVersion returns the AddOnVersion representation of 'meta'.
+// The version is identified by the imageset version of the addon
+// which is unset for addons referencing an index image directly.
+func ToAddOnVersion(meta *addonsv1alpha1.AddonMetadataSpec) (AddOnVersion, error) {
+	if meta.IndexImage == nil {
+		return AddOnVersion{}, errors.New("addon metadata does not reference an index image")
+	}
+
+	version := AddOnVersion{
+		Kind:           "AddOnVersion",
+		Enabled:        meta.Enabled,
+		Channel:        meta.DefaultChannel,
+		SourceImage:    *meta.IndexImage,
+		PullSecretName: meta.PullSecretName,
+	}
+
+	if meta.ImageSetVersion != nil {
+		version.ID = *meta.ImageSetVersion
+		version.HREF = versionHREF(meta.ID, *meta.ImageSetVersion)
+	}
+
+	if meta.AdditionalCatalogSources != nil {
+		for _, src := range *meta.AdditionalCatalogSources {
+			version.AdditionalCatalogSources = append(version.AdditionalCatalogSources, CatalogSource{
+				Name:    src.Name,
+				Image:   src.Image,
+				Enabled: true,
+			})
+		}
+	}
+
+	if meta.AddOnParameters != nil && len(*meta.AddOnParameters) > 0 {
+		version.Parameters = &ParameterList{
+			Items: append([]ocmv1.AddOnParameter{}, *meta.AddOnParameters...),
+		}
+	}
+
+	if meta.AddOnRequirements != nil {
+		version.Requirements = append(version.Requirements, *meta.AddOnRequirements...)
+	}
+
+	if meta.SubOperators != nil {
+		version.SubOperators = append(version.SubOperators, *meta.SubOperators...)
+	}
+
+	version.Config = toConfig(meta)
+
+	return version, nil
+}
+
+func toInstallMode(mode string) (InstallMode, error) {
+	switch mode {
+	case "AllNamespaces":
+		return InstallModeAllNamespaces, nil
+	case "OwnNamespace":
+		return InstallModeOwnNamespace, nil
+	default:
+		return "", fmt.Errorf("unsupported install mode %q", mode)
+	}
+}
+
+// toConfig returns the environment variables and the secrets
+// propagated to the addon namespaces or nil if there are none.
+func toConfig(meta *addonsv1alpha1.AddonMetadataSpec) *AddOnConfig {
+	if meta.Config == nil {
+		return nil
+	}
+
+	var cfg AddOnConfig
+
+	if meta.Config.Env != nil {
+		for _, env := range *meta.Config.Env {
+			cfg.EnvironmentVariables = append(cfg.EnvironmentVariables, EnvironmentVariable{
+				Name:    env.Name,
+				Value:   env.Value,
+				Enabled: true,
+			})
+		}
+	}
+
+	if meta.Config.Secrets != nil {
+		for _, secret := range *meta.Config.Secrets {
+			if secret.DestinationSecretName == nil {
+				continue
+			}
+
+			cfg.SecretPropagations = append(cfg.SecretPropagations, SecretPropagation{
+				SourceSecret:      secret.Name,
+				DestinationSecret: *secret.DestinationSecretName,
+				Enabled:           true,
+			})
+		}
+	}
+
+	if len(cfg.EnvironmentVariables) == 0 && len(cfg.SecretPropagations) == 0 {
+		return nil
+	}
+
+	return &cfg
+}
+
+func versionHREF(addonID, version string) string {
+	return addonsPath + "/" + addonID + "/versions/" + version
+}
+
+func copyMap(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+
+	res := make(map[string]string, len(m))
+
+	for k, v := range m {
+		res[k] = v
+	}
+
+	return res
+}
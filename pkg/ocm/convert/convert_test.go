@@ -0,0 +1,97 @@
+package convert
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestConvertGolden(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"minimal", "full"} {
+		name := name
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			meta := loadMeta(t, filepath.Join("testdata", name+".yaml"))
+
+			payload, err := Convert(meta)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+
+			require.NoError(t, payload.WriteJSON(&buf))
+
+			golden := filepath.Join("testdata", name+".golden.json")
+
+			if *update {
+				require.NoError(t, os.WriteFile(golden, buf.Bytes(), 0o644))
+			}
+
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+
+			assert.Equal(t, string(expected), buf.String(),
+				"%s is out of date; run 'go test ./pkg/ocm/convert -run TestConvertGolden -update'", golden,
+			)
+		})
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Meta     func(*addonsv1alpha1.AddonMetadataSpec)
+		Expected string
+	}{
+		"missing index image": {
+			Meta: func(meta *addonsv1alpha1.AddonMetadataSpec) {
+				meta.IndexImage = nil
+			},
+			Expected: "addon metadata does not reference an index image",
+		},
+		"unsupported install mode": {
+			Meta: func(meta *addonsv1alpha1.AddonMetadataSpec) {
+				meta.InstallMode = "SingleNamespace"
+			},
+			Expected: `unsupported install mode "SingleNamespace"`,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			meta := loadMeta(t, filepath.Join("testdata", "minimal.yaml"))
+			tc.Meta(meta)
+
+			_, err := Convert(meta)
+			require.Error(t, err)
+			assert.EqualError(t, err, tc.Expected)
+		})
+	}
+}
+
+func loadMeta(t *testing.T, path string) *addonsv1alpha1.AddonMetadataSpec {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var meta addonsv1alpha1.AddonMetadataSpec
+
+	require.NoError(t, meta.FromYAML(data))
+
+	return &meta
+}
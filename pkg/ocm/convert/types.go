@@ -0,0 +1,121 @@
+package convert
+
+import (
+	ocmv1 "github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1"
+)
+
+/*
+The types below mirror the JSON representation of addons in the
+clusters_mgmt API of OCM. Only the fields which are derived from
+the addon metadata are included.
+*/
+
+// Payload holds the representations of an addon and
+// of its current version which are sent to OCM.
+type Payload struct {
+	AddOn   AddOn        `json:"addon"`
+	Version AddOnVersion `json:"version"`
+}
+
+// AddOn mirrors 'clusters_mgmt/v1/AddOn'.
+type AddOn struct {
+	Kind                 string              `json:"kind"`
+	ID                   string              `json:"id"`
+	HREF                 string              `json:"href"`
+	Name                 string              `json:"name"`
+	Description          string              `json:"description"`
+	DocsLink             string              `json:"docs_link,omitempty"`
+	Label                string              `json:"label"`
+	Icon                 string              `json:"icon"`
+	Enabled              bool                `json:"enabled"`
+	ResourceName         string              `json:"resource_name"`
+	ResourceCost         float64             `json:"resource_cost"`
+	OperatorName         string              `json:"operator_name"`
+	InstallMode          InstallMode         `json:"install_mode"`
+	TargetNamespace      string              `json:"target_namespace"`
+	Namespaces           []AddOnNamespace    `json:"namespaces,omitempty"`
+	CommonLabels         map[string]string   `json:"common_labels,omitempty"`
+	CommonAnnotations    map[string]string   `json:"common_annotations,omitempty"`
+	CredentialsRequests  []CredentialRequest `json:"credentials_requests,omitempty"`
+	HasExternalResources bool                `json:"has_external_resources"`
+	ManagedService       bool                `json:"managed_service"`
+	Version              *AddOnVersionLink   `json:"version,omitempty"`
+}
+
+// InstallMode mirrors 'clusters_mgmt/v1/AddOnInstallMode'.
+type InstallMode string
+
+const (
+	InstallModeAllNamespaces InstallMode = "all_namespaces"
+	InstallModeOwnNamespace  InstallMode = "own_namespace"
+)
+
+// AddOnNamespace mirrors 'clusters_mgmt/v1/AddOnNamespace'.
+type AddOnNamespace struct {
+	Name        string            `json:"name"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// CredentialRequest mirrors 'clusters_mgmt/v1/CredentialRequest'.
+type CredentialRequest struct {
+	Name              string   `json:"name"`
+	Namespace         string   `json:"namespace"`
+	ServiceAccount    string   `json:"service_account"`
+	PolicyPermissions []string `json:"policy_permissions"`
+}
+
+// AddOnVersionLink references an AddOnVersion from its AddOn.
+type AddOnVersionLink struct {
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+	HREF string `json:"href"`
+}
+
+// AddOnVersion mirrors 'clusters_mgmt/v1/AddOnVersion'.
+type AddOnVersion struct {
+	Kind                     string                   `json:"kind"`
+	ID                       string                   `json:"id,omitempty"`
+	HREF                     string                   `json:"href,omitempty"`
+	Enabled                  bool                     `json:"enabled"`
+	Channel                  string                   `json:"channel"`
+	SourceImage              string                   `json:"source_image"`
+	PullSecretName           string                   `json:"pull_secret_name,omitempty"`
+	AdditionalCatalogSources []CatalogSource          `json:"additional_catalog_sources,omitempty"`
+	Parameters               *ParameterList           `json:"parameters,omitempty"`
+	Requirements             []ocmv1.AddOnRequirement `json:"requirements,omitempty"`
+	SubOperators             []ocmv1.AddOnSubOperator `json:"sub_operators,omitempty"`
+	Config                   *AddOnConfig             `json:"config,omitempty"`
+}
+
+// ParameterList mirrors 'clusters_mgmt/v1/AddOnParameterList'.
+type ParameterList struct {
+	Items []ocmv1.AddOnParameter `json:"items"`
+}
+
+// CatalogSource mirrors 'clusters_mgmt/v1/AdditionalCatalogSource'.
+type CatalogSource struct {
+	Name    string `json:"name"`
+	Image   string `json:"image"`
+	Enabled bool   `json:"enabled"`
+}
+
+// AddOnConfig mirrors 'clusters_mgmt/v1/AddOnConfig'.
+type AddOnConfig struct {
+	EnvironmentVariables []EnvironmentVariable `json:"add_on_environment_variables,omitempty"`
+	SecretPropagations   []SecretPropagation   `json:"secret_propagations,omitempty"`
+}
+
+// EnvironmentVariable mirrors 'clusters_mgmt/v1/AddOnEnvironmentVariable'.
+type EnvironmentVariable struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	Enabled bool   `json:"enabled"`
+}
+
+// SecretPropagation mirrors 'clusters_mgmt/v1/AddOnSecretPropagation'.
+type SecretPropagation struct {
+	SourceSecret      string `json:"source_secret"`
+	DestinationSecret string `json:"destination_secret"`
+	Enabled           bool   `json:"enabled"`
+}
@@ -3,6 +3,7 @@ package operator
 import (
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"sort"
@@ -37,6 +38,50 @@ func NewBundleFromDirectory(path string) (Bundle, error) {
 	return bundle, nil
 }
 
+// NewBundlesFromDirectoryTree loads every directory below 'root' which
+// contains both a manifests and a metadata directory as a bundle. Since
+// such bundles are not pulled from an image, their BundleImage is set to
+// the path of the bundle directory.
+func NewBundlesFromDirectoryTree(root string) ([]Bundle, error) {
+	var bundles []Bundle
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() || !isBundleDirectory(path) {
+			return nil
+		}
+
+		bundle, err := NewBundleFromDirectory(path)
+		if err != nil {
+			return fmt.Errorf("loading bundle from %q: %w", path, err)
+		}
+
+		bundle.BundleImage = path
+		bundles = append(bundles, bundle)
+
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bundles, nil
+}
+
+func isBundleDirectory(path string) bool {
+	for _, dir := range []string{opmbundle.ManifestsDir, opmbundle.MetadataDir} {
+		fi, err := os.Stat(filepath.Join(path, dir))
+		if err != nil || !fi.IsDir() {
+			return false
+		}
+	}
+
+	return true
+}
+
 func readAllManifests(manifestsDir string) ([]*unstructured.Unstructured, error) {
 	var objs []*unstructured.Unstructured
 
@@ -186,6 +231,7 @@ func NewClusterServiceVersionfromRegistryCSV(csv registry.ClusterServiceVersion)
 
 	return ClusterServiceVersion{
 		Name:                              csv.Name,
+		Annotations:                       csv.GetAnnotations(),
 		OwnedCustomResourceDefinitions:    ownedCRDs,
 		RequiredCustomResourceDefinitions: requiredCRDs,
 		Spec:                              spec,
@@ -194,6 +240,7 @@ func NewClusterServiceVersionfromRegistryCSV(csv registry.ClusterServiceVersion)
 
 type ClusterServiceVersion struct {
 	Name                              string
+	Annotations                       map[string]string
 	OwnedCustomResourceDefinitions    []CustomResourceDefinition
 	RequiredCustomResourceDefinitions []CustomResourceDefinition
 	Spec                              opsv1alpha1.ClusterServiceVersionSpec
@@ -0,0 +1,34 @@
+package operator_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBundlesFromDirectoryTree(t *testing.T) {
+	t.Parallel()
+
+	root := filepath.Join(testutils.RootDir().TestData().Bundles(), "reference-addon")
+
+	bundles, err := operator.NewBundlesFromDirectoryTree(root)
+	require.NoError(t, err)
+	require.Len(t, bundles, 2)
+
+	byPackage := make(map[string]operator.Bundle, len(bundles))
+
+	for _, b := range bundles {
+		byPackage[b.Package] = b
+	}
+
+	require.Contains(t, byPackage, "reference-addon")
+	assert.Equal(t, "0.1.6", byPackage["reference-addon"].Version)
+	assert.Equal(t, filepath.Join(root, "main", "0.1.6"), byPackage["reference-addon"].BundleImage)
+
+	require.Contains(t, byPackage, "addon-operator")
+	assert.Equal(t, "0.3.0", byPackage["addon-operator"].Version)
+}
@@ -0,0 +1,56 @@
+package render
+
+import (
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	cloudCredentialV1 = "cloudcredential.openshift.io/v1"
+	// CloudCredentialNamespace is the namespace of the
+	// CredentialsRequests created for addons.
+	CloudCredentialNamespace = "openshift-cloud-credential-operator"
+)
+
+// credentialsRequests returns a CredentialsRequest per entry of the
+// CredentialsRequests of the addon. The policy permissions are IAM
+// actions and are therefore rendered as an AWS provider spec.
+func credentialsRequests(meta *addonsv1alpha1.AddonMetadataSpec) []*unstructured.Unstructured {
+	if meta.CredentialsRequests == nil {
+		return nil
+	}
+
+	objs := make([]*unstructured.Unstructured, 0, len(*meta.CredentialsRequests))
+
+	for _, cr := range *meta.CredentialsRequests {
+		obj := newObject(cloudCredentialV1, "CredentialsRequest", CloudCredentialNamespace, cr.Name)
+
+		var actions []string
+		if cr.PolicyPermissions != nil {
+			actions = *cr.PolicyPermissions
+		}
+
+		obj.Object["spec"] = map[string]interface{}{
+			"secretRef": map[string]interface{}{
+				"name":      cr.Name,
+				"namespace": cr.Namespace,
+			},
+			"serviceAccountNames": stringList([]string{cr.ServiceAccount}),
+			"providerSpec": map[string]interface{}{
+				"apiVersion": cloudCredentialV1,
+				"kind":       "AWSProviderSpec",
+				"statementEntries": []interface{}{
+					map[string]interface{}{
+						"action":   stringList(actions),
+						"effect":   "Allow",
+						"resource": "*",
+					},
+				},
+			},
+		}
+
+		objs = append(objs, obj)
+	}
+
+	return objs
+}
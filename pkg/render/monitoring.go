@@ -0,0 +1,148 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	mtsrev1 "github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	federationCAFile    = "/etc/prometheus/configmaps/serving-certs-ca-bundle/service-ca.crt"
+	federationTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+// MonitoringNamespace returns the namespace holding the monitoring
+// objects of the addon with id 'addonID'.
+func MonitoringNamespace(addonID string) string {
+	return "redhat-monitoring-" + addonID
+}
+
+// monitoringObjects returns the objects created for the MetricsFederation
+// and MonitoringStack of the addon together with their namespace.
+func monitoringObjects(meta *addonsv1alpha1.AddonMetadataSpec) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+
+	if meta.MetricsFederation != nil {
+		objs = append(objs, serviceMonitor(meta.ID, meta.MetricsFederation))
+	}
+
+	if meta.MonitoringStack != nil && (meta.MonitoringStack.Enabled == nil || *meta.MonitoringStack.Enabled) {
+		stack, err := monitoringStack(meta.ID, meta.MonitoringStack)
+		if err != nil {
+			return nil, fmt.Errorf("rendering monitoringStack: %w", err)
+		}
+
+		objs = append(objs, stack)
+	}
+
+	if len(objs) == 0 {
+		return nil, nil
+	}
+
+	ns := newObject("v1", "Namespace", "", MonitoringNamespace(meta.ID))
+	ns.SetLabels(map[string]string{"openshift.io/cluster-monitoring": "true"})
+
+	return append([]*unstructured.Unstructured{ns}, objs...), nil
+}
+
+// serviceMonitor returns the ServiceMonitor federating the metrics
+// listed in 'federation' from the Prometheus server of the addon.
+func serviceMonitor(addonID string, federation *mtsrev1.MetricsFederation) *unstructured.Unstructured {
+	sm := newObject("monitoring.coreos.com/v1", "ServiceMonitor", MonitoringNamespace(addonID), "federated-sm-"+addonID)
+
+	match := make([]string, 0, len(federation.MatchNames))
+	for _, name := range federation.MatchNames {
+		match = append(match, fmt.Sprintf("{__name__=%q}", name))
+	}
+
+	sm.Object["spec"] = map[string]interface{}{
+		"endpoints": []interface{}{
+			map[string]interface{}{
+				"bearerTokenFile": federationTokenFile,
+				"honorLabels":     true,
+				"interval":        "30s",
+				"path":            "/federate",
+				"port":            federation.PortName,
+				"scheme":          "https",
+				"params": map[string]interface{}{
+					"match[]": stringList(match),
+				},
+				"tlsConfig": map[string]interface{}{
+					"caFile":     federationCAFile,
+					"serverName": "prometheus." + federation.Namespace + ".svc",
+				},
+			},
+		},
+		"namespaceSelector": map[string]interface{}{
+			"matchNames": stringList([]string{federation.Namespace}),
+		},
+		"selector": map[string]interface{}{
+			"matchLabels": stringMap(federation.MatchLabels),
+		},
+	}
+
+	return sm
+}
+
+func monitoringStack(addonID string, stack *mtsrev1.MonitoringStack) (*unstructured.Unstructured, error) {
+	ms := newObject("monitoring.rhobs/v1alpha1", "MonitoringStack", MonitoringNamespace(addonID), addonID+"-monitoring-stack")
+
+	spec := map[string]interface{}{}
+
+	if res := stack.Resources; res != nil {
+		resources := map[string]interface{}{}
+
+		for name, r := range map[string]*mtsrev1.MonitoringStackResource{
+			"requests": res.Request,
+			"limits":   res.Limits,
+		} {
+			if r == nil {
+				continue
+			}
+
+			quantities, err := resourceList(r)
+			if err != nil {
+				return nil, fmt.Errorf("parsing resources.%s: %w", name, err)
+			}
+
+			if len(quantities) > 0 {
+				resources[name] = quantities
+			}
+		}
+
+		if len(resources) > 0 {
+			spec["resources"] = resources
+		}
+	}
+
+	ms.Object["spec"] = spec
+
+	return ms, nil
+}
+
+// resourceList returns the canonical form of the quantities in 'r'.
+func resourceList(r *mtsrev1.MonitoringStackResource) (map[string]interface{}, error) {
+	res := map[string]interface{}{}
+
+	for name, value := range map[string]*string{
+		"cpu":    r.Cpu,
+		"memory": r.Memory,
+	} {
+		if value == nil {
+			continue
+		}
+
+		q, err := resource.ParseQuantity(strings.TrimSpace(*value))
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s %q: %w", name, *value, err)
+		}
+
+		res[name] = q.String()
+	}
+
+	return res, nil
+}
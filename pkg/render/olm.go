@@ -0,0 +1,117 @@
+package render
+
+import (
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	operatorsV1       = "operators.coreos.com/v1"
+	operatorsV1alpha1 = "operators.coreos.com/v1alpha1"
+	// OperatorGroupName is the name of the OperatorGroup
+	// created in the target namespace of every addon.
+	OperatorGroupName = "redhat-layered-product-og"
+	catalogPublisher  = "OSD Red Hat Addons"
+)
+
+// CatalogSourceName returns the name of the CatalogSource
+// serving the index image of the addon with id 'addonID'.
+func CatalogSourceName(addonID string) string {
+	return "addon-" + addonID + "-catalog"
+}
+
+// SubscriptionName returns the name of the Subscription
+// to the operator of the addon with id 'addonID'.
+func SubscriptionName(addonID string) string {
+	return "addon-" + addonID
+}
+
+func operatorGroup(meta *addonsv1alpha1.AddonMetadataSpec) *unstructured.Unstructured {
+	og := newObject(operatorsV1, "OperatorGroup", meta.TargetNamespace, OperatorGroupName)
+
+	spec := map[string]interface{}{}
+
+	// an OperatorGroup without target namespaces selects all namespaces
+	if meta.InstallMode == "OwnNamespace" {
+		spec["targetNamespaces"] = stringList([]string{meta.TargetNamespace})
+	}
+
+	og.Object["spec"] = spec
+
+	return og
+}
+
+func catalogSource(meta *addonsv1alpha1.AddonMetadataSpec) *unstructured.Unstructured {
+	var secrets []string
+	if meta.PullSecretName != "" {
+		secrets = append(secrets, meta.PullSecretName)
+	}
+
+	return newCatalogSource(meta, CatalogSourceName(meta.ID), *meta.IndexImage, secrets...)
+}
+
+func additionalCatalogSources(meta *addonsv1alpha1.AddonMetadataSpec) []*unstructured.Unstructured {
+	if meta.AdditionalCatalogSources == nil {
+		return nil
+	}
+
+	objs := make([]*unstructured.Unstructured, 0, len(*meta.AdditionalCatalogSources))
+
+	for _, src := range *meta.AdditionalCatalogSources {
+		objs = append(objs, newCatalogSource(meta, src.Name, src.Image))
+	}
+
+	return objs
+}
+
+func newCatalogSource(meta *addonsv1alpha1.AddonMetadataSpec, name, image string, secrets ...string) *unstructured.Unstructured {
+	cs := newObject(operatorsV1alpha1, "CatalogSource", meta.TargetNamespace, name)
+
+	spec := map[string]interface{}{
+		"sourceType":  "grpc",
+		"image":       image,
+		"displayName": name,
+		"publisher":   catalogPublisher,
+	}
+
+	if len(secrets) > 0 {
+		spec["secrets"] = stringList(secrets)
+	}
+
+	cs.Object["spec"] = spec
+
+	return cs
+}
+
+func subscription(meta *addonsv1alpha1.AddonMetadataSpec) *unstructured.Unstructured {
+	sub := newObject(operatorsV1alpha1, "Subscription", meta.TargetNamespace, SubscriptionName(meta.ID))
+
+	spec := map[string]interface{}{
+		"name":                meta.OperatorName,
+		"channel":             meta.DefaultChannel,
+		"source":              CatalogSourceName(meta.ID),
+		"sourceNamespace":     meta.TargetNamespace,
+		"installPlanApproval": "Automatic",
+	}
+
+	if meta.StartingCSV != nil {
+		spec["startingCSV"] = *meta.StartingCSV
+	}
+
+	if meta.Config != nil && meta.Config.Env != nil && len(*meta.Config.Env) > 0 {
+		env := make([]interface{}, 0, len(*meta.Config.Env))
+
+		for _, item := range *meta.Config.Env {
+			env = append(env, map[string]interface{}{
+				"name":  item.Name,
+				"value": item.Value,
+			})
+		}
+
+		spec["config"] = map[string]interface{}{"env": env}
+	}
+
+	sub.Object["spec"] = spec
+
+	return sub
+}
@@ -0,0 +1,162 @@
+// Package render renders the Kubernetes objects which the platform
+// creates on clusters for an addon based on its metadata. The output
+// is meant for review and for comparing versions of an addon and is
+// not guaranteed to match the objects created by OCM and Hive byte
+// for byte.
+package render
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// Render returns the objects created for the addon described by 'meta'
+// in the order they are applied. The CommonLabels and CommonAnnotations
+// of the addon are applied to all objects without overriding the labels
+// and annotations set on the individual objects.
+func Render(meta *addonsv1alpha1.AddonMetadataSpec) ([]*unstructured.Unstructured, error) {
+	if meta.IndexImage == nil {
+		return nil, errors.New("addon metadata does not reference an index image")
+	}
+
+	objs := namespaces(meta)
+	objs = append(objs, operatorGroup(meta), catalogSource(meta))
+	objs = append(objs, additionalCatalogSources(meta)...)
+	objs = append(objs, subscription(meta))
+
+	monitoring, err := monitoringObjects(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	objs = append(objs, monitoring...)
+	objs = append(objs, credentialsRequests(meta)...)
+
+	for _, obj := range objs {
+		if meta.CommonLabels != nil {
+			obj.SetLabels(merge(*meta.CommonLabels, obj.GetLabels()))
+		}
+
+		if meta.CommonAnnotations != nil {
+			obj.SetAnnotations(merge(*meta.CommonAnnotations, obj.GetAnnotations()))
+		}
+	}
+
+	return objs, nil
+}
+
+// Write writes 'objs' to 'w' as a stream of YAML documents.
+func Write(w io.Writer, objs []*unstructured.Unstructured) error {
+	for i, obj := range objs {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return fmt.Errorf("encoding %s %q: %w", obj.GetKind(), obj.GetName(), err)
+		}
+
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func namespaces(meta *addonsv1alpha1.AddonMetadataSpec) []*unstructured.Unstructured {
+	names := append([]string{}, meta.Namespaces...)
+	if !contains(names, meta.TargetNamespace) {
+		names = append(names, meta.TargetNamespace)
+	}
+
+	var (
+		objs []*unstructured.Unstructured
+		seen = make(map[string]bool)
+	)
+
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+
+		seen[name] = true
+
+		ns := newObject("v1", "Namespace", "", name)
+		ns.SetLabels(merge(meta.NamespaceLabels))
+		ns.SetAnnotations(merge(meta.NamespaceAnnotations))
+
+		objs = append(objs, ns)
+	}
+
+	return objs
+}
+
+func newObject(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+
+	return obj
+}
+
+// merge returns the union of 'maps' where later maps take
+// precedence or nil if the union is empty.
+func merge(maps ...map[string]string) map[string]string {
+	var res map[string]string
+
+	for _, m := range maps {
+		for k, v := range m {
+			if res == nil {
+				res = make(map[string]string)
+			}
+
+			res[k] = v
+		}
+	}
+
+	return res
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stringList converts 'list' into a list which can be stored
+// in the unstructured content of an object.
+func stringList(list []string) []interface{} {
+	res := make([]interface{}, 0, len(list))
+
+	for _, s := range list {
+		res = append(res, s)
+	}
+
+	return res
+}
+
+func stringMap(m map[string]string) map[string]interface{} {
+	res := make(map[string]interface{}, len(m))
+
+	for k, v := range m {
+		res[k] = v
+	}
+
+	return res
+}
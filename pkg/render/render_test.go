@@ -0,0 +1,357 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	mtsrev1 "github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Meta     func(*addonsv1alpha1.AddonMetadataSpec)
+		Expected []string
+		Check    func(t *testing.T, objs []*unstructured.Unstructured)
+	}{
+		"minimal": {
+			Expected: []string{
+				"Namespace//redhat-reference",
+				"OperatorGroup/redhat-reference/redhat-layered-product-og",
+				"CatalogSource/redhat-reference/addon-reference-catalog",
+				"Subscription/redhat-reference/addon-reference",
+			},
+			Check: func(t *testing.T, objs []*unstructured.Unstructured) {
+				og := find(t, objs, "OperatorGroup", OperatorGroupName)
+				assertNoField(t, og, "spec", "targetNamespaces")
+
+				cs := find(t, objs, "CatalogSource", "addon-reference-catalog")
+				assertField(t, cs, "quay.io/osd-addons/reference-addon-index@sha256:abc", "spec", "image")
+				assertNoField(t, cs, "spec", "secrets")
+
+				sub := find(t, objs, "Subscription", "addon-reference")
+				assertField(t, sub, "reference-addon", "spec", "name")
+				assertField(t, sub, "alpha", "spec", "channel")
+				assertField(t, sub, "addon-reference-catalog", "spec", "source")
+				assertField(t, sub, "redhat-reference", "spec", "sourceNamespace")
+				assertNoField(t, sub, "spec", "config")
+			},
+		},
+		"namespaces": {
+			Meta: func(meta *addonsv1alpha1.AddonMetadataSpec) {
+				meta.Namespaces = []string{"redhat-reference-extra", "redhat-reference", "redhat-reference-extra"}
+				meta.NamespaceLabels = map[string]string{"monitoring-key": "reference"}
+				meta.NamespaceAnnotations = map[string]string{"owner": "mt-sre"}
+			},
+			Expected: []string{
+				"Namespace//redhat-reference-extra",
+				"Namespace//redhat-reference",
+				"OperatorGroup/redhat-reference/redhat-layered-product-og",
+				"CatalogSource/redhat-reference/addon-reference-catalog",
+				"Subscription/redhat-reference/addon-reference",
+			},
+			Check: func(t *testing.T, objs []*unstructured.Unstructured) {
+				for _, name := range []string{"redhat-reference", "redhat-reference-extra"} {
+					ns := find(t, objs, "Namespace", name)
+					assert.Equal(t, map[string]string{"monitoring-key": "reference"}, ns.GetLabels())
+					assert.Equal(t, map[string]string{"owner": "mt-sre"}, ns.GetAnnotations())
+				}
+			},
+		},
+		"own namespace install mode": {
+			Meta: func(meta *addonsv1alpha1.AddonMetadataSpec) {
+				meta.InstallMode = "OwnNamespace"
+			},
+			Check: func(t *testing.T, objs []*unstructured.Unstructured) {
+				og := find(t, objs, "OperatorGroup", OperatorGroupName)
+				assertField(t, og, []interface{}{"redhat-reference"}, "spec", "targetNamespaces")
+			},
+		},
+		"catalog sources": {
+			Meta: func(meta *addonsv1alpha1.AddonMetadataSpec) {
+				meta.PullSecretName = "reference-pull-secret"
+				meta.AdditionalCatalogSources = &[]mtsrev1.AdditionalCatalogSource{
+					{Name: "dependency-catalog", Image: "quay.io/osd-addons/dependency-index:v1"},
+				}
+			},
+			Expected: []string{
+				"Namespace//redhat-reference",
+				"OperatorGroup/redhat-reference/redhat-layered-product-og",
+				"CatalogSource/redhat-reference/addon-reference-catalog",
+				"CatalogSource/redhat-reference/dependency-catalog",
+				"Subscription/redhat-reference/addon-reference",
+			},
+			Check: func(t *testing.T, objs []*unstructured.Unstructured) {
+				cs := find(t, objs, "CatalogSource", "addon-reference-catalog")
+				assertField(t, cs, []interface{}{"reference-pull-secret"}, "spec", "secrets")
+
+				dep := find(t, objs, "CatalogSource", "dependency-catalog")
+				assertField(t, dep, "quay.io/osd-addons/dependency-index:v1", "spec", "image")
+				assertNoField(t, dep, "spec", "secrets")
+			},
+		},
+		"subscription config": {
+			Meta: func(meta *addonsv1alpha1.AddonMetadataSpec) {
+				meta.StartingCSV = stringPtr("reference-addon.v0.1.0")
+				meta.Config = &mtsrev1.Config{
+					Env: &[]mtsrev1.EnvItem{{Name: "LOG_LEVEL", Value: "debug"}},
+				}
+			},
+			Check: func(t *testing.T, objs []*unstructured.Unstructured) {
+				sub := find(t, objs, "Subscription", "addon-reference")
+				assertField(t, sub, "reference-addon.v0.1.0", "spec", "startingCSV")
+				assertField(t, sub, []interface{}{
+					map[string]interface{}{"name": "LOG_LEVEL", "value": "debug"},
+				}, "spec", "config", "env")
+			},
+		},
+		"monitoring": {
+			Meta: func(meta *addonsv1alpha1.AddonMetadataSpec) {
+				meta.MetricsFederation = &mtsrev1.MetricsFederation{
+					Namespace:   "redhat-reference",
+					PortName:    "https",
+					MatchNames:  []string{"reference_up"},
+					MatchLabels: map[string]string{"app": "reference"},
+				}
+				meta.MonitoringStack = &mtsrev1.MonitoringStack{
+					Enabled: boolPtr(true),
+					Resources: &mtsrev1.MonitoringStackResources{
+						Request: &mtsrev1.MonitoringStackResource{Cpu: stringPtr("0.5"), Memory: stringPtr("1Gi")},
+					},
+				}
+			},
+			Expected: []string{
+				"Namespace//redhat-reference",
+				"OperatorGroup/redhat-reference/redhat-layered-product-og",
+				"CatalogSource/redhat-reference/addon-reference-catalog",
+				"Subscription/redhat-reference/addon-reference",
+				"Namespace//redhat-monitoring-reference",
+				"ServiceMonitor/redhat-monitoring-reference/federated-sm-reference",
+				"MonitoringStack/redhat-monitoring-reference/reference-monitoring-stack",
+			},
+			Check: func(t *testing.T, objs []*unstructured.Unstructured) {
+				sm := find(t, objs, "ServiceMonitor", "federated-sm-reference")
+				assertField(t, sm, []interface{}{"redhat-reference"}, "spec", "namespaceSelector", "matchNames")
+				assertField(t, sm, map[string]interface{}{"app": "reference"}, "spec", "selector", "matchLabels")
+
+				endpoints, _, err := unstructured.NestedSlice(sm.Object, "spec", "endpoints")
+				require.NoError(t, err)
+				require.Len(t, endpoints, 1)
+
+				endpoint := endpoints[0].(map[string]interface{})
+				assert.Equal(t, "https", endpoint["port"])
+				assert.Equal(t, map[string]interface{}{
+					"match[]": []interface{}{`{__name__="reference_up"}`},
+				}, endpoint["params"])
+
+				ms := find(t, objs, "MonitoringStack", "reference-monitoring-stack")
+				assertField(t, ms, map[string]interface{}{
+					"requests": map[string]interface{}{"cpu": "500m", "memory": "1Gi"},
+				}, "spec", "resources")
+			},
+		},
+		"disabled monitoring stack": {
+			Meta: func(meta *addonsv1alpha1.AddonMetadataSpec) {
+				meta.MonitoringStack = &mtsrev1.MonitoringStack{Enabled: boolPtr(false)}
+			},
+			Expected: []string{
+				"Namespace//redhat-reference",
+				"OperatorGroup/redhat-reference/redhat-layered-product-og",
+				"CatalogSource/redhat-reference/addon-reference-catalog",
+				"Subscription/redhat-reference/addon-reference",
+			},
+		},
+		"credentials requests": {
+			Meta: func(meta *addonsv1alpha1.AddonMetadataSpec) {
+				meta.CredentialsRequests = &[]mtsrev1.CredentialsRequest{
+					{
+						Name:              "reference-aws",
+						Namespace:         "redhat-reference",
+						ServiceAccount:    "reference-operator",
+						PolicyPermissions: &[]string{"s3:GetObject"},
+					},
+				}
+			},
+			Check: func(t *testing.T, objs []*unstructured.Unstructured) {
+				cr := find(t, objs, "CredentialsRequest", "reference-aws")
+				assert.Equal(t, CloudCredentialNamespace, cr.GetNamespace())
+				assertField(t, cr, "redhat-reference", "spec", "secretRef", "namespace")
+				assertField(t, cr, []interface{}{"reference-operator"}, "spec", "serviceAccountNames")
+				assertField(t, cr, []interface{}{
+					map[string]interface{}{
+						"action":   []interface{}{"s3:GetObject"},
+						"effect":   "Allow",
+						"resource": "*",
+					},
+				}, "spec", "providerSpec", "statementEntries")
+			},
+		},
+		"common labels and annotations": {
+			Meta: func(meta *addonsv1alpha1.AddonMetadataSpec) {
+				meta.NamespaceLabels = map[string]string{"tier": "namespace"}
+				meta.CommonLabels = &map[string]string{"tier": "common", "team": "mt-sre"}
+				meta.CommonAnnotations = &map[string]string{"owner": "mt-sre"}
+			},
+			Check: func(t *testing.T, objs []*unstructured.Unstructured) {
+				for _, obj := range objs {
+					assert.Equal(t, "mt-sre", obj.GetLabels()["team"], obj.GetKind())
+					assert.Equal(t, "mt-sre", obj.GetAnnotations()["owner"], obj.GetKind())
+				}
+
+				ns := find(t, objs, "Namespace", "redhat-reference")
+				assert.Equal(t, "namespace", ns.GetLabels()["tier"])
+
+				sub := find(t, objs, "Subscription", "addon-reference")
+				assert.Equal(t, "common", sub.GetLabels()["tier"])
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			meta := referenceMeta()
+			if tc.Meta != nil {
+				tc.Meta(meta)
+			}
+
+			objs, err := Render(meta)
+			require.NoError(t, err)
+
+			if tc.Expected != nil {
+				assert.Equal(t, tc.Expected, ids(objs))
+			}
+
+			if tc.Check != nil {
+				tc.Check(t, objs)
+			}
+		})
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Meta     func(*addonsv1alpha1.AddonMetadataSpec)
+		Expected string
+	}{
+		"missing index image": {
+			Meta: func(meta *addonsv1alpha1.AddonMetadataSpec) {
+				meta.IndexImage = nil
+			},
+			Expected: "does not reference an index image",
+		},
+		"invalid monitoring stack resources": {
+			Meta: func(meta *addonsv1alpha1.AddonMetadataSpec) {
+				meta.MonitoringStack = &mtsrev1.MonitoringStack{
+					Resources: &mtsrev1.MonitoringStackResources{
+						Limits: &mtsrev1.MonitoringStackResource{Memory: stringPtr("lots")},
+					},
+				}
+			},
+			Expected: `parsing resources.limits: parsing memory "lots"`,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			meta := referenceMeta()
+			tc.Meta(meta)
+
+			_, err := Render(meta)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.Expected)
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	objs, err := Render(referenceMeta())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, Write(&buf, objs))
+
+	docs := strings.Split(buf.String(), "---\n")
+	require.Len(t, docs, len(objs))
+
+	for i, doc := range docs {
+		var obj unstructured.Unstructured
+
+		require.NoError(t, yaml.Unmarshal([]byte(doc), &obj.Object))
+		assert.Equal(t, objs[i], &obj)
+	}
+}
+
+func referenceMeta() *addonsv1alpha1.AddonMetadataSpec {
+	return &addonsv1alpha1.AddonMetadataSpec{
+		ID:              "reference",
+		Name:            "Reference Addon",
+		InstallMode:     "AllNamespaces",
+		TargetNamespace: "redhat-reference",
+		Namespaces:      []string{"redhat-reference"},
+		OperatorName:    "reference-addon",
+		DefaultChannel:  "alpha",
+		IndexImage:      stringPtr("quay.io/osd-addons/reference-addon-index@sha256:abc"),
+	}
+}
+
+// ids returns the objects as '<kind>/<namespace>/<name>'.
+func ids(objs []*unstructured.Unstructured) []string {
+	res := make([]string, 0, len(objs))
+
+	for _, obj := range objs {
+		res = append(res, obj.GetKind()+"/"+obj.GetNamespace()+"/"+obj.GetName())
+	}
+
+	return res
+}
+
+func find(t *testing.T, objs []*unstructured.Unstructured, kind, name string) *unstructured.Unstructured {
+	t.Helper()
+
+	for _, obj := range objs {
+		if obj.GetKind() == kind && obj.GetName() == name {
+			return obj
+		}
+	}
+
+	require.Failf(t, "object not found", "%s %q", kind, name)
+
+	return nil
+}
+
+func assertField(t *testing.T, obj *unstructured.Unstructured, expected interface{}, fields ...string) {
+	t.Helper()
+
+	actual, found, err := unstructured.NestedFieldNoCopy(obj.Object, fields...)
+	require.NoError(t, err)
+	require.True(t, found, "field %s of %s %q", strings.Join(fields, "."), obj.GetKind(), obj.GetName())
+	assert.Equal(t, expected, actual)
+}
+
+func assertNoField(t *testing.T, obj *unstructured.Unstructured, fields ...string) {
+	t.Helper()
+
+	_, found, err := unstructured.NestedFieldNoCopy(obj.Object, fields...)
+	require.NoError(t, err)
+	assert.False(t, found, "field %s of %s %q", strings.Join(fields, "."), obj.GetKind(), obj.GetName())
+}
+
+func stringPtr(s string) *string { return &s }
+
+func boolPtr(b bool) *bool { return &b }
@@ -0,0 +1,265 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+)
+
+// Kind identifies a metadata file for which a schema can be generated.
+type Kind string
+
+const (
+	// KindAddon is the 'addon.yaml' file of an addon.
+	KindAddon Kind = "addon"
+	// KindImageSet is a versioned imageset file of an addon.
+	KindImageSet Kind = "imageset"
+)
+
+// Kinds lists every Kind in a stable order.
+var Kinds = []Kind{KindAddon, KindImageSet}
+
+func (k Kind) rootType() (reflect.Type, error) {
+	switch k {
+	case KindAddon:
+		return reflect.TypeOf(addonsv1alpha1.AddonMetadataSpec{}), nil
+	case KindImageSet:
+		return reflect.TypeOf(addonsv1alpha1.AddonImageSetSpec{}), nil
+	default:
+		return nil, fmt.Errorf("unknown kind %q", k)
+	}
+}
+
+// Format is the dialect of a generated schema.
+type Format string
+
+const (
+	// FormatJSONSchema emits a JSON Schema (draft-07) document
+	// as understood by yaml-language-server.
+	FormatJSONSchema Format = "jsonschema"
+	// FormatOpenAPI emits an OpenAPI v3 document holding the
+	// schema as its only component.
+	FormatOpenAPI Format = "openapi"
+)
+
+// Formats lists every Format in a stable order.
+var Formats = []Format{FormatJSONSchema, FormatOpenAPI}
+
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// Node is a single (sub)schema. Only the subset of keywords shared
+// by JSON Schema and OpenAPI v3 is used so that the same tree can be
+// rendered in either format.
+type Node struct {
+	Schema                string           `json:"$schema,omitempty"`
+	Title                 string           `json:"title,omitempty"`
+	Description           string           `json:"description,omitempty"`
+	Type                  string           `json:"type,omitempty"`
+	Properties            map[string]*Node `json:"properties,omitempty"`
+	Required              []string         `json:"required,omitempty"`
+	Items                 *Node            `json:"items,omitempty"`
+	AdditionalProperties  *Node            `json:"additionalProperties,omitempty"`
+	Pattern               string           `json:"pattern,omitempty"`
+	Enum                  []string         `json:"enum,omitempty"`
+	Minimum               *float64         `json:"minimum,omitempty"`
+	Maximum               *float64         `json:"maximum,omitempty"`
+	MinLength             *int             `json:"minLength,omitempty"`
+	MaxLength             *int             `json:"maxLength,omitempty"`
+	MinItems              *int             `json:"minItems,omitempty"`
+	MaxItems              *int             `json:"maxItems,omitempty"`
+	PreserveUnknownFields bool             `json:"x-kubernetes-preserve-unknown-fields,omitempty"`
+}
+
+// Generate returns the schema of the metadata file 'kind' rendered
+// in 'format' as indented JSON terminated by a newline.
+func Generate(kind Kind, format Format) ([]byte, error) {
+	t, err := kind.rootType()
+	if err != nil {
+		return nil, err
+	}
+
+	root := newBuilder(format).build(t, Markers{})
+	root.Title = t.Name()
+
+	var doc interface{}
+
+	switch format {
+	case FormatJSONSchema:
+		root.Schema = jsonSchemaDraft
+		doc = root
+	case FormatOpenAPI:
+		doc = openAPIDocument{
+			OpenAPI: "3.0.3",
+			Info: openAPIInfo{
+				Title:   t.Name(),
+				Version: addonsv1alpha1.GroupVersion.Version,
+			},
+			Paths: map[string]interface{}{},
+			Components: openAPIComponents{
+				Schemas: map[string]*Node{t.Name(): root},
+			},
+		}
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("encoding schema: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+type openAPIDocument struct {
+	OpenAPI    string                 `json:"openapi"`
+	Info       openAPIInfo            `json:"info"`
+	Paths      map[string]interface{} `json:"paths"`
+	Components openAPIComponents      `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*Node `json:"schemas"`
+}
+
+type builder struct {
+	format Format
+	// visiting holds the struct types currently being expanded
+	// so that recursive types terminate.
+	visiting map[reflect.Type]bool
+}
+
+func newBuilder(format Format) *builder {
+	return &builder{
+		format:   format,
+		visiting: make(map[reflect.Type]bool),
+	}
+}
+
+var marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// build returns the schema of type 't' held by a field with markers 'm'.
+func (b *builder) build(t reflect.Type, m Markers) *Node {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	m = merge(m, t)
+
+	if m.Description == "" {
+		if tm, ok := TypeMarkers(t); ok {
+			m.Description = tm.Description
+		}
+	}
+
+	node := &Node{Description: m.Description}
+
+	// Types with a custom JSON encoding such as 'apiextensionsv1.JSON'
+	// may hold arbitrary values.
+	if t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType) {
+		node.PreserveUnknownFields = b.format == FormatOpenAPI
+
+		return node
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		b.buildStruct(node, t)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			node.Type = "string"
+
+			break
+		}
+
+		node.Type = "array"
+		node.Items = b.build(t.Elem(), itemMarkers(m))
+		node.MinItems = m.MinItems
+		node.MaxItems = m.MaxItems
+	case reflect.Map:
+		node.Type = "object"
+		node.AdditionalProperties = b.build(t.Elem(), itemMarkers(m))
+	case reflect.String:
+		node.Type = "string"
+		node.Pattern = m.Pattern
+		node.Enum = m.Enum
+		node.MinLength = m.MinLength
+		node.MaxLength = m.MaxLength
+	case reflect.Bool:
+		node.Type = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		node.Type = "integer"
+		node.Minimum = m.Minimum
+		node.Maximum = m.Maximum
+	case reflect.Float32, reflect.Float64:
+		node.Type = "number"
+		node.Minimum = m.Minimum
+		node.Maximum = m.Maximum
+	}
+
+	return node
+}
+
+func (b *builder) buildStruct(node *Node, t reflect.Type) {
+	node.Type = "object"
+
+	if b.visiting[t] {
+		return
+	}
+
+	b.visiting[t] = true
+	defer delete(b.visiting, t)
+
+	node.Properties = make(map[string]*Node)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+
+		m, _ := FieldMarkers(t, field.Name)
+
+		if tag := field.Tag.Get("validate"); hasRule(tag, "required") {
+			m.Required = true
+		}
+
+		if field.Anonymous && name == "" {
+			embedded := b.build(field.Type, m)
+
+			for n, prop := range embedded.Properties {
+				node.Properties[n] = prop
+			}
+
+			node.Required = append(node.Required, embedded.Required...)
+
+			continue
+		}
+
+		node.Properties[name] = b.build(field.Type, m)
+
+		if m.Required {
+			node.Required = append(node.Required, name)
+		}
+	}
+}
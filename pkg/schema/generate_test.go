@@ -0,0 +1,124 @@
+package schema
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the published schemas in docs/schemas")
+
+const publishedSchemaDir = "../../docs/schemas"
+
+func publishedSchemaFile(kind Kind, format Format) string {
+	ext := "schema.json"
+	if format == FormatOpenAPI {
+		ext = "openapi.json"
+	}
+
+	return filepath.Join(publishedSchemaDir, fmt.Sprintf("%s.%s", kind, ext))
+}
+
+func TestPublishedSchemasUpToDate(t *testing.T) {
+	t.Parallel()
+
+	for _, kind := range Kinds {
+		for _, format := range Formats {
+			kind, format := kind, format
+
+			t.Run(fmt.Sprintf("%s/%s", kind, format), func(t *testing.T) {
+				t.Parallel()
+
+				expected, err := Generate(kind, format)
+				require.NoError(t, err)
+
+				path := publishedSchemaFile(kind, format)
+
+				if *update {
+					require.NoError(t, os.WriteFile(path, expected, 0o644))
+				}
+
+				actual, err := os.ReadFile(path)
+				require.NoError(t, err)
+
+				assert.Equal(t, string(expected), string(actual),
+					"%s is out of date; run 'go test ./pkg/schema -run TestPublishedSchemasUpToDate -update'", path,
+				)
+			})
+		}
+	}
+}
+
+func TestGenerateJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	data, err := Generate(KindAddon, FormatJSONSchema)
+	require.NoError(t, err)
+
+	var root Node
+	require.NoError(t, json.Unmarshal(data, &root))
+
+	assert.Equal(t, jsonSchemaDraft, root.Schema)
+	assert.Equal(t, "AddonMetadataSpec", root.Title)
+	assert.Equal(t, "object", root.Type)
+	assert.Contains(t, root.Required, "id")
+	assert.NotContains(t, root.Required, "indexImage")
+
+	installMode := root.Properties["installMode"]
+	require.NotNil(t, installMode)
+	assert.Equal(t, []string{"AllNamespaces", "OwnNamespace"}, installMode.Enum)
+
+	quayRepo := root.Properties["quayRepo"]
+	require.NotNil(t, quayRepo)
+	assert.Equal(t, `^quay\.io/osd-addons/[a-z-]+$`, quayRepo.Pattern)
+
+	params := root.Properties["addOnParameters"]
+	require.NotNil(t, params)
+	assert.Equal(t, "array", params.Type)
+	require.NotNil(t, params.Items)
+	assert.Contains(t, params.Items.Required, "id")
+
+	labels := root.Properties["namespaceLabels"]
+	require.NotNil(t, labels)
+	assert.Equal(t, "object", labels.Type)
+	require.NotNil(t, labels.AdditionalProperties)
+	assert.Equal(t, "string", labels.AdditionalProperties.Type)
+}
+
+func TestGenerateOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	data, err := Generate(KindImageSet, FormatOpenAPI)
+	require.NoError(t, err)
+
+	var doc struct {
+		OpenAPI    string `json:"openapi"`
+		Components struct {
+			Schemas map[string]Node `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+
+	root, ok := doc.Components.Schemas["AddonImageSetSpec"]
+	require.True(t, ok)
+	assert.Empty(t, root.Schema)
+	assert.Contains(t, root.Required, "indexImage")
+}
+
+func TestGenerateUnknown(t *testing.T) {
+	t.Parallel()
+
+	_, err := Generate("cluster", FormatJSONSchema)
+	assert.Error(t, err)
+
+	_, err = Generate(KindAddon, "protobuf")
+	assert.Error(t, err)
+}
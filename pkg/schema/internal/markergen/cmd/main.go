@@ -0,0 +1,52 @@
+// Command markergen writes the kubebuilder validation markers of the
+// addon metadata types to a Go source file. It is invoked through
+// 'go generate' in the 'pkg/schema' directory.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/schema/internal/markergen"
+)
+
+func main() {
+	var (
+		root    = flag.String("root", ".", "module root directory")
+		out     = flag.String("o", "zz_generated.markers.go", "output file")
+		pkgName = flag.String("package", "schema", "package name of the output file")
+		varName = flag.String("var", "generatedMarkers", "variable name of the generated markers")
+	)
+
+	flag.Parse()
+
+	if err := run(*root, *out, *pkgName, *varName, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		os.Exit(1)
+	}
+}
+
+func run(root, out, pkgName, varName string, dirs []string) error {
+	pkgs, err := markergen.ModulePackages(root, dirs...)
+	if err != nil {
+		return fmt.Errorf("resolving packages: %w", err)
+	}
+
+	markers, err := markergen.Extract(pkgs...)
+	if err != nil {
+		return err
+	}
+
+	src, err := markergen.Render(pkgName, varName, markers)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(out, src, 0o644); err != nil {
+		return fmt.Errorf("writing %q: %w", out, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,400 @@
+// Package markergen extracts kubebuilder validation markers and doc
+// comments from Go type declarations and renders them as a Go source
+// file so that they are available at runtime.
+package markergen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Package is a Go package to extract markers from.
+type Package struct {
+	// ImportPath is the import path of the package.
+	ImportPath string
+	// Dir is the directory containing the package's source files.
+	Dir string
+}
+
+// Markers holds the markers and description of a type or struct field.
+type Markers struct {
+	Required    bool
+	Optional    bool
+	Pattern     string
+	Enum        []string
+	Minimum     *float64
+	Maximum     *float64
+	MinLength   *int
+	MaxLength   *int
+	MinItems    *int
+	MaxItems    *int
+	Default     string
+	Description string
+}
+
+func (m Markers) isZero() bool {
+	return !m.Required && !m.Optional && m.Pattern == "" && len(m.Enum) == 0 &&
+		m.Minimum == nil && m.Maximum == nil &&
+		m.MinLength == nil && m.MaxLength == nil &&
+		m.MinItems == nil && m.MaxItems == nil &&
+		m.Default == "" && m.Description == ""
+}
+
+// Extract parses the non-test, non-generated sources of the given
+// packages and returns the markers of every type and struct field keyed
+// by '<import path>.<type>' and '<import path>.<type>.<field>'.
+func Extract(pkgs ...Package) (map[string]Markers, error) {
+	res := make(map[string]Markers)
+
+	for _, pkg := range pkgs {
+		if err := extractPackage(res, pkg); err != nil {
+			return nil, fmt.Errorf("extracting markers from %q: %w", pkg.ImportPath, err)
+		}
+	}
+
+	return res, nil
+}
+
+func extractPackage(res map[string]Markers, pkg Package) error {
+	fset := token.NewFileSet()
+
+	filter := func(info fs.FileInfo) bool {
+		name := info.Name()
+
+		return !strings.HasSuffix(name, "_test.go") && !strings.HasPrefix(name, "zz_generated")
+	}
+
+	parsed, err := parser.ParseDir(fset, pkg.Dir, filter, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("parsing %q: %w", pkg.Dir, err)
+	}
+
+	for _, p := range parsed {
+		for _, file := range p.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+
+					doc := ts.Doc
+					if doc == nil && len(gen.Specs) == 1 {
+						doc = gen.Doc
+					}
+
+					typeKey := pkg.ImportPath + "." + ts.Name.Name
+
+					m, err := parseMarkers(doc)
+					if err != nil {
+						return fmt.Errorf("type %s: %w", ts.Name.Name, err)
+					}
+
+					if !m.isZero() {
+						res[typeKey] = m
+					}
+
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+
+					for _, field := range st.Fields.List {
+						m, err := parseMarkers(field.Doc)
+						if err != nil {
+							return fmt.Errorf("type %s: %w", ts.Name.Name, err)
+						}
+
+						if m.isZero() {
+							continue
+						}
+
+						for _, name := range field.Names {
+							res[typeKey+"."+name.Name] = m
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+const (
+	validationPrefix = "+kubebuilder:validation:"
+	defaultPrefix    = "+kubebuilder:default"
+)
+
+func parseMarkers(doc *ast.CommentGroup) (Markers, error) {
+	var (
+		m    Markers
+		desc []string
+	)
+
+	if doc == nil {
+		return m, nil
+	}
+
+	for _, c := range doc.List {
+		line := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+
+		if !strings.HasPrefix(line, "+") {
+			if line != "" && !strings.HasPrefix(line, "/*") {
+				desc = append(desc, line)
+			}
+
+			continue
+		}
+
+		if line == "+optional" {
+			m.Optional = true
+
+			continue
+		}
+
+		// both '+kubebuilder:default=<value>' and the older
+		// '+kubebuilder:default:<value>' syntax are in use
+		if value, ok := strings.CutPrefix(line, defaultPrefix); ok {
+			m.Default = strings.TrimPrefix(strings.TrimPrefix(value, ":"), "=")
+
+			continue
+		}
+
+		if !strings.HasPrefix(line, validationPrefix) {
+			continue
+		}
+
+		if err := m.parseValidation(strings.TrimPrefix(line, validationPrefix)); err != nil {
+			return Markers{}, fmt.Errorf("parsing marker %q: %w", line, err)
+		}
+	}
+
+	m.Description = strings.Join(desc, " ")
+
+	return m, nil
+}
+
+func (m *Markers) parseValidation(marker string) error {
+	name, value, _ := strings.Cut(marker, "=")
+
+	var err error
+
+	switch name {
+	case "Required":
+		m.Required = true
+	case "Optional":
+		m.Optional = true
+	case "Pattern":
+		m.Pattern, err = unquote(value)
+	case "Enum":
+		m.Enum = parseList(value)
+	case "Minimum":
+		m.Minimum, err = parseFloat(value)
+	case "Maximum":
+		m.Maximum, err = parseFloat(value)
+	case "MinLength":
+		m.MinLength, err = parseInt(value)
+	case "MaxLength":
+		m.MaxLength, err = parseInt(value)
+	case "MinItems":
+		m.MinItems, err = parseInt(value)
+	case "MaxItems":
+		m.MaxItems, err = parseInt(value)
+	}
+
+	return err
+}
+
+func unquote(value string) (string, error) {
+	if strings.HasPrefix(value, "`") || strings.HasPrefix(value, `"`) {
+		return strconv.Unquote(value)
+	}
+
+	return value, nil
+}
+
+// parseList supports both the '{a,b}' and 'a;b' list syntax.
+func parseList(value string) []string {
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "{"), "}")
+
+	sep := ";"
+	if strings.Contains(value, ",") {
+		sep = ","
+	}
+
+	var res []string
+
+	for _, item := range strings.Split(value, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			if unquoted, err := strconv.Unquote(item); err == nil {
+				item = unquoted
+			}
+
+			res = append(res, item)
+		}
+	}
+
+	return res
+}
+
+func parseFloat(value string) (*float64, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+func parseInt(value string) (*int, error) {
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &i, nil
+}
+
+// Render returns the formatted source of a Go file in package 'pkgName'
+// declaring the extracted markers as the variable 'varName'. The file
+// expects a 'Markers' type and 'float64Ptr'/'intPtr' helpers to exist
+// in the target package.
+func Render(pkgName, varName string, markers map[string]Markers) ([]byte, error) {
+	keys := make([]string, 0, len(markers))
+
+	for k := range markers {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+
+	fmt.Fprintln(&buf, "// Code generated by markergen. DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+	fmt.Fprintf(&buf, "var %s = map[string]Markers{\n", varName)
+
+	for _, k := range keys {
+		fmt.Fprintf(&buf, "%s: {%s},\n", strconv.Quote(k), renderFields(markers[k]))
+	}
+
+	fmt.Fprintln(&buf, "}")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated source: %w", err)
+	}
+
+	return src, nil
+}
+
+func renderFields(m Markers) string {
+	var fields []string
+
+	if m.Required {
+		fields = append(fields, "Required: true")
+	}
+
+	if m.Optional {
+		fields = append(fields, "Optional: true")
+	}
+
+	if m.Pattern != "" {
+		fields = append(fields, "Pattern: "+quote(m.Pattern))
+	}
+
+	if len(m.Enum) > 0 {
+		quoted := make([]string, 0, len(m.Enum))
+
+		for _, e := range m.Enum {
+			quoted = append(quoted, strconv.Quote(e))
+		}
+
+		fields = append(fields, "Enum: []string{"+strings.Join(quoted, ", ")+"}")
+	}
+
+	for _, f := range []struct {
+		name string
+		val  *float64
+	}{{"Minimum", m.Minimum}, {"Maximum", m.Maximum}} {
+		if f.val != nil {
+			fields = append(fields, fmt.Sprintf("%s: float64Ptr(%s)", f.name, strconv.FormatFloat(*f.val, 'g', -1, 64)))
+		}
+	}
+
+	for _, f := range []struct {
+		name string
+		val  *int
+	}{{"MinLength", m.MinLength}, {"MaxLength", m.MaxLength}, {"MinItems", m.MinItems}, {"MaxItems", m.MaxItems}} {
+		if f.val != nil {
+			fields = append(fields, fmt.Sprintf("%s: intPtr(%d)", f.name, *f.val))
+		}
+	}
+
+	if m.Default != "" {
+		fields = append(fields, "Default: "+quote(m.Default))
+	}
+
+	if m.Description != "" {
+		fields = append(fields, "Description: "+strconv.Quote(m.Description))
+	}
+
+	return strings.Join(fields, ", ")
+}
+
+// quote prefers raw string literals so that patterns stay readable.
+func quote(s string) string {
+	if !strings.Contains(s, "`") {
+		return "`" + s + "`"
+	}
+
+	return strconv.Quote(s)
+}
+
+// ModulePackages resolves the given directories relative to the module
+// root 'root' into packages by reading the module path from its go.mod.
+func ModulePackages(root string, dirs ...string) ([]Package, error) {
+	data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return nil, fmt.Errorf("reading go.mod: %w", err)
+	}
+
+	var module string
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			module = strings.TrimSpace(rest)
+
+			break
+		}
+	}
+
+	if module == "" {
+		return nil, fmt.Errorf("no module directive found in %q", filepath.Join(root, "go.mod"))
+	}
+
+	res := make([]Package, 0, len(dirs))
+
+	for _, dir := range dirs {
+		res = append(res, Package{
+			ImportPath: module + "/" + filepath.ToSlash(filepath.Clean(dir)),
+			Dir:        filepath.Join(root, dir),
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,57 @@
+// Package schema exposes the kubebuilder validation markers declared on
+// the addon metadata types at runtime and validates values against them.
+package schema
+
+import (
+	"reflect"
+)
+
+//go:generate go run ./internal/markergen/cmd -root ../.. -o zz_generated.markers.go api/v1alpha1 pkg/mtsre/v1 pkg/ocm/v1
+
+// markerPackages lists the module relative directories of the
+// packages whose markers are included in the generated table.
+var markerPackages = []string{"api/v1alpha1", "pkg/mtsre/v1", "pkg/ocm/v1"}
+
+// Markers holds the validation markers and description
+// declared on a type or struct field.
+type Markers struct {
+	Required    bool
+	Optional    bool
+	Pattern     string
+	Enum        []string
+	Minimum     *float64
+	Maximum     *float64
+	MinLength   *int
+	MaxLength   *int
+	MinItems    *int
+	MaxItems    *int
+	Default     string
+	Description string
+}
+
+// TypeMarkers returns the markers declared on the named type 't'.
+func TypeMarkers(t reflect.Type) (Markers, bool) {
+	if t.Name() == "" {
+		return Markers{}, false
+	}
+
+	m, ok := generatedMarkers[t.PkgPath()+"."+t.Name()]
+
+	return m, ok
+}
+
+// FieldMarkers returns the markers declared on the field 'name'
+// of the named struct type 't'.
+func FieldMarkers(t reflect.Type, name string) (Markers, bool) {
+	if t.Name() == "" {
+		return Markers{}, false
+	}
+
+	m, ok := generatedMarkers[t.PkgPath()+"."+t.Name()+"."+name]
+
+	return m, ok
+}
+
+func float64Ptr(f float64) *float64 { return &f }
+
+func intPtr(i int) *int { return &i }
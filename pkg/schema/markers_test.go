@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/schema/internal/markergen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedMarkersUpToDate(t *testing.T) {
+	t.Parallel()
+
+	pkgs, err := markergen.ModulePackages("../..", markerPackages...)
+	require.NoError(t, err)
+
+	markers, err := markergen.Extract(pkgs...)
+	require.NoError(t, err)
+
+	expected, err := markergen.Render("schema", "generatedMarkers", markers)
+	require.NoError(t, err)
+
+	actual, err := os.ReadFile("zz_generated.markers.go")
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expected), string(actual),
+		"zz_generated.markers.go is out of date; run 'go generate ./pkg/schema'",
+	)
+}
+
+func TestFieldMarkers(t *testing.T) {
+	t.Parallel()
+
+	spec := reflect.TypeOf(addonsv1alpha1.AddonMetadataSpec{})
+
+	m, ok := FieldMarkers(spec, "InstallMode")
+	require.True(t, ok)
+	assert.True(t, m.Required)
+	assert.Equal(t, []string{"AllNamespaces", "OwnNamespace"}, m.Enum)
+
+	m, ok = FieldMarkers(spec, "OcmQuotaCost")
+	require.True(t, ok)
+	require.NotNil(t, m.Minimum)
+	assert.Equal(t, float64(0), *m.Minimum)
+
+	m, ok = FieldMarkers(spec, "NamespaceLabels")
+	require.True(t, ok)
+	assert.True(t, m.Required)
+	assert.Equal(t, "{}", m.Default)
+
+	_, ok = FieldMarkers(reflect.TypeOf(struct{ Foo string }{}), "Foo")
+	assert.False(t, ok)
+}
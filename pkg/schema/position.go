@@ -0,0 +1,130 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Position is a location in a source file.
+type Position struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column,omitempty"`
+}
+
+func (p Position) String() string {
+	if p.Column == 0 {
+		return fmt.Sprintf("%s:%d", p.File, p.Line)
+	}
+
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// Positions maps the JSON paths of fields, as reported in a
+// Violation, to the position at which they are defined.
+type Positions map[string]Position
+
+// LocateYAML returns the position of every key and list item of the
+// YAML document 'data' which maps to a field of type 't'.
+func LocateYAML(file string, data []byte, t reflect.Type) Positions {
+	c := checker{
+		file:      file,
+		positions: make(Positions),
+	}
+
+	c.run(data, t)
+
+	return c.positions
+}
+
+// Lookup returns the position of the field at 'path'. If the field
+// is not defined, e.g. because a required field is missing, the
+// position of its closest defined ancestor is returned instead.
+func (p Positions) Lookup(path string) (Position, bool) {
+	for path != "" {
+		if pos, ok := p[path]; ok {
+			return pos, true
+		}
+
+		path = parentPath(path)
+	}
+
+	return Position{}, false
+}
+
+// Overlay returns the union of both position maps where every top-level
+// field defined in 'other' replaces the same field in 'p' including all
+// of its nested fields. This mirrors how the fields of an imageset
+// replace those of the addon metadata.
+func (p Positions) Overlay(other Positions) Positions {
+	replaced := make(map[string]bool, len(other))
+
+	for path := range other {
+		replaced[topLevelField(path)] = true
+	}
+
+	res := make(Positions, len(p)+len(other))
+
+	for path, pos := range p {
+		if replaced[topLevelField(path)] {
+			continue
+		}
+
+		res[path] = pos
+	}
+
+	for path, pos := range other {
+		res[path] = pos
+	}
+
+	return res
+}
+
+// Select returns the positions of the given top-level fields
+// and of all of their nested fields.
+func (p Positions) Select(fields ...string) Positions {
+	res := make(Positions)
+
+	for path, pos := range p {
+		for _, f := range fields {
+			if topLevelField(path) == f {
+				res[path] = pos
+
+				break
+			}
+		}
+	}
+
+	return res
+}
+
+// parentPath strips the last segment of a JSON path such as
+// 'config.env[0]' or 'namespaceLabels["foo"]'.
+func parentPath(path string) string {
+	if strings.HasSuffix(path, `"]`) {
+		if i := strings.LastIndex(path, `["`); i >= 0 {
+			return path[:i]
+		}
+	}
+
+	if strings.HasSuffix(path, "]") {
+		if i := strings.LastIndex(path, "["); i >= 0 {
+			return path[:i]
+		}
+	}
+
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		return path[:i]
+	}
+
+	return ""
+}
+
+func topLevelField(path string) string {
+	if i := strings.IndexAny(path, ".["); i >= 0 {
+		return path[:i]
+	}
+
+	return path
+}
@@ -0,0 +1,82 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocateYAML(t *testing.T) {
+	t.Parallel()
+
+	data := strings.Join([]string{
+		"id: reference-addon",
+		"namespaces:",
+		"  - redhat-reference-addon",
+		"namespaceLabels:",
+		"  monitoring-key: reference",
+		"addOnParameters:",
+		"  - id: size",
+		"    name: Size",
+	}, "\n")
+
+	positions := LocateYAML("addon.yaml", []byte(data), reflect.TypeOf(addonsv1alpha1.AddonMetadataSpec{}))
+
+	for path, expected := range map[string]string{
+		"id":                                "addon.yaml:1:1",
+		"namespaces[0]":                     "addon.yaml:3:5",
+		`namespaceLabels["monitoring-key"]`: "addon.yaml:5:3",
+		"addOnParameters[0].name":           "addon.yaml:8:5",
+		// missing fields resolve to their closest ancestor
+		"addOnParameters[0].description": "addon.yaml:7:5",
+		"pagerduty.secretName":           "",
+	} {
+		pos, ok := positions.Lookup(path)
+		if expected == "" {
+			assert.False(t, ok, path)
+
+			continue
+		}
+
+		require.True(t, ok, path)
+		assert.Equal(t, expected, pos.String(), path)
+	}
+}
+
+func TestPositionsOverlay(t *testing.T) {
+	t.Parallel()
+
+	meta := Positions{
+		"id":                      {File: "addon.yaml", Line: 1},
+		"addOnParameters":         {File: "addon.yaml", Line: 2},
+		"addOnParameters[1].name": {File: "addon.yaml", Line: 5},
+	}
+	imageSet := Positions{
+		"addOnParameters":    {File: "imageset.yaml", Line: 7},
+		"addOnParameters[0]": {File: "imageset.yaml", Line: 8},
+	}
+
+	assert.Equal(t, Positions{
+		"id":                 {File: "addon.yaml", Line: 1},
+		"addOnParameters":    {File: "imageset.yaml", Line: 7},
+		"addOnParameters[0]": {File: "imageset.yaml", Line: 8},
+	}, meta.Overlay(imageSet))
+}
+
+func TestParentPath(t *testing.T) {
+	t.Parallel()
+
+	for path, expected := range map[string]string{
+		"id":                                 "",
+		"config.env[0].name":                 "config.env[0]",
+		"config.env[0]":                      "config.env",
+		`namespaceLabels["a.b/c"]`:           "namespaceLabels",
+		`addOnRequirements[0].data["a.b"].x`: `addOnRequirements[0].data["a.b"]`,
+	} {
+		assert.Equal(t, expected, parentPath(path), path)
+	}
+}
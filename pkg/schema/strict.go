@@ -0,0 +1,334 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IssueKind classifies a problem found while strictly decoding a file.
+type IssueKind string
+
+const (
+	IssueSyntax       IssueKind = "SyntaxError"
+	IssueUnknownField IssueKind = "UnknownField"
+	IssueDuplicateKey IssueKind = "DuplicateKey"
+	IssueTypeMismatch IssueKind = "TypeMismatch"
+)
+
+// DecodeIssue is a problem found at a position of a YAML file which the
+// lenient decoder either ignores or reports without a position.
+type DecodeIssue struct {
+	File   string
+	Line   int
+	Column int
+	Kind   IssueKind
+	// Path is the JSON path of the offending key or value. It
+	// is empty for syntax errors.
+	Path    string
+	Message string
+}
+
+// Position returns the location of the issue.
+func (i DecodeIssue) Position() Position {
+	return Position{File: i.File, Line: i.Line, Column: i.Column}
+}
+
+// Describe returns the message of the issue prefixed
+// with the path of the offending key if any.
+func (i DecodeIssue) Describe() string {
+	if i.Path == "" {
+		return i.Message
+	}
+
+	return fmt.Sprintf("%s: %s", i.Path, i.Message)
+}
+
+func (i DecodeIssue) String() string {
+	return fmt.Sprintf("%s: %s", i.Position(), i.Describe())
+}
+
+// DecodeError is returned when strict decoding finds any issues.
+type DecodeError struct {
+	Issues []DecodeIssue
+}
+
+func (e *DecodeError) Error() string {
+	msgs := make([]string, 0, len(e.Issues))
+
+	for _, i := range e.Issues {
+		msgs = append(msgs, i.String())
+	}
+
+	return fmt.Sprintf("strict decoding found %d issue(s): %s", len(e.Issues), strings.Join(msgs, "; "))
+}
+
+// CheckYAML reports every key of the YAML document 'data' which does not
+// map to a field of type 't', every duplicated key and every value whose
+// YAML type cannot be decoded into the type of the field holding it.
+// 'file' is only used to annotate the returned issues.
+func CheckYAML(file string, data []byte, t reflect.Type) []DecodeIssue {
+	c := checker{file: file}
+
+	c.run(data, t)
+
+	return c.issues
+}
+
+var syntaxLine = regexp.MustCompile(`^yaml: line (\d+): `)
+
+func syntaxIssue(file string, err error) DecodeIssue {
+	issue := DecodeIssue{
+		File:    file,
+		Kind:    IssueSyntax,
+		Message: err.Error(),
+	}
+
+	if m := syntaxLine.FindStringSubmatch(issue.Message); m != nil {
+		issue.Line, _ = strconv.Atoi(m[1])
+		issue.Message = strings.TrimPrefix(issue.Message, m[0])
+	}
+
+	return issue
+}
+
+type checker struct {
+	file   string
+	issues []DecodeIssue
+	// positions records the position of every key and list
+	// item which was checked unless it is nil.
+	positions Positions
+}
+
+func (c *checker) run(data []byte, t reflect.Type) {
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		c.issues = append(c.issues, syntaxIssue(c.file, err))
+
+		return
+	}
+
+	if len(doc.Content) > 0 {
+		c.check(doc.Content[0], t, "")
+	}
+}
+
+func (c *checker) locate(n *yaml.Node, path string) {
+	if c.positions == nil {
+		return
+	}
+
+	c.positions[path] = Position{File: c.file, Line: n.Line, Column: n.Column}
+}
+
+func (c *checker) report(n *yaml.Node, kind IssueKind, path, format string, args ...interface{}) {
+	c.issues = append(c.issues, DecodeIssue{
+		File:    c.file,
+		Line:    n.Line,
+		Column:  n.Column,
+		Kind:    kind,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *checker) check(n *yaml.Node, t reflect.Type, path string) {
+	if n.Kind == yaml.AliasNode {
+		// aliased nodes are checked where they are defined
+		return
+	}
+
+	// null is a valid value for every field
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		return
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// Types with a custom JSON encoding such as 'apiextensionsv1.JSON'
+	// may hold arbitrary values.
+	if t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		c.checkStruct(n, t, path)
+	case reflect.Map:
+		if !c.expect(n, yaml.MappingNode, "object", path) {
+			return
+		}
+
+		c.eachKey(n, path, mapKeyPath, func(key, val *yaml.Node, keyPath string) {
+			c.check(val, t.Elem(), keyPath)
+		})
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			c.expectScalar(n, path, "string", "!!str", "!!binary")
+
+			return
+		}
+
+		if !c.expect(n, yaml.SequenceNode, "array", path) {
+			return
+		}
+
+		for i, item := range n.Content {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+
+			c.locate(item, itemPath)
+			c.check(item, t.Elem(), itemPath)
+		}
+	case reflect.String:
+		c.expectScalar(n, path, "string", "!!str")
+	case reflect.Bool:
+		c.expectScalar(n, path, "boolean", "!!bool")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		c.expectScalar(n, path, "integer", "!!int")
+	case reflect.Float32, reflect.Float64:
+		c.expectScalar(n, path, "number", "!!int", "!!float")
+	}
+}
+
+func (c *checker) checkStruct(n *yaml.Node, t reflect.Type, path string) {
+	if !c.expect(n, yaml.MappingNode, "object", path) {
+		return
+	}
+
+	fields := make(map[string]reflect.Type)
+	collectFields(t, fields)
+
+	c.eachKey(n, path, joinPath, func(key, val *yaml.Node, keyPath string) {
+		ft, ok := fields[key.Value]
+		if !ok {
+			c.report(key, IssueUnknownField, keyPath, "unknown field")
+
+			return
+		}
+
+		c.check(val, ft, keyPath)
+	})
+}
+
+// collectFields adds the JSON name and type of every field of the
+// struct type 't' to 'fields'. Fields of embedded structs without
+// a name are inlined.
+func collectFields(t reflect.Type, fields map[string]reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+
+			collectFields(ft, fields)
+
+			continue
+		}
+
+		fields[name] = field.Type
+	}
+}
+
+// eachKey calls 'fn' for every key-value pair of the mapping node 'n'
+// and reports every key which is defined more than once. 'join' returns
+// the path of a key below 'path'.
+func (c *checker) eachKey(
+	n *yaml.Node,
+	path string,
+	join func(path, key string) string,
+	fn func(key, val *yaml.Node, keyPath string),
+) {
+	seen := make(map[string]*yaml.Node)
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, val := n.Content[i], n.Content[i+1]
+
+		// merge keys such as '<<: *defaults' are resolved by the decoder
+		if key.Tag == "!!merge" {
+			continue
+		}
+
+		keyPath := join(path, key.Value)
+
+		if first, ok := seen[key.Value]; ok {
+			c.report(key, IssueDuplicateKey, keyPath, "duplicate key, first defined at line %d", first.Line)
+
+			continue
+		}
+
+		seen[key.Value] = key
+
+		c.locate(key, keyPath)
+
+		fn(key, val, keyPath)
+	}
+}
+
+func mapKeyPath(path, key string) string {
+	return fmt.Sprintf("%s[%s]", path, strconv.Quote(key))
+}
+
+func (c *checker) expect(n *yaml.Node, kind yaml.Kind, name, path string) bool {
+	if n.Kind == kind {
+		return true
+	}
+
+	c.report(n, IssueTypeMismatch, path, "expected %s, got %s", name, describe(n))
+
+	return false
+}
+
+func (c *checker) expectScalar(n *yaml.Node, path, name string, tags ...string) {
+	if n.Kind == yaml.ScalarNode && contains(tags, n.ShortTag()) {
+		return
+	}
+
+	msg := fmt.Sprintf("expected %s, got %s", name, describe(n))
+	if n.Kind == yaml.ScalarNode && name == "string" {
+		msg += "; quote the value to keep it as is"
+	}
+
+	c.report(n, IssueTypeMismatch, path, "%s", msg)
+}
+
+func describe(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+
+	switch n.ShortTag() {
+	case "!!str":
+		return fmt.Sprintf("string %q", n.Value)
+	case "!!int":
+		return fmt.Sprintf("integer %s", n.Value)
+	case "!!float":
+		return fmt.Sprintf("number %s", n.Value)
+	case "!!bool":
+		return fmt.Sprintf("boolean %s", n.Value)
+	default:
+		return fmt.Sprintf("%s %q", n.ShortTag(), n.Value)
+	}
+}
@@ -0,0 +1,122 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckYAML(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Data     []string
+		Expected []string
+	}{
+		"valid": {
+			Data: []string{
+				"id: reference-addon",
+				"ocmQuotaCost: 1",
+				"enabled: true",
+				"namespaces:",
+				"  - redhat-reference-addon",
+				"namespaceLabels:",
+				"  monitoring-key: reference",
+				"indexImage: null",
+				"addOnRequirements:",
+				"  - id: cluster",
+				"    resource: cluster",
+				"    enabled: true",
+				"    data:",
+				"      cloud_provider.id: [aws]",
+			},
+		},
+		"unknown fields": {
+			Data: []string{
+				"id: reference-addon",
+				"addonOwnr: MT-SRE",
+				"pagerduty:",
+				"  secretNme: pagerduty",
+			},
+			Expected: []string{
+				"addon.yaml:2:1: addonOwnr: unknown field",
+				"addon.yaml:4:3: pagerduty.secretNme: unknown field",
+			},
+		},
+		"duplicate keys": {
+			Data: []string{
+				"id: reference-addon",
+				"namespaceLabels:",
+				"  foo: bar",
+				"  foo: baz",
+				"id: other-addon",
+			},
+			Expected: []string{
+				`addon.yaml:4:3: namespaceLabels["foo"]: duplicate key, first defined at line 3`,
+				"addon.yaml:5:1: id: duplicate key, first defined at line 1",
+			},
+		},
+		"type mismatches": {
+			Data: []string{
+				"ocmQuotaCost: one",
+				"enabled: yes",
+				"defaultChannel: 1.10",
+				"namespaces: redhat-reference-addon",
+				"addOnParameters:",
+				"  - id: size",
+				"    options: {}",
+			},
+			Expected: []string{
+				`addon.yaml:1:15: ocmQuotaCost: expected integer, got string "one"`,
+				`addon.yaml:2:10: enabled: expected boolean, got string "yes"`,
+				"addon.yaml:3:17: defaultChannel: expected string, got number 1.10; quote the value to keep it as is",
+				`addon.yaml:4:13: namespaces: expected array, got string "redhat-reference-addon"`,
+				"addon.yaml:7:14: addOnParameters[0].options: expected array, got object",
+			},
+		},
+		"syntax error": {
+			Data: []string{
+				"id: reference-addon",
+				"\tname: Reference Addon",
+			},
+			Expected: []string{
+				"addon.yaml:2: found a tab character that violates indentation",
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			issues := CheckYAML(
+				"addon.yaml",
+				[]byte(strings.Join(tc.Data, "\n")),
+				reflect.TypeOf(addonsv1alpha1.AddonMetadataSpec{}),
+			)
+
+			var actual []string
+
+			for _, i := range issues {
+				actual = append(actual, i.String())
+			}
+
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	t.Parallel()
+
+	err := &DecodeError{
+		Issues: []DecodeIssue{
+			{File: "addon.yaml", Line: 2, Column: 1, Kind: IssueUnknownField, Path: "addonOwnr", Message: "unknown field"},
+		},
+	}
+
+	assert.EqualError(t, err, "strict decoding found 1 issue(s): addon.yaml:2:1: addonOwnr: unknown field")
+}
@@ -0,0 +1,344 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Violation is a single constraint which a value does not satisfy.
+type Violation struct {
+	// Path is the JSON path of the offending field, e.g.
+	// 'addOnParameters[0].id' or 'namespaceLabels["foo"]'.
+	Path    string
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Path, v.Message)
+}
+
+// Validate walks 'v' and returns every violation of the kubebuilder
+// validation markers and 'validate:"required"' tags declared on its
+// types and fields. 'v' is typically an '*AddonMetadataSpec' or an
+// '*AddonImageSetSpec'.
+//
+// Since decoded values cannot tell an absent field from its zero value,
+// required booleans and numbers are always considered present and
+// optional strings which are empty are considered absent. Absent required
+// fields which declare a '+kubebuilder:default' are not reported.
+func Validate(v interface{}) []Violation {
+	w := walker{}
+
+	w.walk(reflect.ValueOf(v), "", Markers{}, false)
+
+	return w.violations
+}
+
+type walker struct {
+	violations []Violation
+}
+
+func (w *walker) report(path, format string, args ...interface{}) {
+	if path == "" {
+		path = "."
+	}
+
+	w.violations = append(w.violations, Violation{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// missing reports the absent field at 'path' if it is required. Fields
+// declaring a default are satisfied since the default is applied by the
+// API server.
+func (w *walker) missing(path string, m Markers) {
+	if m.Required && m.Default == "" {
+		w.report(path, "required field is missing")
+	}
+}
+
+// walk validates 'val' found at 'path' against the markers 'm' of the field
+// holding it. 'present' is true if 'val' was reached through a non-nil
+// pointer and must therefore be validated even if it is a zero value.
+func (w *walker) walk(val reflect.Value, path string, m Markers, present bool) {
+	if val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return
+		}
+
+		val = val.Elem()
+	}
+
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			w.missing(path, m)
+
+			return
+		}
+
+		w.walk(val.Elem(), path, m, true)
+
+		return
+	}
+
+	m = merge(m, val.Type())
+
+	switch val.Kind() {
+	case reflect.Struct:
+		w.walkStruct(val, path)
+	case reflect.Slice, reflect.Array:
+		w.walkSlice(val, path, m)
+	case reflect.Map:
+		w.walkMap(val, path, m)
+	case reflect.String:
+		w.checkString(val.String(), path, m, present)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		w.checkNumber(float64(val.Int()), path, m)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		w.checkNumber(float64(val.Uint()), path, m)
+	case reflect.Float32, reflect.Float64:
+		w.checkNumber(val.Float(), path, m)
+	}
+}
+
+func (w *walker) walkStruct(val reflect.Value, path string) {
+	t := val.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+
+		m, _ := FieldMarkers(t, field.Name)
+
+		if tag := field.Tag.Get("validate"); hasRule(tag, "required") {
+			m.Required = true
+		}
+
+		if field.Anonymous && name == "" {
+			w.walk(val.Field(i), path, m, false)
+
+			continue
+		}
+
+		w.walk(val.Field(i), joinPath(path, name), m, false)
+	}
+}
+
+func (w *walker) walkSlice(val reflect.Value, path string, m Markers) {
+	if val.Kind() == reflect.Slice && val.IsNil() {
+		w.missing(path, m)
+
+		return
+	}
+
+	// byte slices are opaque values such as raw JSON
+	if val.Type().Elem().Kind() == reflect.Uint8 {
+		return
+	}
+
+	if m.MinItems != nil && val.Len() < *m.MinItems {
+		w.report(path, "must have at least %d items, but has %d", *m.MinItems, val.Len())
+	}
+
+	if m.MaxItems != nil && val.Len() > *m.MaxItems {
+		w.report(path, "must have at most %d items, but has %d", *m.MaxItems, val.Len())
+	}
+
+	for i := 0; i < val.Len(); i++ {
+		w.walk(val.Index(i), fmt.Sprintf("%s[%d]", path, i), itemMarkers(m), true)
+	}
+}
+
+func (w *walker) walkMap(val reflect.Value, path string, m Markers) {
+	if val.IsNil() {
+		w.missing(path, m)
+
+		return
+	}
+
+	keys := val.MapKeys()
+
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+
+	for _, k := range keys {
+		itemPath := fmt.Sprintf("%s[%s]", path, strconv.Quote(fmt.Sprint(k.Interface())))
+
+		w.walk(val.MapIndex(k), itemPath, itemMarkers(m), true)
+	}
+}
+
+func (w *walker) checkString(s, path string, m Markers, present bool) {
+	if s == "" && !present {
+		w.missing(path, m)
+
+		return
+	}
+
+	if m.MinLength != nil && len(s) < *m.MinLength {
+		w.report(path, "must be at least %d characters long", *m.MinLength)
+	}
+
+	if m.MaxLength != nil && len(s) > *m.MaxLength {
+		w.report(path, "must be at most %d characters long", *m.MaxLength)
+	}
+
+	if m.Pattern != "" {
+		re, err := compile(m.Pattern)
+		if err != nil {
+			w.report(path, "invalid pattern %q: %v", m.Pattern, err)
+		} else if !re.MatchString(s) {
+			w.report(path, "value %q does not match pattern %q", s, m.Pattern)
+		}
+	}
+
+	if len(m.Enum) > 0 && !contains(m.Enum, s) {
+		w.report(path, "value %q must be one of %s", s, quoteAll(m.Enum))
+	}
+}
+
+func (w *walker) checkNumber(f float64, path string, m Markers) {
+	if m.Minimum != nil && f < *m.Minimum {
+		w.report(path, "value %v must be greater than or equal to %v", f, *m.Minimum)
+	}
+
+	if m.Maximum != nil && f > *m.Maximum {
+		w.report(path, "value %v must be less than or equal to %v", f, *m.Maximum)
+	}
+}
+
+// merge adds the markers declared on the named type 't' to the
+// markers of the field holding a value of that type.
+func merge(m Markers, t reflect.Type) Markers {
+	tm, ok := TypeMarkers(t)
+	if !ok {
+		return m
+	}
+
+	if m.Pattern == "" {
+		m.Pattern = tm.Pattern
+	}
+
+	if len(m.Enum) == 0 {
+		m.Enum = tm.Enum
+	}
+
+	if m.Minimum == nil {
+		m.Minimum = tm.Minimum
+	}
+
+	if m.Maximum == nil {
+		m.Maximum = tm.Maximum
+	}
+
+	if m.MinLength == nil {
+		m.MinLength = tm.MinLength
+	}
+
+	if m.MaxLength == nil {
+		m.MaxLength = tm.MaxLength
+	}
+
+	return m
+}
+
+// itemMarkers returns the markers applying to the items of a list or
+// the values of a map. Scalar constraints declared on a list of strings
+// apply to every item while presence and size constraints do not.
+func itemMarkers(m Markers) Markers {
+	return Markers{
+		Pattern:   m.Pattern,
+		Enum:      m.Enum,
+		Minimum:   m.Minimum,
+		Maximum:   m.Maximum,
+		MinLength: m.MinLength,
+		MaxLength: m.MaxLength,
+	}
+}
+
+// jsonName returns the JSON name of a struct field. Embedded structs
+// without a name are inlined and reported with an empty name.
+func jsonName(field reflect.StructField) (string, bool) {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+	switch {
+	case name == "-":
+		return "", false
+	case name != "":
+		return name, true
+	case field.Anonymous:
+		return "", true
+	default:
+		return field.Name, true
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+func hasRule(tag, rule string) bool {
+	for _, r := range strings.Split(tag, ",") {
+		if strings.TrimSpace(r) == rule {
+			return true
+		}
+	}
+
+	return false
+}
+
+var patterns sync.Map
+
+func compile(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns.Store(pattern, re)
+
+	return re, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func quoteAll(list []string) string {
+	quoted := make([]string, 0, len(list))
+
+	for _, s := range list {
+		quoted = append(quoted, strconv.Quote(s))
+	}
+
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
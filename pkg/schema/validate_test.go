@@ -0,0 +1,125 @@
+package schema
+
+import (
+	"testing"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	mtsrev1 "github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1"
+	ocmv1 "github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Mutate   func(*addonsv1alpha1.AddonMetadataSpec)
+		Expected []string
+	}{
+		"valid": {
+			Mutate: func(*addonsv1alpha1.AddonMetadataSpec) {},
+		},
+		"malformed quayRepo and addonOwner": {
+			Mutate: func(s *addonsv1alpha1.AddonMetadataSpec) {
+				s.QuayRepo = "docker.io/reference-addon"
+				s.AddonOwner = "someone@example.com"
+			},
+			Expected: []string{
+				`addonOwner: value "someone@example.com" does not match pattern "^([A-Za-z -]+ <[0-9A-Za-z_.-]+@redhat\\.com>,?)+$"`,
+				`quayRepo: value "docker.io/reference-addon" does not match pattern "^quay\\.io/osd-addons/[a-z-]+$"`,
+			},
+		},
+		"missing required fields": {
+			Mutate: func(s *addonsv1alpha1.AddonMetadataSpec) {
+				s.Description = ""
+				s.Namespaces = nil
+			},
+			Expected: []string{
+				"description: required field is missing",
+				"namespaces: required field is missing",
+			},
+		},
+		"missing required fields with defaults": {
+			Mutate: func(s *addonsv1alpha1.AddonMetadataSpec) {
+				s.NamespaceLabels = nil
+				s.NamespaceAnnotations = nil
+			},
+		},
+		"enum and minimum": {
+			Mutate: func(s *addonsv1alpha1.AddonMetadataSpec) {
+				s.InstallMode = "SingleNamespace"
+				s.OcmQuotaCost = -1
+			},
+			Expected: []string{
+				`installMode: value "SingleNamespace" must be one of ["AllNamespaces", "OwnNamespace"]`,
+				"ocmQuotaCost: value -1 must be greater than or equal to 0",
+			},
+		},
+		"nested fields": {
+			Mutate: func(s *addonsv1alpha1.AddonMetadataSpec) {
+				s.AddOnParameters = &[]ocmv1.AddOnParameter{
+					{ID: "size", Name: "Size", Description: "Size of the addon", ValueType: "string"},
+					{Name: "Region", Description: "Region of the addon", ValueType: "string"},
+				}
+				s.BundleParameters = &mtsrev1.BundleParameters{
+					UseClusterStorage: stringPtr("yes"),
+				}
+				s.PagerDuty = &mtsrev1.PagerDuty{
+					EscalationPolicy: "ABC123",
+					SecretName:       "pagerduty",
+					SecretNamespace:  "-invalid",
+				}
+				s.AddonNotifications = &[]mtsrev1.Notification{"Invalid"}
+			},
+			Expected: []string{
+				"addOnParameters[1].id: required field is missing",
+				`addonNotifications[0]: value "Invalid" does not match pattern "^([A-Za-z -]+ <[0-9A-Za-z_.-]+@redhat\\.com>,?)+$"`,
+				`bundleParameters.useClusterStorage: value "yes" does not match pattern "^(true|false|^$)$"`,
+				`pagerduty.secretNamespace: value "-invalid" does not match pattern "^[A-Za-z0-9][A-Za-z0-9-]{0,60}[A-Za-z0-9]$"`,
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := validSpec()
+			tc.Mutate(spec)
+
+			var actual []string
+
+			for _, v := range Validate(spec) {
+				actual = append(actual, v.String())
+			}
+
+			assert.ElementsMatch(t, tc.Expected, actual)
+		})
+	}
+}
+
+func validSpec() *addonsv1alpha1.AddonMetadataSpec {
+	return &addonsv1alpha1.AddonMetadataSpec{
+		ID:                   "reference-addon",
+		Name:                 "Reference Addon",
+		Description:          "Reference Addon is a real Addon.",
+		Icon:                 "aWNvbg==",
+		Label:                "api.openshift.com/addon-reference-addon",
+		Enabled:              true,
+		AddonOwner:           "MT-SRE Team <sd-mt-sre@redhat.com>",
+		QuayRepo:             "quay.io/osd-addons/reference-addon",
+		TestHarness:          "quay.io/osd-addons/reference-addon-test-harness",
+		InstallMode:          "OwnNamespace",
+		TargetNamespace:      "redhat-reference-addon",
+		Namespaces:           []string{"redhat-reference-addon"},
+		OcmQuotaName:         "addon-reference-addon",
+		OcmQuotaCost:         1,
+		OperatorName:         "reference-addon",
+		DefaultChannel:       "alpha",
+		NamespaceLabels:      map[string]string{},
+		NamespaceAnnotations: map[string]string{},
+		IndexImage:           stringPtr("quay.io/osd-addons/reference-addon-index@sha256:d9f95ecd"),
+	}
+}
+
+func stringPtr(s string) *string { return &s }
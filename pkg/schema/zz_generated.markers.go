@@ -0,0 +1,151 @@
+// Code generated by markergen. DO NOT EDIT.
+
+package schema
+
+var generatedMarkers = map[string]Markers{
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonImageSet":                                    {Description: "AddonImageSet is the Schema for the addonimagesets API"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonImageSetList":                                {Description: "AddonImageSetList contains a list of AddonImageSet"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonImageSetSpec":                                {Description: "AddonImageSetSpec defines the desired state of AddonImageSet"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonImageSetSpec.AddOnParameters":                {Optional: true, Description: "OCM representation of an add-on parameter"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonImageSetSpec.AddOnRequirements":              {Optional: true, Description: "OCM representation of an addon-requirement"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonImageSetSpec.AdditionalCatalogSources":       {Optional: true, Description: "List of additional catalog sources to be created."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonImageSetSpec.Config":                         {Optional: true, Description: "Configs to be passed to the subscription OLM object."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonImageSetSpec.IndexImage":                     {Required: true, Pattern: `^quay\.io/osd-addons/[a-z-]+`, Description: "The url for the index image"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonImageSetSpec.Name":                           {Required: true, Description: "The name of the imageset along with the version."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonImageSetSpec.PackageImage":                   {Optional: true, Pattern: `^quay\.io/osd-addons/[a-z-]+`, Description: "The url for the package image"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonImageSetSpec.PullSecretName":                 {Pattern: `^[a-z0-9][a-z0-9-]{1,60}[a-z0-9]$`, Description: "Name of the secret under `secrets` which is supposed to be used for pulling Catalog Image under CatalogSource."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonImageSetSpec.RelatedImages":                  {Required: true, Description: "A list of image urls of related operators"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonImageSetSpec.SubOperators":                   {Optional: true, Description: "OCM representation of an add-on sub operator. A sub operator is an operator who's life cycle is controlled by the add-on umbrella operator."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonImageSetStatus":                              {Description: "AddonImageSetStatus defines the observed state of AddonImageSet"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadata":                                    {Description: "AddonMetadata is the Schema for the AddonMetadata API"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataList":                                {Description: "AddonMetadataList contains a list of AddonMetadata"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec":                                {Description: "AddonMetadataSpec defines the desired state of AddonMetadata View markers: $ controller-gen -www crd"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.AddOnParameters":                {Optional: true, Description: "OCM representation of an add-on parameter"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.AddOnRequirements":              {Optional: true, Description: "OCM representation of an addon-requirement"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.AdditionalCatalogSources":       {Optional: true, Description: "List of additional catalog sources to be created."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.AddonNotifications":             {Optional: true},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.AddonOwner":                     {Required: true, Pattern: `^([A-Za-z -]+ <[0-9A-Za-z_.-]+@redhat\.com>,?)+$`, Description: "Team or individual responsible for this addon. Needs to match: 'some name <some-email@redhat.com>'."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.BundleParameters":               {Optional: true, Description: "Deprecated: Replaced by SubscriptionConfig."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.Channels":                       {Optional: true, Description: "Deprecated: List of channels where the addon operator is available. Only needed for legacy addon builds."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.CommonAnnotations":              {Optional: true, Description: "Annotations to be applied to all objects created in the SelectorSyncSet."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.CommonLabels":                   {Optional: true, Description: "Labels to be applied to all objects created in the SelectorSyncSet."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.Config":                         {Optional: true, Description: "Configs to be passed to the subscription OLM object."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.CredentialsRequests":            {Optional: true, Description: "List of credential requests to authenticate operators."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.DeadmansSnitch":                 {Optional: true, Description: "Denotes the Deadmans Snitch Configuration which is supposed to be setup alongside the Addon."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.DefaultChannel":                 {Required: true, Enum: []string{"alpha", "beta", "stable", "edge", "rc"}, Description: "OLM channel from which to install the addon-operator. One of: alpha, beta, stable, edge or rc."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.Description":                    {Required: true, Description: "Short description for the addon"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.Enabled":                        {Required: true, Description: "Set to true to allow installation of the addon."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.ExtraResources":                 {Optional: true, Description: "Extra Resources to be applied to the Hive cluster."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.HasExternalResources":           {Optional: true},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.ID":                             {Required: true, Pattern: `^[A-Za-z0-9][A-Za-z0-9-]{0,30}[A-Za-z0-9]$`, Description: "Unique ID of the addon"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.Icon":                           {Required: true, Description: "Icon to be shown in UI. Should be around 200px and base64 encoded."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.ImageSetVersion":                {Optional: true, Description: "A string which specifies the imageset to use. Can either be 'latest' or a version string MAJOR.MINOR.PATCH"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.IndexImage":                     {Optional: true, Pattern: `^quay\.io/osd-addons/[a-z-]+`},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.InstallMode":                    {Required: true, Enum: []string{"AllNamespaces", "OwnNamespace"}, Description: "OLM InstallMode for the addon operator. One of: AllNamespaces or OwnNamespace."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.Label":                          {Required: true, Pattern: `^api\.openshift\.com/addon-[0-9a-z][0-9a-z-]{0,30}[0-9a-z]$`, Description: "Kubernetes label for the addon. Needs to match: 'api.openshift.com/<addon-id>'."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.Link":                           {Optional: true, Pattern: `^http[s]?://(?:[a-zA-Z]|[0-9]|[$-_@.&+]|[!*\(\),]|(?:%[0-9a-fA-F][0-9a-fA-F]))+$`, Description: "Link to the addon documentation"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.ManagedService":                 {Optional: true, Description: "Indicates if the add-on will be used as a Managed Service."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.MetricsFederation":              {Optional: true, Description: "Configuration parameters to be injected in the ServiceMonitor used for federation. The target prometheus server found by matchLabels needs to serve service-ca signed TLS traffic (https://docs.openshift.com/container-platform/4.6/security/certificate_types_descriptions/service-ca-certificates.html), and it needs to be runing inside the monitoring.namespace, with the service name 'prometheus'."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.Monitoring":                     {Optional: true, Description: "Deprecated: Replaced by MetricsFederation Configuration parameters to be injected in the ServiceMonitor used for federation. The target prometheus server found by matchLabels needs to serve service-ca signed TLS traffic (https://docs.openshift.com/container-platform/4.6/security/certificate_types_descriptions/service-ca-certificates.html), and it needs to be runing inside the monitoring.namespace, with the service name 'prometheus'."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.MonitoringStack":                {Optional: true, Description: "Configuration parameters which will determine the underlying configuration of the MonitoringStack CR which will be created in runtime whenever the respective addon would be installed."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.Name":                           {Required: true, Description: "Friendly name for the addon, displayed in the UI"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.NamespaceAnnotations":           {Required: true, Default: `{}`, Description: "Annotations to be applied on all listed namespaces."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.NamespaceLabels":                {Required: true, Default: `{}`, Description: "Labels to be applied on all listed namespaces."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.Namespaces":                     {Required: true, Description: "Namespaces managed by the addon-operator. Need to include the TargetNamespace."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.OcmQuotaCost":                   {Required: true, Minimum: float64Ptr(0), Description: "OCM Quota cost for installing the addon."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.OcmQuotaName":                   {Required: true, Pattern: `^[A-Za-z0-9][A-Za-z0-9-_]{0,35}[A-Za-z0-9]$`, Description: "Refers to the SKU name for the addon."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.OperatorName":                   {Required: true, Pattern: `^[A-Za-z0-9][A-Za-z0-9-]*[A-Za-z0-9]$`, Description: "Name of the addon operator."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.PagerDuty":                      {Optional: true},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.PullSecretName":                 {Optional: true, Description: "Name of the secret under secrets which is supposed to be used for pulling Catalog Image under CatalogSource."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.QuayRepo":                       {Required: true, Pattern: `^quay\.io/osd-addons/[a-z-]+$`, Description: "Quay repository for the addon operator. Needs to match: 'quay.io/osd-addons/<my-addon-repo>'."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.StartingCSV":                    {Optional: true},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.SubOperators":                   {Optional: true, Description: "OCM representation of an add-on sub operator. A sub operator is an operator who's life cycle is controlled by the add-on umbrella operator."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.SyncsetMigration":               {Optional: true, Description: "The step currently in consideration in the process of migrating the addon to SyncSet."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.TargetNamespace":                {Required: true, Pattern: `^[A-Za-z0-9][A-Za-z0-9-]{0,60}[A-Za-z0-9]$`, Description: "Namespace where the addon operator should be installed."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataSpec.TestHarness":                    {Required: true, Pattern: `^quay\.io/[0-9A-Za-z._-]+/[0-9A-Za-z._-]+(:[A-Za-z0-9._-]+)?$`, Description: "Quay repository for the testHarness image. Needs to match: 'quay.io/<my-repo>/<my-test-harness>:<my-tag>'."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataStatus":                              {Description: "AddonMetadataStatus defines the observed state of AddonMetadata"},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataStatus.Bundles":                      {Optional: true, Description: "Bundles of the addon operator which were resolved from the index image."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataStatus.Conditions":                   {Optional: true, Description: "Conditions describing the outcome of the last validation. Known condition types are 'Valid', 'BundlesResolved' and 'OCMQuotaVerified'."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataStatus.LastValidatedImageSetVersion": {Optional: true, Description: "The imageset version which was last validated."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.AddonMetadataStatus.ObservedGeneration":           {Optional: true, Description: "The generation of the AddonMetadata which was last reconciled."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.BundleReference":                                  {Description: "BundleReference identifies a bundle of the addon operator."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.BundleReference.Image":                            {Optional: true, Description: "Image of the bundle."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.BundleReference.Name":                             {Description: "Name of the bundle, usually the name of its ClusterServiceVersion."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.BundleReference.Version":                          {Optional: true, Description: "Version of the bundle."},
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1.Channel":                                          {Description: "Channel - list all channels for a given operator"},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.AdditionalCatalogSource.Image":                    {Required: true, Description: "Image url of the additional catalog source"},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.AdditionalCatalogSource.Name":                     {Pattern: `^[a-z]([-a-z0-9]*[a-z0-9])?$`, Description: "Name of the additional catalog source"},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.BundleParameters":                                 {Description: "Deprecated: Replaced by SubscriptionConfig."},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.BundleParameters.AddonParamsSecretName":           {Optional: true, Pattern: `^addon-[0-9A-Za-z-]+-parameters$`},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.BundleParameters.AlertSMTPFrom":                   {Optional: true, Pattern: `^[0-9A-Za-z._-]+@(devshift\.net|rhmw\.io)$`},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.BundleParameters.AlertingEmailAddress":            {Optional: true, Pattern: `^([0-9A-Za-z_.-]+@redhat\.com,? ?)+$`},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.BundleParameters.BuAlertingEmailAddress":          {Optional: true, Pattern: `^([0-9A-Za-z_.-]+@redhat\.com,? ?)+$`},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.BundleParameters.UseClusterStorage":               {Optional: true, Pattern: `^(true|false|^$)$`},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.Config.Env":                                       {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.Config.Secrets":                                   {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.CredentialsRequest.Name":                          {Pattern: `^[A-Za-z0-9][A-Za-z0-9-]{0,60}[A-Za-z0-9]$`, Description: "Name of the credentials secret used to access cloud resources"},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.CredentialsRequest.Namespace":                     {Pattern: `^[A-Za-z0-9][A-Za-z0-9-]{0,60}[A-Za-z0-9]$`, Description: "Namespace where the credentials secret lives in the cluster"},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.CredentialsRequest.PolicyPermissions":             {Description: "List of policy permissions needed to access cloud resources"},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.CredentialsRequest.ServiceAccount":                {Pattern: `^[A-Za-z0-9][A-Za-z0-9-]{0,60}[A-Za-z0-9]$`, Description: "Service account name to use when authenticating"},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.DeadmansSnitch.ClusterDeploymentSelector":         {Optional: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.DeadmansSnitch.SnitchNamePostFix":                 {Optional: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.DeadmansSnitch.Tags":                              {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.DeadmansSnitch.TargetSecretRef":                   {Optional: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.EnvItem.Name":                                     {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.EnvItem.Value":                                    {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.MetricsFederation.MatchLabels":                    {Pattern: `^[A-Za-z0-9-_./]+$`},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.MetricsFederation.MatchNames":                     {Pattern: `^[a-zA-Z_:][a-zA-Z0-9_:]*$`},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.MetricsFederation.Namespace":                      {Pattern: `^[A-Za-z0-9][A-Za-z0-9-]{0,60}[A-Za-z0-9]$`},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.MetricsFederation.PortName":                       {Pattern: `^[A-Za-z0-9][A-Za-z0-9-]{0,60}[A-Za-z0-9]$`},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.Monitoring":                                       {Description: "Deprecated: Replaced by MetricsFederation"},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.Monitoring.MatchLabels":                           {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.Monitoring.MatchNames":                            {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.Monitoring.Namespace":                             {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.MonitoringStack.Enabled":                          {Optional: true, Description: "This denotes whether the addon requires the MonitoringStack CR to be created in runtime or not. Validation fails if it is provided as 'false' and at the same time other parameters are specified"},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.MonitoringStack.Resources":                        {Optional: true, Description: "Represents the resource quotas (requests/limits) to be allocated to the Prometheus instances which will be spun up consequently by the respective MonitoringStack CR in runtime. If not provided, the default values would be used: '{requests: {cpu: '100m', memory: '256M'}, limits:{memory: '512M', cpu: '500m'}}'"},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.MonitoringStackResource.Cpu":                      {Pattern: `^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$`, Description: "Ref: https://github.com/kubernetes/apimachinery/blob/master/pkg/api/resource/quantity.go#L147"},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.MonitoringStackResource.Memory":                   {Pattern: `^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$`},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.MonitoringStackResources.Limits":                  {Description: "Represents the max. amount of cpu/memory resources which would be accessible by the Prometheus instances spun up consequently by the MonitoringStack CR in runtime"},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.MonitoringStackResources.Request":                 {Description: "Represents the cpu/memory resources which would be requested by the Prometheus instances spun up consequently by the MonitoringStack CR in runtime"},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.Notification":                                     {Pattern: `^([A-Za-z -]+ <[0-9A-Za-z_.-]+@redhat\.com>,?)+$`},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.PagerDuty.AcknowledgeTimeout":                     {Required: true, Minimum: float64Ptr(0)},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.PagerDuty.EscalationPolicy":                       {Required: true, Pattern: `^[A-Za-z0-9]+$`},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.PagerDuty.ResolveTimeout":                         {Required: true, Minimum: float64Ptr(0)},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.PagerDuty.SecretName":                             {Required: true, Pattern: `^[A-Za-z0-9][A-Za-z0-9-]{0,60}[A-Za-z0-9]$`},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.PagerDuty.SecretNamespace":                        {Required: true, Pattern: `^[A-Za-z0-9][A-Za-z0-9-]{0,60}[A-Za-z0-9]$`},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.Secret.DestinationSecretName":                     {Optional: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.Secret.Name":                                      {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.Secret.Type":                                      {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.Secret.VaultPath":                                 {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.Tag":                                              {Pattern: `^[A-Za-z0-9][A-Za-z0-9-]{0,60}[A-Za-z0-9]$`},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.TargetSecretRef.Name":                             {Optional: true, Pattern: `^[A-Za-z0-9][A-Za-z0-9-]{0,60}[A-Za-z0-9]$`},
+	"github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1.TargetSecretRef.Namespace":                        {Optional: true, Pattern: `^[A-Za-z0-9][A-Za-z0-9-]{0,60}[A-Za-z0-9]$`},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnParameter.Conditions":                          {Optional: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnParameter.DefaultValue":                        {Optional: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnParameter.Description":                         {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnParameter.Editable":                            {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnParameter.Enabled":                             {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnParameter.ID":                                  {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnParameter.Name":                                {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnParameter.Options":                             {Optional: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnParameter.Order":                               {Optional: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnParameter.Required":                            {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnParameter.Validation":                          {Optional: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnParameter.ValidationErrMsg":                    {Optional: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnParameter.ValueType":                           {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnParameterOption.Name":                          {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnParameterOption.Value":                         {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnRequirement.Data":                              {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnRequirement.Enabled":                           {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnRequirement.ID":                                {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnRequirement.Resource":                          {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnRequirement.Status":                            {Optional: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnResourceRequirement.Data":                      {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnResourceRequirement.Resource":                  {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnResourceRequirement.Status":                    {Optional: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnResourceRequirementStatus.ErrorMsgs":           {Optional: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnResourceRequirementStatus.Fulfilled":           {Optional: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnSubOperator.Enabled":                           {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnSubOperator.OperatorName":                      {Required: true},
+	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1.AddOnSubOperator.OperatorNamespace":                 {Required: true},
+}
@@ -3,11 +3,15 @@ package types
 import (
 	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
 	op "github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/schema"
 )
 
 type MetaBundle struct {
 	AddonMeta *v1alpha1.AddonMetadataSpec
 	Bundles   []op.Bundle
+	// Positions optionally locates the fields of AddonMeta
+	// in the files it was loaded from.
+	Positions schema.Positions
 }
 
 func NewMetaBundle(addonMeta *v1alpha1.AddonMetadataSpec, bundles []op.Bundle) *MetaBundle {
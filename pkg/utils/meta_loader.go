@@ -7,15 +7,21 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
 	"sort"
 
 	log "github.com/sirupsen/logrus"
 
 	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/schema"
 )
 
 type MetaLoader interface {
 	Load() (*addonsv1alpha1.AddonMetadataSpec, error)
+	// LoadWithPositions behaves like Load but additionally returns the
+	// position in the metadata or imageset file of every field of the
+	// returned metadata.
+	LoadWithPositions() (*addonsv1alpha1.AddonMetadataSpec, schema.Positions, error)
 }
 
 type defaultMetaLoader struct {
@@ -23,24 +29,87 @@ type defaultMetaLoader struct {
 	AddonName string
 	Env       string
 	Version   string
+	Strict    bool
+}
+
+// MetaLoaderOption configures optional behavior of the default MetaLoader.
+type MetaLoaderOption func(*defaultMetaLoader)
+
+// WithStrictDecoding makes the MetaLoader report unknown fields, duplicate
+// keys and values of the wrong type in the metadata and imageset files.
+// Load then returns a '*schema.DecodeError' listing every issue with its
+// file, line and column. If the files could still be decoded leniently the
+// resulting metadata is returned alongside the error so that callers may
+// treat the issues as findings rather than as a fatal error.
+func WithStrictDecoding() MetaLoaderOption {
+	return func(l *defaultMetaLoader) { l.Strict = true }
 }
 
 // NewMetaLoader - returns default implementation of the AddonMetaLoader
-func NewMetaLoader(addonDir, env, version string) MetaLoader {
-	return defaultMetaLoader{
+func NewMetaLoader(addonDir, env, version string, opts ...MetaLoaderOption) MetaLoader {
+	l := defaultMetaLoader{
 		AddonDir:  addonDir,
 		AddonName: path.Base(addonDir),
 		Env:       env,
 		Version:   version,
 	}
+
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	return l
 }
 
 // Load - loads the addon metadata and imageSet
 func (l defaultMetaLoader) Load() (*addonsv1alpha1.AddonMetadataSpec, error) {
-	meta, err := l.readMeta()
+	meta, _, err := l.LoadWithPositions()
+
+	return meta, err
+}
+
+// LoadWithPositions - loads the addon metadata and imageSet and locates
+// their fields
+func (l defaultMetaLoader) LoadWithPositions() (*addonsv1alpha1.AddonMetadataSpec, schema.Positions, error) {
+	var st loadState
+
+	meta, err := l.load(&st)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(st.issues) > 0 {
+		return meta, st.positions, &schema.DecodeError{Issues: st.issues}
+	}
+
+	return meta, st.positions, nil
+}
+
+// imageSetFields are the fields of the addon metadata
+// which are replaced by those of the imageset.
+var imageSetFields = []string{
+	"indexImage",
+	"addOnParameters",
+	"addOnRequirements",
+	"subOperators",
+	"config",
+	"pullSecretName",
+	"additionalCatalogSources",
+}
+
+// loadState collects what is learned about the
+// metadata and imageset files while decoding them.
+type loadState struct {
+	issues    []schema.DecodeIssue
+	positions schema.Positions
+}
+
+func (l defaultMetaLoader) load(st *loadState) (*addonsv1alpha1.AddonMetadataSpec, error) {
+	meta, positions, err := l.readMeta(st)
 	if err != nil {
 		return nil, err
 	}
+	st.positions = positions
 	// invalid - legacy addon
 	if meta.IndexImage == nil && meta.ImageSetVersion == nil {
 		return nil, errors.New("No validation support for legacy addon. Please use the imageSet feature.")
@@ -51,49 +120,86 @@ func (l defaultMetaLoader) Load() (*addonsv1alpha1.AddonMetadataSpec, error) {
 	}
 	// imageSet
 	if meta.ImageSetVersion != nil {
-		imageSet, err := l.readImageSet(*meta.ImageSetVersion)
+		imageSet, positions, err := l.readImageSet(*meta.ImageSetVersion, st)
 		if err != nil {
-			return nil, fmt.Errorf("Could not read imageSet, got %v.\n", err)
+			return nil, fmt.Errorf("Could not read imageSet, got %w.\n", err)
 		}
 		combinedMeta, err := meta.CombineWithImageSet(imageSet)
 		if err != nil {
 			return nil, fmt.Errorf("Could not combine metadata and imageset, got %v.", err)
 		}
+		st.positions = st.positions.Overlay(positions.Select(imageSetFields...))
 		return combinedMeta, nil
 	}
 
 	return meta, nil
 }
 
-func (l defaultMetaLoader) readMeta() (*addonsv1alpha1.AddonMetadataSpec, error) {
-	data, err := os.ReadFile(l.getMetadataPath())
+func (l defaultMetaLoader) readMeta(st *loadState) (*addonsv1alpha1.AddonMetadataSpec, schema.Positions, error) {
+	metaPath := l.getMetadataPath()
+	data, err := os.ReadFile(metaPath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	log.Debugf("Raw metadata read from addon: %v. \n%v\n", l.AddonName, string(data))
 	meta := &addonsv1alpha1.AddonMetadataSpec{}
-	err = meta.FromYAML(data)
-	return meta, err
+	positions, err := l.decode(metaPath, data, meta, st)
+	return meta, positions, err
 }
 
 func (l defaultMetaLoader) getMetadataPath() string {
-	return filepath.Join(l.AddonDir, "metadata", l.Env, "addon.yaml")
+	return MetadataPath(l.AddonDir, l.Env)
+}
+
+// MetadataPath returns the path of the metadata file of
+// the addon in 'addonDir' for the given environment.
+func MetadataPath(addonDir, env string) string {
+	return filepath.Join(addonDir, "metadata", env, "addon.yaml")
 }
 
-func (l defaultMetaLoader) readImageSet(defaultVersion string) (*addonsv1alpha1.AddonImageSetSpec, error) {
+func (l defaultMetaLoader) readImageSet(defaultVersion string, st *loadState) (*addonsv1alpha1.AddonImageSetSpec, schema.Positions, error) {
 	version := l.getImageSetVersion(defaultVersion)
 	imageSetPath, err := l.getImagesetPath(version)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	data, err := os.ReadFile(imageSetPath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	log.Debugf("Raw imageSet read from addon: %v. \n%v\n", l.AddonName, string(data))
 	imageSet := &addonsv1alpha1.AddonImageSetSpec{}
-	err = imageSet.FromYAML(data)
-	return imageSet, err
+	positions, err := l.decode(imageSetPath, data, imageSet, st)
+	return imageSet, positions, err
+}
+
+type yamlDecoder interface {
+	FromYAML(data []byte) error
+}
+
+// decode leniently decodes 'data' into 'obj' and returns the positions
+// of its fields. In strict mode the issues found in the file are added
+// to the load state and, if lenient decoding fails as well, returned as
+// a '*schema.DecodeError' since they are more precise than the lenient
+// decoder's error.
+func (l defaultMetaLoader) decode(file string, data []byte, obj yamlDecoder, st *loadState) (schema.Positions, error) {
+	var found []schema.DecodeIssue
+
+	if l.Strict {
+		found = schema.CheckYAML(file, data, reflect.TypeOf(obj).Elem())
+	}
+
+	if err := obj.FromYAML(data); err != nil {
+		if len(found) > 0 {
+			return nil, &schema.DecodeError{Issues: found}
+		}
+
+		return nil, err
+	}
+
+	st.issues = append(st.issues, found...)
+
+	return schema.LocateYAML(file, data, reflect.TypeOf(obj).Elem()), nil
 }
 
 // defaultVersion == meta.ImageSetVersion
@@ -1,10 +1,15 @@
 package utils_test
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	"github.com/mt-sre/addon-metadata-operator/pkg/schema"
 	"github.com/mt-sre/addon-metadata-operator/pkg/utils"
 	"github.com/stretchr/testify/require"
 )
@@ -84,3 +89,138 @@ func TestMetaLoaderImageSet(t *testing.T) {
 		})
 	}
 }
+
+func TestMetaLoaderStrictDecoding(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		metadata       []string
+		strict         bool
+		expectedIssues []string
+		expectMeta     bool
+	}{
+		"lenient mode ignores unknown fields": {
+			metadata: []string{
+				"id: reference-addon",
+				"indexImage: quay.io/osd-addons/reference-addon-index@sha256:d9f95ecd",
+				"addonOwnr: MT-SRE Team <sd-mt-sre@redhat.com>",
+			},
+			expectMeta: true,
+		},
+		"strict mode reports unknown fields and duplicate keys": {
+			metadata: []string{
+				"id: reference-addon",
+				"indexImage: quay.io/osd-addons/reference-addon-index@sha256:d9f95ecd",
+				"addonOwnr: MT-SRE Team <sd-mt-sre@redhat.com>",
+				"id: reference-addon",
+			},
+			strict: true,
+			expectedIssues: []string{
+				"addon.yaml:3:1: addonOwnr: unknown field",
+				"addon.yaml:4:1: id: duplicate key, first defined at line 1",
+			},
+			expectMeta: true,
+		},
+		"strict mode reports type mismatches which fail lenient decoding": {
+			metadata: []string{
+				"id: reference-addon",
+				"indexImage: quay.io/osd-addons/reference-addon-index@sha256:d9f95ecd",
+				"ocmQuotaCost: one",
+			},
+			strict: true,
+			expectedIssues: []string{
+				`addon.yaml:3:15: ocmQuotaCost: expected integer, got string "one"`,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // pin
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addonDir := filepath.Join(t.TempDir(), "reference-addon")
+			metaDir := filepath.Join(addonDir, "metadata", "stage")
+			require.NoError(t, os.MkdirAll(metaDir, 0o755))
+			require.NoError(t, os.WriteFile(
+				filepath.Join(metaDir, "addon.yaml"),
+				[]byte(strings.Join(tc.metadata, "\n")),
+				0o644,
+			))
+
+			var opts []utils.MetaLoaderOption
+			if tc.strict {
+				opts = append(opts, utils.WithStrictDecoding())
+			}
+
+			meta, err := utils.NewMetaLoader(addonDir, "stage", "", opts...).Load()
+			if tc.expectMeta {
+				require.NotNil(t, meta)
+				require.Equal(t, "reference-addon", meta.ID)
+			} else {
+				require.Nil(t, meta)
+			}
+
+			if len(tc.expectedIssues) == 0 {
+				require.NoError(t, err)
+
+				return
+			}
+
+			var decodeErr *schema.DecodeError
+			require.True(t, errors.As(err, &decodeErr))
+
+			issues := make([]string, 0, len(decodeErr.Issues))
+			for _, i := range decodeErr.Issues {
+				issues = append(issues, strings.TrimPrefix(i.String(), metaDir+string(filepath.Separator)))
+			}
+			require.Equal(t, tc.expectedIssues, issues)
+		})
+	}
+}
+
+func TestMetaLoaderPositions(t *testing.T) {
+	t.Parallel()
+
+	addonDir := filepath.Join(t.TempDir(), "reference-addon")
+	metaDir := filepath.Join(addonDir, "metadata", "stage")
+	imageSetDir := filepath.Join(addonDir, "addonimagesets", "stage")
+	require.NoError(t, os.MkdirAll(metaDir, 0o755))
+	require.NoError(t, os.MkdirAll(imageSetDir, 0o755))
+
+	metaPath := filepath.Join(metaDir, "addon.yaml")
+	require.NoError(t, os.WriteFile(metaPath, []byte(strings.Join([]string{
+		"id: reference-addon",
+		"name: Reference Addon",
+		"addonImageSetVersion: 0.0.1",
+		"addOnParameters:",
+		"  - id: size",
+		"    name: Size",
+	}, "\n")), 0o644))
+
+	imageSetPath := filepath.Join(imageSetDir, "reference-addon.v0.0.1.yaml")
+	require.NoError(t, os.WriteFile(imageSetPath, []byte(strings.Join([]string{
+		"name: reference-addon.v0.0.1",
+		"indexImage: quay.io/osd-addons/reference-addon-index@sha256:d9f95ecd",
+		"relatedImages: []",
+		"addOnParameters:",
+		"  - id: replicas",
+		"    name: Replicas",
+	}, "\n")), 0o644))
+
+	meta, positions, err := utils.NewMetaLoader(addonDir, "stage", "").LoadWithPositions()
+	require.NoError(t, err)
+	require.Equal(t, "reference-addon", meta.ID)
+
+	for path, expected := range map[string]string{
+		"id":   metaPath + ":1:1",
+		"name": metaPath + ":2:1",
+		// fields overridden by the imageset point to the imageset
+		"indexImage":              imageSetPath + ":2:1",
+		"addOnParameters[0].name": imageSetPath + ":6:5",
+	} {
+		pos, ok := positions.Lookup(path)
+		require.True(t, ok, path)
+		require.Equal(t, expected, pos.String(), path)
+	}
+}
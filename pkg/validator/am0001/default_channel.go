@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	opsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/fix"
 	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
@@ -25,6 +26,7 @@ func NewDefaultChannel(deps validator.Dependencies) (validator.Validator, error)
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseRequires(validator.RequiresBundles),
 	)
 	if err != nil {
 		return nil, err
@@ -68,8 +70,9 @@ func (d *DefaultChannel) isPartOfEnum(defaultChannel string) validator.Result {
 		"fast":   {},
 	}
 	if _, ok := enum[defaultChannel]; !ok {
-		msg := fmt.Sprintf("The defaultChannel '%v' is not part of the accepted values: alpha, beta, stable, edge or rc.", defaultChannel)
-		return d.Fail(msg)
+		return d.FailWith(validator.NewFinding("defaultChannel",
+			"The defaultChannel '%v' is not part of the accepted values: alpha, beta, stable, edge or rc.", defaultChannel,
+		))
 	}
 	return d.Success()
 }
@@ -87,12 +90,13 @@ func (d *DefaultChannel) isListedInChannels(channels *[]opsv1alpha1.Channel, def
 		}
 		channelNames = append(channelNames, channel.Name)
 	}
-	msg := fmt.Sprintf("The defaultChannel '%v' is not part of the listed channelNames: %v.", defaultChannel, channelNames)
-	return d.Fail(msg)
+	return d.FailWith(validator.NewFinding("defaultChannel",
+		"The defaultChannel '%v' is not part of the listed channelNames: %v.", defaultChannel, channelNames,
+	))
 }
 
 func (d *DefaultChannel) matchesBundleChannelAnnotations(defaultChannel string, bundles []operator.Bundle) validator.Result {
-	var message []string
+	var findings []validator.Finding
 
 	bundle, ok := operator.HeadBundle(bundles...)
 	if !ok {
@@ -100,32 +104,42 @@ func (d *DefaultChannel) matchesBundleChannelAnnotations(defaultChannel string,
 	}
 
 	if bundle.Annotations.DefaultChannelName == "" && defaultChannel != "alpha" {
-		msg := fmt.Sprintf("operators.operatorframework.io.bundle.channel.default.v1 is not defined so defaultChannel should be 'alpha' instead of '%v'", defaultChannel)
-		message = append(message, msg)
+		findings = append(findings, validator.NewFinding("defaultChannel",
+			"operators.operatorframework.io.bundle.channel.default.v1 is not defined so defaultChannel should be 'alpha' instead of '%v'", defaultChannel,
+		).WithFix(setDefaultChannel("alpha")))
 	}
 
 	if bundle.Annotations.DefaultChannelName != defaultChannel && bundle.Annotations.DefaultChannelName != "" {
-		msg := fmt.Sprintf("The defaultChannel '%v' does not match annotation operators.operatorframework.io.bundle.channel.default.v1 '%v'.",
+		findings = append(findings, validator.NewFinding("defaultChannel",
+			"The defaultChannel '%v' does not match annotation operators.operatorframework.io.bundle.channel.default.v1 '%v'.",
 			defaultChannel, bundle.Annotations.DefaultChannelName,
-		)
-		message = append(message, msg)
+		).WithFix(setDefaultChannel(bundle.Annotations.DefaultChannelName)))
 	}
 
 	channels := bundle.Annotations.Channels
 
 	if !isPresentInBundleChannels(defaultChannel, channels) {
-		msg := fmt.Sprintf("The defaultChannel '%v' is not present in annotation operators.operatorframework.io.bundle.channels.v1 '%v'.",
+		findings = append(findings, validator.NewFinding("defaultChannel",
+			"The defaultChannel '%v' is not present in annotation operators.operatorframework.io.bundle.channels.v1 '%v'.",
 			defaultChannel, channels,
-		)
-		message = append(message, msg)
+		))
 	}
 
-	if len(message) > 0 {
-		return d.Fail(message...)
+	if len(findings) > 0 {
+		return d.FailWith(findings...)
 	}
 	return d.Success()
 }
 
+func setDefaultChannel(channel string) fix.Edit {
+	return fix.Edit{
+		Description: fmt.Sprintf("set defaultChannel to '%s'", channel),
+		Op:          fix.OpSet,
+		Path:        "defaultChannel",
+		Value:       channel,
+	}
+}
+
 func isPresentInBundleChannels(defaultChannel string, channels []string) bool {
 	for _, channel := range channels {
 		if channel == defaultChannel {
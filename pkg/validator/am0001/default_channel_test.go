@@ -1,12 +1,15 @@
 package am0001
 
 import (
+	"context"
 	"testing"
 
 	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/fix"
 	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -118,3 +121,34 @@ func TestDefaultChannelInvalid(t *testing.T) {
 		},
 	})
 }
+
+func TestDefaultChannelSuggestsFix(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewDefaultChannel)
+
+	res := tester.Val.Run(context.Background(), types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{
+			ID:             "random-operator",
+			DefaultChannel: "alpha",
+		},
+		Bundles: []operator.Bundle{
+			{
+				Annotations: operator.Annotations{
+					DefaultChannelName: "beta",
+					Channels:           []string{"beta", "alpha"},
+				},
+			},
+		},
+	})
+	require.False(t, res.IsSuccess())
+	require.Len(t, res.Findings, 1)
+	require.NotNil(t, res.Findings[0].Fix)
+
+	assert.Equal(t, fix.Edit{
+		Description: "set defaultChannel to 'beta'",
+		Op:          fix.OpSet,
+		Path:        "defaultChannel",
+		Value:       "beta",
+	}, *res.Findings[0].Fix)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/mt-sre/addon-metadata-operator/pkg/fix"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
 )
@@ -39,9 +40,15 @@ type AddonLabel struct {
 
 func (a *AddonLabel) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
 	operatorId, label := mb.AddonMeta.ID, mb.AddonMeta.Label
-	if label != "api.openshift.com/addon-"+operatorId {
-		msg := fmt.Sprintf("addon label '%s' wasn't recognized to follow the 'api.openshift.com/addon-<id>' format", label)
-		return a.Fail(msg)
+	if expected := "api.openshift.com/addon-" + operatorId; label != expected {
+		return a.FailWith(validator.NewFinding("label",
+			"addon label '%s' wasn't recognized to follow the 'api.openshift.com/addon-<id>' format", label,
+		).WithFix(fix.Edit{
+			Description: fmt.Sprintf("set label to '%s'", expected),
+			Op:          fix.OpSet,
+			Path:        "label",
+			Value:       expected,
+		}))
 	}
 
 	return a.Success()
@@ -1,11 +1,15 @@
 package am0002
 
 import (
+	"context"
 	"testing"
 
 	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/fix"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAddonLabelValid(t *testing.T) {
@@ -44,3 +48,26 @@ func TestAddonLabelInvalid(t *testing.T) {
 		},
 	})
 }
+
+func TestAddonLabelSuggestsFix(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewAddonLabel)
+
+	res := tester.Val.Run(context.Background(), types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{
+			ID:    "random-operator",
+			Label: "foo-bar",
+		},
+	})
+	require.False(t, res.IsSuccess())
+	require.Len(t, res.Findings, 1)
+	require.NotNil(t, res.Findings[0].Fix)
+
+	assert.Equal(t, fix.Edit{
+		Description: "set label to 'api.openshift.com/addon-random-operator'",
+		Op:          fix.OpSet,
+		Path:        "label",
+		Value:       "api.openshift.com/addon-random-operator",
+	}, *res.Findings[0].Fix)
+}
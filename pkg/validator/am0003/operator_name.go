@@ -26,6 +26,7 @@ func NewOperatorName(deps validator.Dependencies) (validator.Validator, error) {
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseRequires(validator.RequiresBundles),
 	)
 	if err != nil {
 		return nil, err
@@ -54,7 +55,7 @@ func (o *OperatorName) Run(ctx context.Context, mb types.MetaBundle) validator.R
 	}
 
 	if len(failures) > 0 {
-		return o.Fail(failures...)
+		return o.FailWith(validator.FindingsAt("operatorName", failures...)...)
 	}
 
 	return o.Success()
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
-	"fmt"
 	"image/png"
 
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
@@ -44,17 +43,17 @@ type IconBase64 struct {
 func (i *IconBase64) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
 	icon := mb.AddonMeta.Icon
 	if icon == "" {
-		return i.Fail(fmt.Sprintf("`icon` not found under the addon metadata of %s", mb.AddonMeta.ID))
+		return i.FailWith(validator.NewFinding("icon", "`icon` not found under the addon metadata of %s", mb.AddonMeta.ID))
 	}
 
 	b64decoded, err := base64.StdEncoding.DecodeString(icon)
 	if err != nil {
-		return i.Fail(fmt.Sprintf("`icon` found to be improperly base64 populated under the addon metadata of %s", mb.AddonMeta.ID))
+		return i.FailWith(validator.NewFinding("icon", "`icon` found to be improperly base64 populated under the addon metadata of %s", mb.AddonMeta.ID))
 	}
 
 	_, err = png.Decode(bytes.NewReader(b64decoded))
 	if err != nil {
-		return i.Fail(fmt.Sprintf("`icon`'s base64 value found to correspond to a non-png data under the addon metadata of %s", mb.AddonMeta.ID))
+		return i.FailWith(validator.NewFinding("icon", "`icon`'s base64 value found to correspond to a non-png data under the addon metadata of %s", mb.AddonMeta.ID))
 	}
 
 	return i.Success()
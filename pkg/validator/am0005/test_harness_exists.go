@@ -2,7 +2,6 @@ package am0005
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
@@ -24,6 +23,7 @@ func NewTestHarnessExists(deps validator.Dependencies) (validator.Validator, err
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(description),
+		validator.BaseRequires(validator.RequiresNetwork),
 	)
 	if err != nil {
 		return nil, err
@@ -43,11 +43,11 @@ type TestHarnessExists struct {
 func (t *TestHarnessExists) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
 	ref, err := imageparser.Parse(mb.AddonMeta.TestHarness)
 	if err != nil {
-		return t.Fail("Failed to parse testharness url")
+		return t.FailWith(validator.NewFinding("testHarness", "Failed to parse testharness url"))
 	}
 
 	if ref.Registry() != "quay.io" {
-		return t.Fail("Testharness image is not in the quay.io registry")
+		return t.FailWith(validator.NewFinding("testHarness", "Testharness image is not in the quay.io registry"))
 	}
 
 	ok, err := t.quay.HasReference(ctx, ref)
@@ -56,7 +56,7 @@ func (t *TestHarnessExists) Run(ctx context.Context, mb types.MetaBundle) valida
 	}
 
 	if !ok {
-		return t.Fail(fmt.Sprintf("The testharness image %q does not exist", ref.Name()))
+		return t.FailWith(validator.NewFinding("testHarness", "The testharness image %q does not exist", ref.Name()))
 	}
 
 	return t.Success()
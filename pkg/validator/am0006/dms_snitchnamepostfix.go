@@ -2,7 +2,6 @@ package am0006
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
@@ -41,7 +40,9 @@ func (d *DMSSnitchNamePostFix) Run(ctx context.Context, mb types.MetaBundle) val
 		return d.Success()
 	}
 	if strings.HasPrefix(*dmsConf.SnitchNamePostFix, "hive-") {
-		return d.Fail(fmt.Sprintf("`deadmanssnitch.snitchNamePostFix` in addon %s found to begin with 'hive-'", mb.AddonMeta.ID))
+		return d.FailWith(validator.NewFinding("deadmanssnitch.snitchNamePostFix",
+			"`deadmanssnitch.snitchNamePostFix` in addon %s found to begin with 'hive-'", mb.AddonMeta.ID,
+		))
 	}
 	return d.Success()
 }
@@ -24,6 +24,8 @@ func NewCSVInstallModes(deps validator.Dependencies) (validator.Validator, error
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseRequires(validator.RequiresBundles),
+		validator.BasePrerequisites(21),
 	)
 	if err != nil {
 		return nil, err
@@ -43,7 +45,7 @@ func (c *CSVInstallModes) Run(ctx context.Context, mb types.MetaBundle) validato
 
 	// allow only AllNamespaces and OwnNamespace install mode.
 	if indexOf(installMode, validInstallModes) == -1 {
-		return c.Fail(fmt.Sprintf("unsupported install mode %v", installMode))
+		return c.FailWith(validator.NewFinding("installMode", "unsupported install mode %v", installMode))
 	}
 
 	for _, bundle := range mb.Bundles {
@@ -53,7 +55,7 @@ func (c *CSVInstallModes) Run(ctx context.Context, mb types.MetaBundle) validato
 		)
 
 		if success, failureMsg := isInstallModeSupported(modes, installMode); !success {
-			return c.Fail(fmt.Sprintf("Bundle %v failed CSV validation: %v.", bundleName, failureMsg))
+			return c.FailWith(validator.NewFinding("installMode", "Bundle %v failed CSV validation: %v.", bundleName, failureMsg))
 		}
 	}
 	return c.Success()
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 
+	"github.com/mt-sre/addon-metadata-operator/pkg/fix"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
 	"golang.org/x/exp/slices"
@@ -46,12 +47,19 @@ func (n *Namespace) Run(ctx context.Context, mb types.MetaBundle) validator.Resu
 	namespaceList := mb.AddonMeta.Namespaces
 	valid := validateNamespacePresence(targetNamespace, namespaceList, namespacePresenceExceptions())
 	if !valid {
-		return n.Fail("Target namespace is not in the list of supplied namespaces")
+		return n.FailWith(validator.NewFinding("targetNamespace",
+			"Target namespace is not in the list of supplied namespaces",
+		).WithFix(fix.Edit{
+			Description: fmt.Sprintf("add targetNamespace '%s' to namespaces", targetNamespace),
+			Op:          fix.OpAppend,
+			Path:        "namespaces",
+			Value:       targetNamespace,
+		}))
 	}
 
 	allValid, failedNamespaces := validateNamespaceRegex(namespaceList, n.ExcludedNamespaces)
 	if !allValid {
-		return n.Fail(fmt.Sprintf("Some namespaces doesn't start with 'redhat-*' %v", failedNamespaces))
+		return n.FailWith(validator.NewFinding("namespaces", "Some namespaces doesn't start with 'redhat-*' %v", failedNamespaces))
 	}
 	return n.Success()
 }
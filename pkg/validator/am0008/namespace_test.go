@@ -1,11 +1,14 @@
 package am0008
 
 import (
+	"context"
 	"testing"
 
 	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/fix"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	utils "github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -60,3 +63,27 @@ func TestNamespaceInvalid(t *testing.T) {
 		},
 	})
 }
+
+func TestNamespaceSuggestsFix(t *testing.T) {
+	t.Parallel()
+
+	tester := utils.NewValidatorTester(t, NewNamespace)
+
+	res := tester.Val.Run(context.Background(), types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{
+			ID:              "random-operator",
+			TargetNamespace: "redhat-other-operator",
+			Namespaces:      []string{"redhat-random-operator"},
+		},
+	})
+	require.False(t, res.IsSuccess())
+	require.Len(t, res.Findings, 1)
+	require.NotNil(t, res.Findings[0].Fix)
+
+	assert.Equal(t, fix.Edit{
+		Description: "add targetNamespace 'redhat-other-operator' to namespaces",
+		Op:          fix.OpAppend,
+		Path:        "namespaces",
+		Value:       "redhat-other-operator",
+	}, *res.Findings[0].Fix)
+}
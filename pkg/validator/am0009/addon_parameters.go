@@ -43,13 +43,14 @@ func (a *AddonParamters) Run(ctx context.Context, mb types.MetaBundle) validator
 	if addonParams == nil {
 		return a.Success()
 	}
-	for _, param := range *addonParams {
+	for i, param := range *addonParams {
+		path := fmt.Sprintf("addOnParameters[%d]", i)
 		validation := param.Validation
 		options := param.Options
 		defaultValue := param.DefaultValue
 
 		if validation != nil && options != nil {
-			return a.Fail("validation and options can't both be set")
+			return a.FailWith(validator.NewFinding(path+".validation", "validation and options can't both be set"))
 		}
 
 		if defaultValue != nil {
@@ -61,9 +62,9 @@ func (a *AddonParamters) Run(ctx context.Context, mb types.MetaBundle) validator
 				if !r.MatchString(*defaultValue) {
 					msg := fmt.Sprintf("defaultValue %s didn't match its validation", *defaultValue)
 					if param.ValidationErrMsg != nil {
-						return a.Fail(fmt.Sprintf("%s: %s", msg, *param.ValidationErrMsg))
+						msg = fmt.Sprintf("%s: %s", msg, *param.ValidationErrMsg)
 					}
-					return a.Fail(msg)
+					return a.FailWith(validator.NewFinding(path+".defaultValue", "%s", msg))
 				}
 				return a.Success()
 			}
@@ -74,7 +75,7 @@ func (a *AddonParamters) Run(ctx context.Context, mb types.MetaBundle) validator
 						return a.Success()
 					}
 				}
-				return a.Fail(fmt.Sprintf("defaultValue '%s' not found in `options`", *defaultValue))
+				return a.FailWith(validator.NewFinding(path+".defaultValue", "defaultValue '%s' not found in `options`", *defaultValue))
 			}
 		}
 	}
@@ -2,7 +2,6 @@ package am0010
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/mt-sre/addon-metadata-operator/internal/kube"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
@@ -39,7 +38,7 @@ type K8SResourceAndFieldNames struct {
 }
 
 func (k *K8SResourceAndFieldNames) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
-	subValidators := []func(types.MetaBundle) []string{
+	subValidators := []func(types.MetaBundle) []validator.Finding{
 		validateLabel,
 		validateTargetNamespace,
 		validateAllNamespaces,
@@ -48,41 +47,41 @@ func (k *K8SResourceAndFieldNames) Run(ctx context.Context, mb types.MetaBundle)
 		validatePagerDutyFields,
 	}
 
-	var msgs []string
+	var findings []validator.Finding
 
 	for _, v := range subValidators {
-		msgs = append(msgs, v(mb)...)
+		findings = append(findings, v(mb)...)
 	}
 
-	if len(msgs) > 0 {
-		return k.Fail(msgs...)
+	if len(findings) > 0 {
+		return k.FailWith(findings...)
 	}
 
 	return k.Success()
 }
 
-func validateLabel(mb types.MetaBundle) (failures []string) {
+func validateLabel(mb types.MetaBundle) (failures []validator.Finding) {
 	if msg := kube.IsValidk8sLabelName(mb.AddonMeta.Label); msg != "" {
-		failures = append(failures, prefixedFailureMsg("label", msg))
+		failures = append(failures, prefixedFinding("label", msg))
 	}
 
 	return failures
 }
 
-func validateTargetNamespace(mb types.MetaBundle) (failures []string) {
+func validateTargetNamespace(mb types.MetaBundle) (failures []validator.Finding) {
 	if msg := kube.IsValidk8sNamespaceName(mb.AddonMeta.TargetNamespace); msg != "" {
-		failures = append(failures, prefixedFailureMsg("targetNamespace", msg))
+		failures = append(failures, prefixedFinding("targetNamespace", msg))
 	}
 
 	return failures
 }
 
-func validateAllNamespaces(mb types.MetaBundle) []string {
-	var result []string
+func validateAllNamespaces(mb types.MetaBundle) []validator.Finding {
+	var result []validator.Finding
 
 	if msgs := kube.AreValidk8sNamespaceNames(mb.AddonMeta.Namespaces...); len(msgs) > 0 {
 		for _, msg := range msgs {
-			result = append(result, prefixedFailureMsg(
+			result = append(result, prefixedFinding(
 				"namespaces", msg,
 			))
 		}
@@ -90,7 +89,7 @@ func validateAllNamespaces(mb types.MetaBundle) []string {
 
 	if msgs := kube.AreValidk8sAnnotationNames(keys(mb.AddonMeta.NamespaceAnnotations)...); len(msgs) > 0 {
 		for _, msg := range msgs {
-			result = append(result, prefixedFailureMsg(
+			result = append(result, prefixedFinding(
 				"namespaceAnnotations", msg,
 			))
 		}
@@ -98,7 +97,7 @@ func validateAllNamespaces(mb types.MetaBundle) []string {
 
 	if msgs := kube.AreValidk8sLabelNames(keys(mb.AddonMeta.NamespaceLabels)...); len(msgs) > 0 {
 		for _, msg := range msgs {
-			result = append(result, prefixedFailureMsg(
+			result = append(result, prefixedFinding(
 				"namespaceLabels", msg,
 			))
 		}
@@ -107,18 +106,18 @@ func validateAllNamespaces(mb types.MetaBundle) []string {
 	return result
 }
 
-func validateCommonAnnotations(mb types.MetaBundle) []string {
+func validateCommonAnnotations(mb types.MetaBundle) []validator.Finding {
 	if mb.AddonMeta.CommonAnnotations == nil {
-		return []string{}
+		return []validator.Finding{}
 	}
 
 	annotations := *mb.AddonMeta.CommonAnnotations
 
-	result := make([]string, 0, len(annotations))
+	result := make([]validator.Finding, 0, len(annotations))
 
 	if msgs := kube.AreValidk8sAnnotationNames(keys(annotations)...); len(msgs) > 0 {
 		for _, msg := range msgs {
-			result = append(result, prefixedFailureMsg(
+			result = append(result, prefixedFinding(
 				"commonAnnotations", msg,
 			))
 		}
@@ -127,18 +126,18 @@ func validateCommonAnnotations(mb types.MetaBundle) []string {
 	return result
 }
 
-func validateCommonLabels(mb types.MetaBundle) []string {
+func validateCommonLabels(mb types.MetaBundle) []validator.Finding {
 	if mb.AddonMeta.CommonLabels == nil {
-		return []string{}
+		return []validator.Finding{}
 	}
 
 	labels := *mb.AddonMeta.CommonLabels
 
-	result := make([]string, 0, len(labels))
+	result := make([]validator.Finding, 0, len(labels))
 
 	if msgs := kube.AreValidk8sLabelNames(keys(labels)...); len(msgs) > 0 {
 		for _, msg := range msgs {
-			result = append(result, prefixedFailureMsg(
+			result = append(result, prefixedFinding(
 				"commonLabels", msg,
 			))
 		}
@@ -147,23 +146,23 @@ func validateCommonLabels(mb types.MetaBundle) []string {
 	return result
 }
 
-func validatePagerDutyFields(mb types.MetaBundle) []string {
+func validatePagerDutyFields(mb types.MetaBundle) []validator.Finding {
 	pd := mb.AddonMeta.PagerDuty
 	if pd == nil {
-		return []string{}
+		return []validator.Finding{}
 	}
 
-	var msgs []string
+	var findings []validator.Finding
 
 	if msg := kube.IsValidk8sNamespaceName(pd.SecretNamespace); msg != "" {
-		msgs = append(msgs, msg)
+		findings = append(findings, validator.Finding{Message: msg, Path: "pagerduty.secretNamespace"})
 	}
 
 	if msg := kube.IsValidk8sSecretName(pd.SecretName); msg != "" {
-		msgs = append(msgs, msg)
+		findings = append(findings, validator.Finding{Message: msg, Path: "pagerduty.secretName"})
 	}
 
-	return msgs
+	return findings
 }
 
 func keys(m map[string]string) []string {
@@ -176,6 +175,8 @@ func keys(m map[string]string) []string {
 	return keys
 }
 
-func prefixedFailureMsg(prefix string, msg string) string {
-	return string(fmt.Sprintf("%s: %s", prefix, msg))
+// prefixedFinding returns a Finding for 'field'
+// whose message is prefixed with the field name.
+func prefixedFinding(field string, msg string) validator.Finding {
+	return validator.NewFinding(field, "%s: %s", field, msg)
 }
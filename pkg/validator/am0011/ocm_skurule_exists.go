@@ -2,7 +2,6 @@ package am0011
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
@@ -23,6 +22,7 @@ func NewOCMSKURuleExists(deps validator.Dependencies) (validator.Validator, erro
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseRequires(validator.RequiresNetwork),
 	)
 	if err != nil {
 		return nil, err
@@ -52,7 +52,7 @@ func (o *OCMSKURuleExists) Run(ctx context.Context, mb types.MetaBundle) validat
 	}
 
 	if !quotaRuleExists {
-		return o.Fail(fmt.Sprintf("no QuotaRule exists for ocmQuotaName '%s'", quotaName))
+		return o.FailWith(validator.NewFinding("ocmQuotaName", "no QuotaRule exists for ocmQuotaName '%s'", quotaName))
 	}
 
 	return o.Success()
@@ -25,6 +25,8 @@ func NewCSVRBAC(opt validator.Dependencies) (validator.Validator, error) {
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseRequires(validator.RequiresBundles),
+		validator.BasePrerequisites(21),
 	)
 	if err != nil {
 		return nil, err
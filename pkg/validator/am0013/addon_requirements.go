@@ -44,17 +44,19 @@ func (a *AddonRequirements) Run(ctx context.Context, mb types.MetaBundle) valida
 		return a.Success()
 	}
 
-	var msgs []string
+	var findings []validator.Finding
 
-	for _, r := range *requirements {
+	for i, r := range *requirements {
 		if len(r.Data) == 0 {
-			msgs = append(msgs, fmt.Sprintf("requirement %q has no data", r.ID))
+			findings = append(findings, validator.NewFinding(
+				fmt.Sprintf("addOnRequirements[%d].data", i), "requirement %q has no data", r.ID,
+			))
 		}
 	}
 
-	if len(msgs) == 0 {
+	if len(findings) == 0 {
 		return a.Success()
 	}
 
-	return a.Fail(msgs...)
+	return a.FailWith(findings...)
 }
@@ -25,6 +25,9 @@ func NewCSVDeployment(deps validator.Dependencies) (validator.Validator, error)
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseSeverity(validator.SeverityWarning),
+		validator.BaseRequires(validator.RequiresBundles),
+		validator.BasePrerequisites(21),
 	)
 	if err != nil {
 		return nil, err
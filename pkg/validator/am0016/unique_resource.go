@@ -39,31 +39,31 @@ type UniqueResource struct {
 }
 
 func (u *UniqueResource) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
-	var messages []string
+	var findings []validator.Finding
 
 	acsMessage := UniqueAdditionalCataloSources(mb.AddonMeta)
 	if len(acsMessage) > 0 {
-		messages = append(messages, acsMessage...)
+		findings = append(findings, acsMessage...)
 	}
 
 	secretMessage := UniqueSecrets(mb.AddonMeta)
 	if len(secretMessage) > 0 {
-		messages = append(messages, secretMessage...)
+		findings = append(findings, secretMessage...)
 	}
 
 	credentialRequestMessage := UniqueCredentialRequests(mb.AddonMeta)
 	if len(credentialRequestMessage) > 0 {
-		messages = append(messages, credentialRequestMessage...)
+		findings = append(findings, credentialRequestMessage...)
 	}
 
-	if len(messages) > 0 {
-		return u.Fail(messages...)
+	if len(findings) > 0 {
+		return u.FailWith(findings...)
 	}
 	return u.Success()
 }
 
-func UniqueAdditionalCataloSources(addonMetadataSpec *v1alpha1.AddonMetadataSpec) []string {
-	var messages []string
+func UniqueAdditionalCataloSources(addonMetadataSpec *v1alpha1.AddonMetadataSpec) []validator.Finding {
+	var messages []validator.Finding
 
 	additionalCatalogSources := addonMetadataSpec.AdditionalCatalogSources
 	if additionalCatalogSources == nil {
@@ -74,13 +74,14 @@ func UniqueAdditionalCataloSources(addonMetadataSpec *v1alpha1.AddonMetadataSpec
 	}
 
 	catalogSourceNames := make(map[string]bool)
-	for _, additionalCatalogSource := range *additionalCatalogSources {
+	for i, additionalCatalogSource := range *additionalCatalogSources {
 		catalogSourceName := additionalCatalogSource.Name
 
 		if catalogSourceNames[catalogSourceName] {
-			messages = append(messages,
-				fmt.Sprintf("additional catalaog source: additionalCatalogSource name %v is already present and not unique.", catalogSourceName),
-			)
+			messages = append(messages, validator.NewFinding(
+				fmt.Sprintf("additionalCatalogSources[%d].name", i),
+				"additional catalaog source: additionalCatalogSource name %v is already present and not unique.", catalogSourceName,
+			))
 		} else {
 			catalogSourceNames[catalogSourceName] = true
 		}
@@ -88,8 +89,8 @@ func UniqueAdditionalCataloSources(addonMetadataSpec *v1alpha1.AddonMetadataSpec
 	return messages
 }
 
-func UniqueSecrets(addonMetadataSpec *v1alpha1.AddonMetadataSpec) []string {
-	var messages []string
+func UniqueSecrets(addonMetadataSpec *v1alpha1.AddonMetadataSpec) []validator.Finding {
+	var messages []validator.Finding
 
 	config := addonMetadataSpec.Config
 	// if config is nil
@@ -106,11 +107,14 @@ func UniqueSecrets(addonMetadataSpec *v1alpha1.AddonMetadataSpec) []string {
 	}
 
 	secretNames := make(map[string]bool)
-	for _, secret := range *secrets {
+	for i, secret := range *secrets {
 		secretName := secret.Name
 
 		if secretNames[secretName] {
-			messages = append(messages, fmt.Sprintf("secrets: secret name %v is already present and not unique.", secretName))
+			messages = append(messages, validator.NewFinding(
+				fmt.Sprintf("config.secrets[%d].name", i),
+				"secrets: secret name %v is already present and not unique.", secretName,
+			))
 		} else {
 			secretNames[secretName] = true
 		}
@@ -119,8 +123,8 @@ func UniqueSecrets(addonMetadataSpec *v1alpha1.AddonMetadataSpec) []string {
 	return messages
 }
 
-func UniqueCredentialRequests(addonMetadataSpec *v1alpha1.AddonMetadataSpec) []string {
-	var messages []string
+func UniqueCredentialRequests(addonMetadataSpec *v1alpha1.AddonMetadataSpec) []validator.Finding {
+	var messages []validator.Finding
 
 	credentialRequests := addonMetadataSpec.CredentialsRequests
 	if credentialRequests == nil {
@@ -131,13 +135,14 @@ func UniqueCredentialRequests(addonMetadataSpec *v1alpha1.AddonMetadataSpec) []s
 	}
 
 	credentialNames := make(map[string]bool)
-	for _, credential := range *credentialRequests {
+	for i, credential := range *credentialRequests {
 		crName := credential.Name
 
 		if credentialNames[crName] {
-			messages = append(messages,
-				fmt.Sprintf("credential requests: credentialRequest name %v is already present and not unique", crName),
-			)
+			messages = append(messages, validator.NewFinding(
+				fmt.Sprintf("credentialsRequests[%d].name", i),
+				"credential requests: credentialRequest name %v is already present and not unique", crName,
+			))
 		} else {
 			credentialNames[crName] = true
 		}
@@ -45,13 +45,15 @@ func (p *PullSecretname) Run(ctx context.Context, mb types.MetaBundle) validator
 	config := mb.AddonMeta.Config
 	// if config is nil
 	if config == nil {
-		return p.Fail(fmt.Sprintf("pullSecretName %v is present in addon.yaml whereas addon config is nil", pullSecretName))
+		return p.FailWith(validator.NewFinding("pullSecretName", "pullSecretName %v is present in addon.yaml whereas addon config is nil", pullSecretName))
 	}
 
 	secrets := config.Secrets
 	// if secrets is nil
 	if secrets == nil {
-		return p.Fail(fmt.Sprintf("pullSecretName %v is present in addon.yaml whereas addon secrets are nil", pullSecretName))
+		return p.FailWith(validator.NewFinding("pullSecretName",
+			"pullSecretName %v is present in addon.yaml whereas addon secrets are nil; %s", pullSecretName, suggestion(pullSecretName),
+		))
 	}
 
 	for _, secret := range *secrets {
@@ -59,5 +61,13 @@ func (p *PullSecretname) Run(ctx context.Context, mb types.MetaBundle) validator
 			return p.Success()
 		}
 	}
-	return p.Fail(fmt.Sprintf("pullSecretName %v is not present in addon secrets", pullSecretName))
+	return p.FailWith(validator.NewFinding("pullSecretName",
+		"pullSecretName %v is not present in addon secrets; %s", pullSecretName, suggestion(pullSecretName),
+	))
+}
+
+// suggestion describes how to resolve a missing pull secret. No fix is
+// suggested as the type and vault path of the secret are not known.
+func suggestion(secretName string) string {
+	return fmt.Sprintf("add a secret named '%s' with its type and vaultPath to config.secrets", secretName)
 }
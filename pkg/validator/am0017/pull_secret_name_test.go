@@ -1,12 +1,15 @@
 package am0017
 
 import (
+	"context"
 	"testing"
 
 	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
 	mtsrev1 "github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewPullSecretnameValid(t *testing.T) {
@@ -100,3 +103,28 @@ func TestNewPullSecretnameInvalid(t *testing.T) {
 		},
 	})
 }
+
+func TestNewPullSecretnameSuggestsSecret(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewPullSecretname)
+
+	res := tester.Val.Run(context.Background(), types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{
+			ID:             "random-operator",
+			PullSecretName: "test-pull-secret",
+			Config: &mtsrev1.Config{
+				Secrets: &[]mtsrev1.Secret{{Name: "other-secret"}},
+			},
+		},
+	})
+	require.False(t, res.IsSuccess())
+	require.Len(t, res.Findings, 1)
+
+	assert.Nil(t, res.Findings[0].Fix, "the type and vault path of the secret are unknown")
+	assert.Equal(t,
+		"pullSecretName test-pull-secret is not present in addon secrets; "+
+			"add a secret named 'test-pull-secret' with its type and vaultPath to config.secrets",
+		res.Findings[0].Message,
+	)
+}
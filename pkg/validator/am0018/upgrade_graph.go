@@ -0,0 +1,368 @@
+package am0018
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+func init() {
+	validator.Register(NewUpgradeGraph)
+}
+
+const (
+	code = 18
+	name = "upgrade_graph"
+	desc = "Ensure the replaces/skips/skipRange upgrade graph of every channel is connected, acyclic and has a single head"
+
+	skipRangeAnnotation = "olm.skipRange"
+)
+
+func NewUpgradeGraph(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseRequires(validator.RequiresBundles),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpgradeGraph{
+		Base: base,
+	}, nil
+}
+
+type UpgradeGraph struct {
+	*validator.Base
+}
+
+func (u *UpgradeGraph) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	index := make(map[string]struct{}, len(mb.Bundles))
+
+	for _, b := range mb.Bundles {
+		index[b.ClusterServiceVersion.Name] = struct{}{}
+	}
+
+	var failures []string
+
+	for _, b := range mb.Bundles {
+		raw := b.ClusterServiceVersion.Annotations[skipRangeAnnotation]
+		if raw == "" {
+			continue
+		}
+
+		if _, err := semver.ParseRange(raw); err != nil {
+			failures = append(failures, fmt.Sprintf(
+				"bundle %q has an invalid %s annotation %q: %v", b.ClusterServiceVersion.Name, skipRangeAnnotation, raw, err,
+			))
+		}
+	}
+
+	for _, ch := range channelGraphs(mb.Bundles) {
+		failures = append(failures, ch.validate(index)...)
+	}
+
+	if len(failures) > 0 {
+		return u.Fail(failures...)
+	}
+
+	return u.Success()
+}
+
+// channelGraph is the upgrade graph of a single channel. Nodes are
+// CSV names and an edge points from a bundle to every bundle it
+// replaces, skips or includes in its skipRange. Like OLM, a skipRange
+// only includes bundles older than the bundle declaring it.
+type channelGraph struct {
+	name    string
+	bundles map[string]operator.Bundle
+	edges   map[string][]string
+}
+
+// channelGraphs groups the given bundles by channel and
+// returns the resulting graphs ordered by channel name.
+func channelGraphs(bundles []operator.Bundle) []*channelGraph {
+	graphs := make(map[string]*channelGraph)
+
+	for _, b := range bundles {
+		for _, ch := range bundleChannels(b) {
+			g, ok := graphs[ch]
+			if !ok {
+				g = &channelGraph{
+					name:    ch,
+					bundles: make(map[string]operator.Bundle),
+					edges:   make(map[string][]string),
+				}
+				graphs[ch] = g
+			}
+
+			g.bundles[b.ClusterServiceVersion.Name] = b
+		}
+	}
+
+	res := make([]*channelGraph, 0, len(graphs))
+
+	for _, g := range graphs {
+		g.buildEdges()
+
+		res = append(res, g)
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].name < res[j].name })
+
+	return res
+}
+
+// bundleChannels returns the channels of 'b' as resolved from the index
+// image. The channels annotation of the bundle is only used for bundles
+// without index information, e.g. those loaded from a local directory,
+// since the index may add a bundle to channels it does not annotate.
+func bundleChannels(b operator.Bundle) []string {
+	if len(b.Channels) > 0 {
+		return b.Channels
+	}
+
+	var res []string
+
+	for _, ch := range b.Annotations.Channels {
+		if ch = strings.TrimSpace(ch); ch != "" {
+			res = append(res, ch)
+		}
+	}
+
+	return res
+}
+
+func (g *channelGraph) buildEdges() {
+	for name, b := range g.bundles {
+		spec := b.ClusterServiceVersion.Spec
+
+		var targets []string
+
+		if spec.Replaces != "" {
+			targets = append(targets, spec.Replaces)
+		}
+
+		targets = append(targets, spec.Skips...)
+
+		targets = append(targets, g.skipRangeTargets(b)...)
+
+		for _, t := range targets {
+			if _, ok := g.bundles[t]; ok && t != name {
+				g.edges[name] = append(g.edges[name], t)
+			}
+		}
+
+		sort.Strings(g.edges[name])
+	}
+}
+
+// skipRangeTargets returns the bundles of the channel which are older than
+// 'b' and within its skipRange. Invalid skipRanges are reported by Run.
+func (g *channelGraph) skipRangeTargets(b operator.Bundle) []string {
+	raw := b.ClusterServiceVersion.Annotations[skipRangeAnnotation]
+	if raw == "" {
+		return nil
+	}
+
+	inRange, err := semver.ParseRange(raw)
+	if err != nil {
+		return nil
+	}
+
+	own, err := semver.ParseTolerant(b.Version)
+	if err != nil {
+		return nil
+	}
+
+	var targets []string
+
+	for other, ob := range g.bundles {
+		ver, err := semver.ParseTolerant(ob.Version)
+		if err != nil {
+			continue
+		}
+
+		if ver.LT(own) && inRange(ver) {
+			targets = append(targets, other)
+		}
+	}
+
+	return targets
+}
+
+func (g *channelGraph) validate(index map[string]struct{}) []string {
+	var msgs []string
+
+	msgs = append(msgs, g.danglingReplaces(index)...)
+	msgs = append(msgs, g.cycles()...)
+
+	heads, orphans := g.headsAndOrphans()
+
+	for _, o := range orphans {
+		msgs = append(msgs, fmt.Sprintf(
+			"channel %q: bundle %q is orphaned; it neither replaces nor is replaced by any other bundle in the channel", g.name, o,
+		))
+	}
+
+	if len(heads) > 1 {
+		msgs = append(msgs, fmt.Sprintf(
+			"channel %q: has multiple heads %s; exactly one bundle must not be replaced or skipped by another", g.name, quoteAll(heads),
+		))
+	}
+
+	return msgs
+}
+
+// danglingReplaces reports 'replaces' fields which point at a CSV missing
+// from the channel. A missing CSV is tolerated if it is also skipped since
+// this is the supported way of pruning old bundles from an index.
+func (g *channelGraph) danglingReplaces(index map[string]struct{}) []string {
+	var msgs []string
+
+	for _, name := range g.sortedNames() {
+		spec := g.bundles[name].ClusterServiceVersion.Spec
+
+		if spec.Replaces == "" || contains(spec.Skips, spec.Replaces) {
+			continue
+		}
+
+		if _, ok := g.bundles[spec.Replaces]; ok {
+			continue
+		}
+
+		if _, ok := index[spec.Replaces]; ok {
+			msgs = append(msgs, fmt.Sprintf(
+				"channel %q: bundle %q replaces %q which is not part of the channel", g.name, name, spec.Replaces,
+			))
+
+			continue
+		}
+
+		msgs = append(msgs, fmt.Sprintf(
+			"channel %q: bundle %q replaces %q which is missing from the index", g.name, name, spec.Replaces,
+		))
+	}
+
+	return msgs
+}
+
+// cycles reports every cycle found while traversing the graph depth-first.
+func (g *channelGraph) cycles() []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	var (
+		msgs  []string
+		path  []string
+		state = make(map[string]int, len(g.bundles))
+		visit func(string)
+	)
+
+	visit = func(node string) {
+		state[node] = visiting
+		path = append(path, node)
+
+		for _, next := range g.edges[node] {
+			switch state[next] {
+			case unvisited:
+				visit(next)
+			case visiting:
+				start := indexOf(path, next)
+				cycle := append(append([]string{}, path[start:]...), next)
+
+				msgs = append(msgs, fmt.Sprintf(
+					"channel %q: upgrade graph contains a cycle %s", g.name, strings.Join(cycle, " -> "),
+				))
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[node] = visited
+	}
+
+	for _, name := range g.sortedNames() {
+		if state[name] == unvisited {
+			visit(name)
+		}
+	}
+
+	return msgs
+}
+
+// headsAndOrphans returns the bundles which no other bundle upgrades from
+// and the bundles which are not connected to any other bundle. Orphans are
+// only reported for channels with more than one bundle and are not
+// counted as heads.
+func (g *channelGraph) headsAndOrphans() (heads, orphans []string) {
+	incoming := make(map[string]int, len(g.bundles))
+
+	for _, targets := range g.edges {
+		for _, t := range targets {
+			incoming[t]++
+		}
+	}
+
+	for _, name := range g.sortedNames() {
+		if incoming[name] > 0 {
+			continue
+		}
+
+		if len(g.edges[name]) == 0 && len(g.bundles) > 1 {
+			orphans = append(orphans, name)
+
+			continue
+		}
+
+		heads = append(heads, name)
+	}
+
+	return heads, orphans
+}
+
+func (g *channelGraph) sortedNames() []string {
+	names := make([]string, 0, len(g.bundles))
+
+	for name := range g.bundles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func contains(list []string, s string) bool {
+	return indexOf(list, s) >= 0
+}
+
+func indexOf(list []string, s string) int {
+	for i, item := range list {
+		if item == s {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func quoteAll(list []string) string {
+	quoted := make([]string, 0, len(list))
+
+	for _, s := range list {
+		quoted = append(quoted, fmt.Sprintf("%q", s))
+	}
+
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
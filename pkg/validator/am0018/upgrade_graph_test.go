@@ -0,0 +1,222 @@
+package am0018
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	opsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeGraphValid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewUpgradeGraph)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"no bundles": newMetaBundle(),
+		"single bundle": newMetaBundle(
+			newBundle("reference-addon.v0.1.0", "0.1.0", []string{"alpha"}),
+		),
+		"linear replaces chain": newMetaBundle(
+			newBundle("reference-addon.v0.1.0", "0.1.0", []string{"alpha"}),
+			newBundle("reference-addon.v0.1.1", "0.1.1", []string{"alpha"}, withReplaces("reference-addon.v0.1.0")),
+			newBundle("reference-addon.v0.1.2", "0.1.2", []string{"alpha"}, withReplaces("reference-addon.v0.1.1")),
+		),
+		"skips and skipRange": newMetaBundle(
+			newBundle("reference-addon.v0.1.0", "0.1.0", []string{"alpha"}),
+			newBundle("reference-addon.v0.1.1", "0.1.1", []string{"alpha"}),
+			newBundle("reference-addon.v0.2.0", "0.2.0", []string{"alpha"},
+				withSkips("reference-addon.v0.1.1"),
+				withSkipRange("<0.1.1"),
+			),
+		),
+		"wide skipRange on every bundle": newMetaBundle(
+			newBundle("reference-addon.v0.1.0", "0.1.0", []string{"alpha"}),
+			newBundle("reference-addon.v0.1.1", "0.1.1", []string{"alpha"}, withSkipRange("<9.9.9")),
+			newBundle("reference-addon.v0.1.2", "0.1.2", []string{"alpha"}, withSkipRange("<9.9.9")),
+		),
+		"pruned replaces which is also skipped": newMetaBundle(
+			newBundle("reference-addon.v0.1.1", "0.1.1", []string{"alpha"},
+				withReplaces("reference-addon.v0.1.0"),
+				withSkips("reference-addon.v0.1.0"),
+			),
+		),
+		"multiple channels": newMetaBundle(
+			newBundle("reference-addon.v0.1.0", "0.1.0", []string{"alpha", "beta"}),
+			newBundle("reference-addon.v0.1.1", "0.1.1", []string{"alpha"}, withReplaces("reference-addon.v0.1.0")),
+		),
+	})
+}
+
+func TestUpgradeGraphInvalid(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		MetaBundle types.MetaBundle
+		Expected   []string
+	}{
+		"replaces missing from index": {
+			MetaBundle: newMetaBundle(
+				newBundle("reference-addon.v0.1.1", "0.1.1", []string{"alpha"}, withReplaces("reference-addon.v0.1.0")),
+			),
+			Expected: []string{
+				`channel "alpha": bundle "reference-addon.v0.1.1" replaces "reference-addon.v0.1.0" which is missing from the index`,
+			},
+		},
+		"replaces in another channel": {
+			MetaBundle: newMetaBundle(
+				newBundle("reference-addon.v0.1.0", "0.1.0", []string{"beta"}),
+				newBundle("reference-addon.v0.1.1", "0.1.1", []string{"alpha"}, withReplaces("reference-addon.v0.1.0")),
+			),
+			Expected: []string{
+				`channel "alpha": bundle "reference-addon.v0.1.1" replaces "reference-addon.v0.1.0" which is not part of the channel`,
+			},
+		},
+		"cycle": {
+			MetaBundle: newMetaBundle(
+				newBundle("reference-addon.v0.1.0", "0.1.0", []string{"alpha"}, withReplaces("reference-addon.v0.1.1")),
+				newBundle("reference-addon.v0.1.1", "0.1.1", []string{"alpha"}, withReplaces("reference-addon.v0.1.0")),
+			),
+			Expected: []string{
+				`channel "alpha": upgrade graph contains a cycle reference-addon.v0.1.0 -> reference-addon.v0.1.1 -> reference-addon.v0.1.0`,
+			},
+		},
+		"multiple heads": {
+			MetaBundle: newMetaBundle(
+				newBundle("reference-addon.v0.1.0", "0.1.0", []string{"alpha"}),
+				newBundle("reference-addon.v0.1.1", "0.1.1", []string{"alpha"}, withReplaces("reference-addon.v0.1.0")),
+				newBundle("reference-addon.v0.1.2", "0.1.2", []string{"alpha"}, withReplaces("reference-addon.v0.1.0")),
+			),
+			Expected: []string{
+				`channel "alpha": has multiple heads ["reference-addon.v0.1.1", "reference-addon.v0.1.2"]; exactly one bundle must not be replaced or skipped by another`,
+			},
+		},
+		"invalid skipRange": {
+			MetaBundle: newMetaBundle(
+				newBundle("reference-addon.v0.1.0", "0.1.0", []string{"alpha"}),
+				newBundle("reference-addon.v0.1.1", "0.1.1", []string{"alpha"},
+					withReplaces("reference-addon.v0.1.0"),
+					withSkipRange(">=0.1.0 <<0.1.1"),
+				),
+			),
+			Expected: []string{
+				`bundle "reference-addon.v0.1.1" has an invalid olm.skipRange annotation ">=0.1.0 <<0.1.1": Could not parse Range "<<0.1.1": Could not parse comparator "<<" in "<<0.1.1"`,
+			},
+		},
+		"orphaned bundle": {
+			MetaBundle: newMetaBundle(
+				newBundle("reference-addon.v0.1.0", "0.1.0", []string{"stable"}),
+				newBundle("reference-addon.v0.1.1", "0.1.1", []string{"stable"}, withReplaces("reference-addon.v0.1.0")),
+				newBundle("reference-addon.v0.0.9", "0.0.9", []string{"stable"}),
+			),
+			Expected: []string{
+				`channel "stable": bundle "reference-addon.v0.0.9" is orphaned; it neither replaces nor is replaced by any other bundle in the channel`,
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tester := testutils.NewValidatorTester(t, NewUpgradeGraph)
+
+			res := tester.Val.Run(context.Background(), tc.MetaBundle)
+			require.False(t, res.IsError())
+			require.False(t, res.IsSuccess())
+
+			assert.Equal(t, tc.Expected, res.FailureMsgs)
+		})
+	}
+}
+
+func newMetaBundle(bundles ...operator.Bundle) types.MetaBundle {
+	return types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{
+			OperatorName: "reference-addon",
+		},
+		Bundles: bundles,
+	}
+}
+
+type bundleOption func(*operator.Bundle)
+
+func withReplaces(csv string) bundleOption {
+	return func(b *operator.Bundle) {
+		b.ClusterServiceVersion.Spec.Replaces = csv
+	}
+}
+
+func withSkips(csvs ...string) bundleOption {
+	return func(b *operator.Bundle) {
+		b.ClusterServiceVersion.Spec.Skips = csvs
+	}
+}
+
+func TestBundleChannels(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		IndexChannels      []string
+		AnnotationChannels []string
+		Expected           []string
+	}{
+		"index channels take precedence": {
+			IndexChannels:      []string{"alpha", "beta"},
+			AnnotationChannels: []string{"alpha"},
+			Expected:           []string{"alpha", "beta"},
+		},
+		"annotation channels without index channels": {
+			AnnotationChannels: []string{" alpha", "", "beta "},
+			Expected:           []string{"alpha", "beta"},
+		},
+		"no channels": {},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			b := newBundle("reference-addon.v0.1.0", "0.1.0", tc.IndexChannels)
+			b.Annotations.Channels = tc.AnnotationChannels
+
+			assert.Equal(t, tc.Expected, bundleChannels(b))
+		})
+	}
+}
+
+func withSkipRange(skipRange string) bundleOption {
+	return func(b *operator.Bundle) {
+		b.ClusterServiceVersion.Annotations = map[string]string{
+			skipRangeAnnotation: skipRange,
+		}
+	}
+}
+
+func newBundle(csvName, version string, channels []string, opts ...bundleOption) operator.Bundle {
+	b := operator.Bundle{
+		Name:     csvName,
+		Package:  "reference-addon",
+		Version:  version,
+		Channels: channels,
+		Annotations: operator.Annotations{
+			PackageName: "reference-addon",
+			Channels:    channels,
+		},
+		ClusterServiceVersion: operator.ClusterServiceVersion{
+			Name: csvName,
+			Spec: opsv1alpha1.ClusterServiceVersionSpec{},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&b)
+	}
+
+	return b
+}
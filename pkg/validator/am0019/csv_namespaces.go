@@ -0,0 +1,204 @@
+package am0019
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	validator.Register(NewCSVNamespaces)
+}
+
+const (
+	code = 19
+	name = "csv_namespaces"
+	desc = "Ensure namespaces referenced by the head bundle's CSV are declared in the addon namespaces and match its installMode"
+
+	targetNamespacesAnnotation   = "olm.targetNamespaces"
+	suggestedNamespaceAnnotation = "operatorframework.io/suggested-namespace"
+)
+
+// namespaceEnvVars are the environment variables through which operators
+// receive the namespaces OLM installs them into and makes them watch.
+// Other variables ending in 'NAMESPACE', e.g. 'MONITORING_NAMESPACE',
+// may legitimately refer to namespaces the addon does not own.
+var namespaceEnvVars = []string{"OPERATOR_NAMESPACE", "WATCH_NAMESPACE"}
+
+func NewCSVNamespaces(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseRequires(validator.RequiresBundles),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CSVNamespaces{
+		Base:               base,
+		ExcludedNamespaces: deps.ValidatorConfig.ExcludedNamespaces,
+	}, nil
+}
+
+type CSVNamespaces struct {
+	*validator.Base
+	ExcludedNamespaces []string
+}
+
+func (c *CSVNamespaces) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	bundle, ok := operator.HeadBundle(mb.Bundles...)
+	if !ok {
+		return c.Success()
+	}
+
+	var (
+		meta     = mb.AddonMeta
+		csv      = bundle.ClusterServiceVersion
+		findings []validator.Finding
+	)
+
+	for _, ref := range referencedNamespaces(csv) {
+		if slices.Contains(meta.Namespaces, ref.Namespace) || slices.Contains(c.ExcludedNamespaces, ref.Namespace) {
+			continue
+		}
+
+		findings = append(findings, validator.NewFinding("namespaces",
+			"bundle %q: %s references namespace %q which is not listed in namespaces %v",
+			bundle.GetNameVersion(), ref.Source, ref.Namespace, meta.Namespaces,
+		))
+	}
+
+	if suggested := csv.Annotations[suggestedNamespaceAnnotation]; !c.isAddonNamespace(suggested, meta.TargetNamespace, meta.Namespaces) {
+		findings = append(findings, validator.NewFinding("targetNamespace",
+			"bundle %q: annotation %q is %q which is neither the targetNamespace %q nor listed in namespaces %v",
+			bundle.GetNameVersion(), suggestedNamespaceAnnotation, suggested, meta.TargetNamespace, meta.Namespaces,
+		))
+	}
+
+	if msg := checkInstallMode(meta.InstallMode, meta.TargetNamespace, csv); msg != "" {
+		findings = append(findings, validator.NewFinding("installMode", "bundle %q: %s", bundle.GetNameVersion(), msg))
+	}
+
+	if len(findings) > 0 {
+		return c.FailWith(findings...)
+	}
+
+	return c.Success()
+}
+
+// isAddonNamespace reports whether the namespace 'ns' suggested by a
+// bundle is either unset, the target namespace, one of the namespaces
+// of the addon or excluded from validation. OLM only uses the suggested
+// namespace as the default when a user installs the operator manually,
+// so any namespace owned by the addon is acceptable.
+func (c *CSVNamespaces) isAddonNamespace(ns, targetNamespace string, namespaces []string) bool {
+	return ns == "" || ns == targetNamespace ||
+		slices.Contains(namespaces, ns) || slices.Contains(c.ExcludedNamespaces, ns)
+}
+
+type namespaceRef struct {
+	Namespace string
+	// Source describes where in the CSV the namespace is referenced.
+	Source string
+}
+
+// referencedNamespaces returns all namespaces hardcoded in the OLM
+// annotations, pod templates and OLM namespace environment variables
+// of the CSV ordered by namespace. The suggested namespace is checked
+// separately as it may also be the targetNamespace.
+func referencedNamespaces(csv operator.ClusterServiceVersion) []namespaceRef {
+	var refs []namespaceRef
+
+	for _, ns := range splitList(csv.Annotations[targetNamespacesAnnotation]) {
+		refs = append(refs, namespaceRef{
+			Namespace: ns,
+			Source:    fmt.Sprintf("annotation %q", targetNamespacesAnnotation),
+		})
+	}
+
+	for _, dep := range csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+		tmpl := dep.Spec.Template
+
+		if ns := tmpl.Namespace; ns != "" {
+			refs = append(refs, namespaceRef{
+				Namespace: ns,
+				Source:    fmt.Sprintf("deployment %q pod template", dep.Name),
+			})
+		}
+
+		containers := make([]corev1.Container, 0, len(tmpl.Spec.InitContainers)+len(tmpl.Spec.Containers))
+		containers = append(containers, tmpl.Spec.InitContainers...)
+		containers = append(containers, tmpl.Spec.Containers...)
+
+		for _, container := range containers {
+			for _, env := range container.Env {
+				// only literal values are hardcoded; values from the
+				// downward API follow the namespace chosen by OLM
+				if !slices.Contains(namespaceEnvVars, env.Name) || env.ValueFrom != nil {
+					continue
+				}
+
+				for _, ns := range splitList(env.Value) {
+					refs = append(refs, namespaceRef{
+						Namespace: ns,
+						Source:    fmt.Sprintf("deployment %q container %q env %q", dep.Name, container.Name, env.Name),
+					})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(refs, func(i, j int) bool { return refs[i].Namespace < refs[j].Namespace })
+
+	return refs
+}
+
+// checkInstallMode ensures the 'olm.targetNamespaces' annotation is
+// consistent with the addon installMode. An empty string is returned
+// if the annotation is unset or consistent.
+func checkInstallMode(installMode, targetNamespace string, csv operator.ClusterServiceVersion) string {
+	raw, ok := csv.Annotations[targetNamespacesAnnotation]
+	if !ok {
+		return ""
+	}
+
+	targets := splitList(raw)
+
+	switch installMode {
+	case "AllNamespaces":
+		if len(targets) > 0 {
+			return fmt.Sprintf(
+				"installMode 'AllNamespaces' requires annotation %q to be empty, but it is %q", targetNamespacesAnnotation, raw,
+			)
+		}
+	case "OwnNamespace":
+		if len(targets) != 1 || targets[0] != targetNamespace {
+			return fmt.Sprintf(
+				"installMode 'OwnNamespace' requires annotation %q to be %q, but it is %q", targetNamespacesAnnotation, targetNamespace, raw,
+			)
+		}
+	}
+
+	return ""
+}
+
+func splitList(list string) []string {
+	var res []string
+
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,195 @@
+package am0019
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	opsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCSVNamespacesValid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewCSVNamespaces)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"no bundles": newMetaBundle("AllNamespaces"),
+		"no namespace references": newMetaBundle("AllNamespaces",
+			newBundle(),
+		),
+		"all namespaces with empty target namespaces": newMetaBundle("AllNamespaces",
+			newBundle(
+				withAnnotation(targetNamespacesAnnotation, ""),
+				withAnnotation(suggestedNamespaceAnnotation, "reference-addon"),
+			),
+		),
+		"own namespace": newMetaBundle("OwnNamespace",
+			newBundle(
+				withAnnotation(targetNamespacesAnnotation, "reference-addon"),
+				withAnnotation(suggestedNamespaceAnnotation, "reference-addon"),
+			),
+		),
+		"suggested namespace listed in namespaces": newMetaBundle("AllNamespaces",
+			newBundle(withAnnotation(suggestedNamespaceAnnotation, "reference-addon-monitoring")),
+		),
+		"hardcoded namespaces which are declared": newMetaBundle("AllNamespaces",
+			newBundle(
+				withDeployment("reference-addon-manager", "reference-addon",
+					corev1.EnvVar{Name: "WATCH_NAMESPACE", Value: "reference-addon, reference-addon-monitoring"},
+				),
+			),
+		),
+		"other namespace env vars": newMetaBundle("AllNamespaces",
+			newBundle(
+				withDeployment("reference-addon-manager", "",
+					corev1.EnvVar{Name: "MONITORING_NAMESPACE", Value: "openshift-monitoring"},
+				),
+			),
+		),
+		"namespaces from the downward API": newMetaBundle("AllNamespaces",
+			newBundle(
+				withDeployment("reference-addon-manager", "",
+					corev1.EnvVar{
+						Name: "POD_NAMESPACE",
+						ValueFrom: &corev1.EnvVarSource{
+							FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+						},
+					},
+				),
+			),
+		),
+	})
+}
+
+func TestCSVNamespacesInvalid(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		MetaBundle types.MetaBundle
+		Expected   []string
+	}{
+		"undeclared target namespace": {
+			MetaBundle: newMetaBundle("OwnNamespace",
+				newBundle(withAnnotation(targetNamespacesAnnotation, "reference-addon,openshift-operators")),
+			),
+			Expected: []string{
+				`bundle "reference-addon:0.1.6": annotation "olm.targetNamespaces" references namespace "openshift-operators" which is not listed in namespaces [reference-addon reference-addon-monitoring]`,
+				`bundle "reference-addon:0.1.6": installMode 'OwnNamespace' requires annotation "olm.targetNamespaces" to be "reference-addon", but it is "reference-addon,openshift-operators"`,
+			},
+		},
+		"target namespaces with AllNamespaces": {
+			MetaBundle: newMetaBundle("AllNamespaces",
+				newBundle(withAnnotation(targetNamespacesAnnotation, "reference-addon")),
+			),
+			Expected: []string{
+				`bundle "reference-addon:0.1.6": installMode 'AllNamespaces' requires annotation "olm.targetNamespaces" to be empty, but it is "reference-addon"`,
+			},
+		},
+		"undeclared suggested namespace": {
+			MetaBundle: newMetaBundle("AllNamespaces",
+				newBundle(withAnnotation(suggestedNamespaceAnnotation, "reference-addon-system")),
+			),
+			Expected: []string{
+				`bundle "reference-addon:0.1.6": annotation "operatorframework.io/suggested-namespace" is "reference-addon-system" which is neither the targetNamespace "reference-addon" nor listed in namespaces [reference-addon reference-addon-monitoring]`,
+			},
+		},
+		"hardcoded deployment namespaces": {
+			MetaBundle: newMetaBundle("AllNamespaces",
+				newBundle(
+					withDeployment("reference-addon-manager", "reference-addon-system",
+						corev1.EnvVar{Name: "WATCH_NAMESPACE", Value: "reference-addon,default"},
+						corev1.EnvVar{Name: "OPERATOR_NAMESPACE", Value: "operators"},
+					),
+				),
+			),
+			Expected: []string{
+				`bundle "reference-addon:0.1.6": deployment "reference-addon-manager" container "manager" env "WATCH_NAMESPACE" references namespace "default" which is not listed in namespaces [reference-addon reference-addon-monitoring]`,
+				`bundle "reference-addon:0.1.6": deployment "reference-addon-manager" container "manager" env "OPERATOR_NAMESPACE" references namespace "operators" which is not listed in namespaces [reference-addon reference-addon-monitoring]`,
+				`bundle "reference-addon:0.1.6": deployment "reference-addon-manager" pod template references namespace "reference-addon-system" which is not listed in namespaces [reference-addon reference-addon-monitoring]`,
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tester := testutils.NewValidatorTester(t, NewCSVNamespaces)
+
+			res := tester.Val.Run(context.Background(), tc.MetaBundle)
+			require.False(t, res.IsError())
+			require.False(t, res.IsSuccess())
+
+			assert.Equal(t, tc.Expected, res.FailureMsgs)
+		})
+	}
+}
+
+func newMetaBundle(installMode string, bundles ...operator.Bundle) types.MetaBundle {
+	return types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{
+			OperatorName:    "reference-addon",
+			InstallMode:     installMode,
+			TargetNamespace: "reference-addon",
+			Namespaces:      []string{"reference-addon", "reference-addon-monitoring"},
+		},
+		Bundles: bundles,
+	}
+}
+
+type bundleOption func(*operator.Bundle)
+
+func withAnnotation(key, value string) bundleOption {
+	return func(b *operator.Bundle) {
+		if b.ClusterServiceVersion.Annotations == nil {
+			b.ClusterServiceVersion.Annotations = make(map[string]string)
+		}
+
+		b.ClusterServiceVersion.Annotations[key] = value
+	}
+}
+
+func withDeployment(name, namespace string, env ...corev1.EnvVar) bundleOption {
+	return func(b *operator.Bundle) {
+		strategy := &b.ClusterServiceVersion.Spec.InstallStrategy.StrategySpec
+
+		strategy.DeploymentSpecs = append(strategy.DeploymentSpecs, opsv1alpha1.StrategyDeploymentSpec{
+			Name: name,
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: "manager", Env: env},
+						},
+					},
+				},
+			},
+		})
+	}
+}
+
+func newBundle(opts ...bundleOption) operator.Bundle {
+	b := operator.Bundle{
+		Name:    "reference-addon",
+		Package: "reference-addon",
+		Version: "0.1.6",
+		ClusterServiceVersion: operator.ClusterServiceVersion{
+			Name: "reference-addon.v0.1.6",
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&b)
+	}
+
+	return b
+}
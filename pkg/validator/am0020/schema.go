@@ -0,0 +1,53 @@
+package am0020
+
+import (
+	"context"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/schema"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+func init() {
+	validator.Register(NewSchema)
+}
+
+const (
+	code = 20
+	name = "schema"
+	desc = "Ensure the addon metadata satisfies the kubebuilder validation markers and required tags of its types"
+)
+
+func NewSchema(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Schema{
+		Base: base,
+	}, nil
+}
+
+type Schema struct {
+	*validator.Base
+}
+
+func (s *Schema) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	violations := schema.Validate(mb.AddonMeta)
+	if len(violations) == 0 {
+		return s.Success()
+	}
+
+	findings := make([]validator.Finding, 0, len(violations))
+
+	for _, v := range violations {
+		findings = append(findings, validator.Finding{Message: v.String(), Path: v.Path})
+	}
+
+	return s.FailWith(findings...)
+}
@@ -0,0 +1,73 @@
+package am0020
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	utils "github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaValid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewSchema)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"reference addon": newReferenceMetaBundle(t),
+	})
+}
+
+func TestSchemaInvalid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewSchema)
+	tester.TestInvalidBundles(map[string]types.MetaBundle{
+		"empty metadata": {
+			AddonMeta: &v1alpha1.AddonMetadataSpec{},
+		},
+	})
+}
+
+func TestSchemaReportsJSONPaths(t *testing.T) {
+	t.Parallel()
+
+	mb := newReferenceMetaBundle(t)
+
+	meta := mb.AddonMeta.DeepCopy()
+	meta.QuayRepo = "quay.io/someone-else/reference-addon"
+	mb.AddonMeta = meta
+
+	tester := testutils.NewValidatorTester(t, NewSchema)
+
+	res := tester.Val.Run(context.Background(), mb)
+	require.False(t, res.IsError())
+	require.False(t, res.IsSuccess())
+
+	assert.Equal(t, []string{
+		`quayRepo: value "quay.io/someone-else/reference-addon" does not match pattern "^quay\\.io/osd-addons/[a-z-]+$"`,
+	}, res.FailureMsgs)
+	require.Len(t, res.Findings, 1)
+	assert.Equal(t, "quayRepo", res.Findings[0].Path)
+}
+
+// newReferenceMetaBundle returns the stage metadata of the reference
+// addon combined with its imageset but without bundles, which are
+// not inspected by the validator.
+func newReferenceMetaBundle(t *testing.T) types.MetaBundle {
+	t.Helper()
+
+	ref, err := utils.GetReferenceAddonStage()
+	require.NoError(t, err)
+
+	imageSet, err := ref.GetImageSet(*ref.MetaImageSet.ImageSetVersion)
+	require.NoError(t, err)
+
+	meta, err := ref.MetaImageSet.DeepCopy().CombineWithImageSet(imageSet)
+	require.NoError(t, err)
+
+	return *types.NewMetaBundle(meta, nil)
+}
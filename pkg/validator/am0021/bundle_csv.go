@@ -0,0 +1,76 @@
+package am0021
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	opsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func init() {
+	validator.Register(NewBundleCSV)
+}
+
+const (
+	code = 21
+	name = "bundle_csv"
+	desc = "Ensure every bundle has a CSV with a deployment install strategy"
+)
+
+func NewBundleCSV(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseRequires(validator.RequiresBundles),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BundleCSV{
+		Base: base,
+	}, nil
+}
+
+// BundleCSV checks that every bundle carries a CSV which the validators
+// inspecting its install modes, permissions and deployments can parse.
+// Those declare it as a prerequisite so that bundles without a usable
+// CSV are reported once instead of by each of them.
+type BundleCSV struct {
+	*validator.Base
+}
+
+func (b *BundleCSV) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	var msgs []string
+
+	for _, bundle := range mb.Bundles {
+		if msg := validateBundle(bundle); msg != "" {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	if len(msgs) > 0 {
+		return b.Fail(msgs...)
+	}
+
+	return b.Success()
+}
+
+func validateBundle(bundle operator.Bundle) string {
+	nameVer := bundle.GetNameVersion()
+	csv := bundle.ClusterServiceVersion
+
+	if csv.Name == "" {
+		return fmt.Sprintf("bundle %q has no CSV", nameVer)
+	}
+
+	if strategy := csv.Spec.InstallStrategy.StrategyName; strategy != opsv1alpha1.InstallStrategyNameDeployment {
+		return fmt.Sprintf("bundle %q has a CSV with the unsupported install strategy %q", nameVer, strategy)
+	}
+
+	return ""
+}
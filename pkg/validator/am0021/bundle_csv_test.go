@@ -0,0 +1,91 @@
+package am0021
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	opsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleCSVValid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewBundleCSV)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"no bundles": {},
+		"deployment": {Bundles: []operator.Bundle{newBundle()}},
+		"multiple": {Bundles: []operator.Bundle{newBundle(), newBundle(func(b *operator.Bundle) {
+			b.Version = "0.1.7"
+			b.ClusterServiceVersion.Name = "reference-addon.v0.1.7"
+		})}},
+	})
+}
+
+func TestBundleCSVInvalid(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Bundle   operator.Bundle
+		Expected []string
+	}{
+		"missing csv": {
+			Bundle: newBundle(func(b *operator.Bundle) {
+				b.ClusterServiceVersion = operator.ClusterServiceVersion{}
+			}),
+			Expected: []string{
+				`bundle "reference-addon:0.1.6" has no CSV`,
+			},
+		},
+		"missing install strategy": {
+			Bundle: newBundle(func(b *operator.Bundle) {
+				b.ClusterServiceVersion.Spec.InstallStrategy = opsv1alpha1.NamedInstallStrategy{}
+			}),
+			Expected: []string{
+				`bundle "reference-addon:0.1.6" has a CSV with the unsupported install strategy ""`,
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tester := testutils.NewValidatorTester(t, NewBundleCSV)
+
+			res := tester.Val.Run(context.Background(), types.MetaBundle{
+				Bundles: []operator.Bundle{tc.Bundle},
+			})
+			require.False(t, res.IsError())
+			require.False(t, res.IsSuccess())
+
+			assert.Equal(t, tc.Expected, res.FailureMsgs)
+		})
+	}
+}
+
+func newBundle(opts ...func(*operator.Bundle)) operator.Bundle {
+	b := operator.Bundle{
+		Name:    "reference-addon",
+		Package: "reference-addon",
+		Version: "0.1.6",
+		ClusterServiceVersion: operator.ClusterServiceVersion{
+			Name: "reference-addon.v0.1.6",
+			Spec: opsv1alpha1.ClusterServiceVersionSpec{
+				InstallStrategy: opsv1alpha1.NamedInstallStrategy{
+					StrategyName: opsv1alpha1.InstallStrategyNameDeployment,
+				},
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&b)
+	}
+
+	return b
+}
@@ -0,0 +1,85 @@
+package validator
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Backoff decides how long a RetryMiddleware waits
+// before running a Validator task again.
+type Backoff interface {
+	// Delay returns the time to wait after the given attempt, starting
+	// at 1, failed. 'prev' is the delay returned after the previous
+	// attempt or zero after the first attempt.
+	Delay(attempt int, prev time.Duration) time.Duration
+}
+
+// ConstantBackoff waits the same time after every attempt.
+type ConstantBackoff time.Duration
+
+func (b ConstantBackoff) Delay(int, time.Duration) time.Duration {
+	return time.Duration(b)
+}
+
+// DefaultBackoffMultiplier is the factor by which an ExponentialBackoff
+// increases the delay when no Multiplier is configured.
+const DefaultBackoffMultiplier = 2
+
+// ExponentialBackoff waits 'Initial' after the first attempt and
+// 'Multiplier' times as long after each following attempt, but
+// never longer than 'Max' unless 'Max' is zero.
+type ExponentialBackoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+func (b ExponentialBackoff) Delay(attempt int, _ time.Duration) time.Duration {
+	mult := b.Multiplier
+	if mult == 0 {
+		mult = DefaultBackoffMultiplier
+	}
+
+	delay := float64(b.Initial) * math.Pow(mult, float64(attempt-1))
+
+	return capDelay(delay, b.Max)
+}
+
+// DecorrelatedJitterBackoff waits a random time between 'Base' and three
+// times the previous delay, but never longer than 'Max' unless 'Max' is
+// zero. Spreading out retries keeps concurrent Validators from calling
+// the same service in lockstep.
+type DecorrelatedJitterBackoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+func (b DecorrelatedJitterBackoff) Delay(_ int, prev time.Duration) time.Duration {
+	if prev < b.Base {
+		prev = b.Base
+	}
+
+	upper := 3 * float64(prev)
+	delay := float64(b.Base)
+
+	if span := upper - delay; span > 0 {
+		delay += rand.Float64() * span
+	}
+
+	return capDelay(delay, b.Max)
+}
+
+// capDelay converts 'delay' to a time.Duration no longer than 'max'
+// unless 'max' is zero, guarding against overflows.
+func capDelay(delay float64, max time.Duration) time.Duration {
+	if max > 0 && delay > float64(max) {
+		return max
+	}
+
+	if delay > math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+
+	return time.Duration(delay)
+}
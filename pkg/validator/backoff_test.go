@@ -0,0 +1,84 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Backoff  Backoff
+		Expected []time.Duration
+	}{
+		"constant": {
+			Backoff:  ConstantBackoff(time.Second),
+			Expected: []time.Duration{time.Second, time.Second, time.Second},
+		},
+		"exponential": {
+			Backoff:  ExponentialBackoff{Initial: time.Second},
+			Expected: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second},
+		},
+		"exponential with multiplier and max": {
+			Backoff: ExponentialBackoff{
+				Initial:    100 * time.Millisecond,
+				Max:        time.Second,
+				Multiplier: 3,
+			},
+			Expected: []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 900 * time.Millisecond, time.Second},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				delay  time.Duration
+				actual []time.Duration
+			)
+
+			for attempt := 1; attempt <= len(tc.Expected); attempt++ {
+				delay = tc.Backoff.Delay(attempt, delay)
+				actual = append(actual, delay)
+			}
+
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+}
+
+func TestExponentialBackoffDoesNotOverflow(t *testing.T) {
+	t.Parallel()
+
+	backoff := ExponentialBackoff{Initial: time.Second}
+
+	assert.Equal(t, time.Duration(1<<63-1), backoff.Delay(1000, 0))
+}
+
+func TestDecorrelatedJitterBackoffDelay(t *testing.T) {
+	t.Parallel()
+
+	backoff := DecorrelatedJitterBackoff{
+		Base: 100 * time.Millisecond,
+		Max:  2 * time.Second,
+	}
+
+	var delay time.Duration
+
+	for attempt := 1; attempt <= 100; attempt++ {
+		prev := delay
+		if prev < backoff.Base {
+			prev = backoff.Base
+		}
+
+		delay = backoff.Delay(attempt, delay)
+
+		assert.GreaterOrEqual(t, delay, backoff.Base)
+		assert.LessOrEqual(t, delay, backoff.Max)
+		assert.LessOrEqual(t, delay, 3*prev)
+	}
+}
@@ -0,0 +1,202 @@
+package validator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+)
+
+// ResultStore persists serialized Results between runs.
+type ResultStore interface {
+	// ReadResult returns the data stored for 'key'. 'ok'
+	// returns false if the key does not exist or expired.
+	ReadResult(key string) (data []byte, ok bool)
+	// WriteResult stores 'data' for 'key'.
+	WriteResult(key string, data []byte) error
+}
+
+// NewCacheMiddleware returns a middleware which reuses the Results
+// stored in 'store' by earlier runs of the same Validator against
+// the same addon metadata and bundles.
+func NewCacheMiddleware(store ResultStore, opts ...CacheMiddlewareOption) *CacheMiddleware {
+	var cfg CacheMiddlewareConfig
+
+	cfg.Option(opts...)
+
+	return &CacheMiddleware{
+		cfg:   cfg,
+		store: store,
+	}
+}
+
+type CacheMiddleware struct {
+	cfg   CacheMiddlewareConfig
+	store ResultStore
+}
+
+// Wrap returns 'run' unchanged since results cannot be
+// cached without knowing the Validator which produced them.
+func (c *CacheMiddleware) Wrap(run RunFunc) RunFunc { return run }
+
+// WrapValidator returns the cached Result of 'v' if one exists for the
+// MetaBundle and otherwise runs the task and caches its Result unless
+// it is an error. Caching is best effort so that a broken cache never
+// fails a run.
+func (c *CacheMiddleware) WrapValidator(v Validator, run RunFunc) RunFunc {
+	return func(ctx context.Context, mb types.MetaBundle) Result {
+		var scope string
+
+		if c.cfg.Scope != nil {
+			scope = c.cfg.Scope(ctx)
+		}
+
+		key, err := resultKey(v, mb, c.cfg.Version, scope)
+		if err != nil {
+			return run(ctx, mb)
+		}
+
+		if !c.cfg.Refresh {
+			if res, ok := c.read(key); ok {
+				return res
+			}
+		}
+
+		res := run(ctx, mb)
+
+		if res.IsSuccess() || res.Status() == ResultStatusFailed {
+			c.write(key, res)
+		}
+
+		return res
+	}
+}
+
+func (c *CacheMiddleware) read(key string) (Result, bool) {
+	data, ok := c.store.ReadResult(key)
+	if !ok {
+		return Result{}, false
+	}
+
+	var res Result
+
+	if err := json.Unmarshal(data, &res); err != nil {
+		return Result{}, false
+	}
+
+	res.Cached = true
+
+	return res, true
+}
+
+func (c *CacheMiddleware) write(key string, res Result) {
+	// cached results were not run again
+	res.Attempts = 0
+	res.Duration = 0
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		return
+	}
+
+	_ = c.store.WriteResult(key, data)
+}
+
+// resultKey returns a content hash identifying the Result of 'v'
+// against 'mb'. Bundles pinned by digest are identified by their
+// digest and all other bundles by their content.
+func resultKey(v Validator, mb types.MetaBundle, version, scope string) (string, error) {
+	bundles := make([]string, 0, len(mb.Bundles))
+
+	for _, b := range mb.Bundles {
+		if _, digest, ok := strings.Cut(b.BundleImage, "@"); ok && strings.Contains(digest, ":") {
+			bundles = append(bundles, digest)
+
+			continue
+		}
+
+		data, err := json.Marshal(b)
+		if err != nil {
+			return "", fmt.Errorf("encoding bundle %q: %w", b.BundleImage, err)
+		}
+
+		sum := sha256.Sum256(data)
+
+		bundles = append(bundles, "sha256:"+hex.EncodeToString(sum[:]))
+	}
+
+	var validatorVersion int
+
+	if vv, ok := v.(interface{ Version() int }); ok {
+		validatorVersion = vv.Version()
+	}
+
+	data, err := json.Marshal(struct {
+		Code             Code                        `json:"code"`
+		ValidatorVersion int                         `json:"validatorVersion"`
+		Version          string                      `json:"version"`
+		Scope            string                      `json:"scope"`
+		Spec             *v1alpha1.AddonMetadataSpec `json:"spec"`
+		Bundles          []string                    `json:"bundles"`
+	}{
+		Code:             v.Code(),
+		ValidatorVersion: validatorVersion,
+		Version:          version,
+		Scope:            scope,
+		Spec:             mb.AddonMeta,
+		Bundles:          bundles,
+	})
+	if err != nil {
+		return "", fmt.Errorf("encoding cache key: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return fmt.Sprintf("%s-%s", v.Code(), hex.EncodeToString(sum[:])), nil
+}
+
+type CacheMiddlewareConfig struct {
+	// Version identifies the build and configuration of the Validators.
+	// Results cached with a different version are not reused.
+	Version string
+	// Scope optionally returns what else the Results of a run depend on,
+	// e.g. the OCM environment selected through the context.
+	Scope func(context.Context) string
+	// Refresh ignores cached Results while still caching new ones.
+	Refresh bool
+}
+
+func (c *CacheMiddlewareConfig) Option(opts ...CacheMiddlewareOption) {
+	for _, opt := range opts {
+		opt.ConfigureCacheMiddleware(c)
+	}
+}
+
+type CacheMiddlewareOption interface {
+	ConfigureCacheMiddleware(*CacheMiddlewareConfig)
+}
+
+type WithCacheVersion string
+
+func (w WithCacheVersion) ConfigureCacheMiddleware(c *CacheMiddlewareConfig) {
+	c.Version = string(w)
+}
+
+type WithCacheScope func(context.Context) string
+
+func (w WithCacheScope) ConfigureCacheMiddleware(c *CacheMiddlewareConfig) {
+	c.Scope = w
+}
+
+// WithCacheRefresh ignores cached Results which are
+// replaced by the Results of the new runs.
+type WithCacheRefresh bool
+
+func (w WithCacheRefresh) ConfigureCacheMiddleware(c *CacheMiddlewareConfig) {
+	c.Refresh = bool(w)
+}
@@ -0,0 +1,216 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheMiddleware(t *testing.T) {
+	t.Parallel()
+
+	base, err := NewBase(Code(1), BaseName("dummy_validator"), BaseVersion(1))
+	require.NoError(t, err)
+
+	v := &ValidatorMock{Base: base}
+
+	mb := types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{ID: "addon"},
+		Bundles: []operator.Bundle{
+			{BundleImage: "quay.io/osd-addons/addon-bundle@sha256:0123456789abcdef"},
+			{BundleImage: "/bundles/addon/0.1.0", Version: "0.1.0"},
+		},
+	}
+
+	changedSpec := mb
+	changedSpec.AddonMeta = &v1alpha1.AddonMetadataSpec{ID: "other"}
+
+	changedBundle := mb
+	changedBundle.Bundles = []operator.Bundle{
+		mb.Bundles[0],
+		{BundleImage: "/bundles/addon/0.1.0", Version: "0.1.1"},
+	}
+
+	for name, tc := range map[string]struct {
+		Result       Result
+		Options      []CacheMiddlewareOption
+		Second       types.MetaBundle
+		SecondOpts   []CacheMiddlewareOption
+		ExpectedRuns int
+	}{
+		"success is reused": {
+			Result:       base.Success(),
+			Second:       mb,
+			ExpectedRuns: 1,
+		},
+		"failure is reused": {
+			Result:       base.Fail("failed"),
+			Second:       mb,
+			ExpectedRuns: 1,
+		},
+		"errors are not cached": {
+			Result:       base.RetryableError(errors.New("unavailable")),
+			Second:       mb,
+			ExpectedRuns: 2,
+		},
+		"changed spec": {
+			Result:       base.Success(),
+			Second:       changedSpec,
+			ExpectedRuns: 2,
+		},
+		"changed bundle content": {
+			Result:       base.Success(),
+			Second:       changedBundle,
+			ExpectedRuns: 2,
+		},
+		"changed version": {
+			Result:       base.Success(),
+			Options:      []CacheMiddlewareOption{WithCacheVersion("v1")},
+			Second:       mb,
+			SecondOpts:   []CacheMiddlewareOption{WithCacheVersion("v2")},
+			ExpectedRuns: 2,
+		},
+		"changed scope": {
+			Result:       base.Success(),
+			Options:      []CacheMiddlewareOption{WithCacheScope(func(context.Context) string { return "stage" })},
+			Second:       mb,
+			SecondOpts:   []CacheMiddlewareOption{WithCacheScope(func(context.Context) string { return "production" })},
+			ExpectedRuns: 2,
+		},
+		"refresh": {
+			Result:       base.Success(),
+			Second:       mb,
+			SecondOpts:   []CacheMiddlewareOption{WithCacheRefresh(true)},
+			ExpectedRuns: 2,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			store := newMemoryResultStore()
+
+			var runs int
+
+			run := func(context.Context, types.MetaBundle) Result {
+				runs++
+
+				return tc.Result
+			}
+
+			first := NewCacheMiddleware(store, tc.Options...).WrapValidator(v, run)(context.Background(), mb)
+			assert.False(t, first.Cached)
+
+			secondOpts := tc.SecondOpts
+			if secondOpts == nil {
+				secondOpts = tc.Options
+			}
+
+			second := NewCacheMiddleware(store, secondOpts...).WrapValidator(v, run)(context.Background(), tc.Second)
+
+			assert.Equal(t, tc.ExpectedRuns, runs)
+			assert.Equal(t, tc.ExpectedRuns == 1, second.Cached)
+			assert.Equal(t, first.Status(), second.Status())
+			assert.Equal(t, first.FailureMsgs, second.FailureMsgs)
+		})
+	}
+}
+
+func TestCacheMiddlewareValidatorVersion(t *testing.T) {
+	t.Parallel()
+
+	mb := types.MetaBundle{AddonMeta: &v1alpha1.AddonMetadataSpec{ID: "addon"}}
+
+	v1, err := NewBase(Code(1), BaseVersion(1))
+	require.NoError(t, err)
+
+	v2, err := NewBase(Code(1), BaseVersion(2))
+	require.NoError(t, err)
+
+	key1, err := resultKey(&ValidatorMock{Base: v1}, mb, "", "")
+	require.NoError(t, err)
+
+	key2, err := resultKey(&ValidatorMock{Base: v2}, mb, "", "")
+	require.NoError(t, err)
+
+	assert.NotEqual(t, key1, key2)
+}
+
+func TestRunnerCacheMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var runs int
+
+	store := newMemoryResultStore()
+
+	newRunner := func() *Runner {
+		runner, err := NewRunner(
+			WithInitializers{
+				NewValidatorMock(Code(1), "dummy_validator", "this is a dummy validator",
+					func(context.Context, types.MetaBundle) Result {
+						runs++
+
+						return Result{success: true}
+					},
+				),
+			},
+			WithMiddleware{NewCacheMiddleware(store)},
+		)
+		require.NoError(t, err)
+
+		return runner
+	}
+
+	mb := types.MetaBundle{AddonMeta: &v1alpha1.AddonMetadataSpec{ID: "addon"}}
+
+	firstResults := collectResults(newRunner().Run(context.Background(), mb))
+	require.Len(t, firstResults, 1)
+
+	secondResults := collectResults(newRunner().Run(context.Background(), mb))
+	require.Len(t, secondResults, 1)
+
+	first, second := firstResults[0], secondResults[0]
+
+	assert.Equal(t, 1, runs)
+	assert.False(t, first.Cached)
+	assert.True(t, second.Cached)
+	assert.True(t, second.IsSuccess())
+	assert.Equal(t, "dummy_validator", second.Name)
+}
+
+func newMemoryResultStore() *memoryResultStore {
+	return &memoryResultStore{
+		data: make(map[string][]byte),
+	}
+}
+
+type memoryResultStore struct {
+	lock sync.Mutex
+	data map[string][]byte
+}
+
+func (s *memoryResultStore) ReadResult(key string) ([]byte, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	data, ok := s.data[key]
+
+	return data, ok
+}
+
+func (s *memoryResultStore) WriteResult(key string, data []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.data[key] = data
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/fix"
+	"github.com/mt-sre/addon-metadata-operator/pkg/schema"
+)
+
+// Finding is a single reason for which a Validator task failed.
+type Finding struct {
+	Message string `json:"message"`
+	// Path is the JSON path of the addon metadata field which
+	// caused the failure, e.g. 'addOnParameters[0].id'. It is
+	// empty if the failure does not relate to a single field.
+	Path string `json:"path,omitempty"`
+	// Position is the location at which the field at Path is
+	// defined. It is resolved from the positions recorded by the
+	// MetaLoader once the Validator task has finished.
+	Position *schema.Position `json:"position,omitempty"`
+	// Fix optionally suggests an edit of the addon metadata
+	// file which resolves the failure.
+	Fix *fix.Edit `json:"fix,omitempty"`
+}
+
+// NewFinding returns a Finding for the field at 'path'
+// with a message formatted according to 'format'.
+func NewFinding(path, format string, args ...interface{}) Finding {
+	return Finding{
+		Message: fmt.Sprintf(format, args...),
+		Path:    path,
+	}
+}
+
+// WithFix returns a copy of the Finding suggesting the edit 'e'.
+func (f Finding) WithFix(e fix.Edit) Finding {
+	f.Fix = &e
+
+	return f
+}
+
+// FindingsAt returns one Finding for the field at 'path' per message.
+func FindingsAt(path string, msgs ...string) []Finding {
+	findings := make([]Finding, 0, len(msgs))
+
+	for _, msg := range msgs {
+		findings = append(findings, Finding{Message: msg, Path: path})
+	}
+
+	return findings
+}
+
+// String returns the message of the Finding prefixed with
+// its position if it is known.
+func (f Finding) String() string {
+	if f.Position == nil {
+		return f.Message
+	}
+
+	return fmt.Sprintf("%s: %s", f.Position, f.Message)
+}
+
+func findingsFromMessages(msgs []string) []Finding {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	findings := make([]Finding, 0, len(msgs))
+
+	for _, msg := range msgs {
+		findings = append(findings, Finding{Message: msg})
+	}
+
+	return findings
+}
+
+func messagesFromFindings(findings []Finding) []string {
+	if len(findings) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(findings))
+
+	for _, f := range findings {
+		msgs = append(msgs, f.Message)
+	}
+
+	return msgs
+}
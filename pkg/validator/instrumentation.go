@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NewLoggingMiddleware returns a middleware which logs the
+// outcome of every Validator task it wraps to 'log'.
+func NewLoggingMiddleware(log logr.Logger) *LoggingMiddleware {
+	return &LoggingMiddleware{
+		log: log,
+	}
+}
+
+type LoggingMiddleware struct {
+	log logr.Logger
+}
+
+// Wrap logs a single entry with the code, name, status, duration
+// and number of attempts of the task once it has finished. Errored
+// tasks are logged as errors.
+func (l *LoggingMiddleware) Wrap(run RunFunc) RunFunc {
+	return func(ctx context.Context, mb types.MetaBundle) Result {
+		start := time.Now()
+
+		res := run(ctx, mb)
+
+		kv := []interface{}{
+			"code", res.Code.String(),
+			"name", res.Name,
+			"status", string(res.Status()),
+			"duration", time.Since(start).String(),
+			"attempts", res.Attempts,
+		}
+
+		if res.IsError() {
+			l.log.Error(res.Error, "validator errored", kv...)
+		} else {
+			l.log.Info("validator finished", kv...)
+		}
+
+		return res
+	}
+}
+
+const metricsNamespace = "addon_metadata"
+
+// NewMetricsMiddleware returns a middleware which records Prometheus
+// metrics for every Validator task it wraps. The middleware is a
+// prometheus.Collector which must be registered to expose them.
+func NewMetricsMiddleware() *MetricsMiddleware {
+	return &MetricsMiddleware{
+		runs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "validator",
+			Name:      "runs_total",
+			Help:      "Number of validator runs by code and status.",
+		}, []string{"code", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "validator",
+			Name:      "duration_seconds",
+			Help:      "Time validators ran for including retries.",
+			// validators range from in-memory checks to
+			// pulling images and calling remote services
+			Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"code"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "validator",
+			Name:      "retries_total",
+			Help:      "Number of times validators were run again after a retryable error.",
+		}, []string{"code"}),
+	}
+}
+
+type MetricsMiddleware struct {
+	runs     *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	retries  *prometheus.CounterVec
+}
+
+func (m *MetricsMiddleware) Wrap(run RunFunc) RunFunc {
+	return func(ctx context.Context, mb types.MetaBundle) Result {
+		start := time.Now()
+
+		res := run(ctx, mb)
+
+		code := res.Code.String()
+
+		m.runs.WithLabelValues(code, string(res.Status())).Inc()
+		m.duration.WithLabelValues(code).Observe(time.Since(start).Seconds())
+
+		if res.Attempts > 1 {
+			m.retries.WithLabelValues(code).Add(float64(res.Attempts - 1))
+		}
+
+		return res
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *MetricsMiddleware) Describe(ch chan<- *prometheus.Desc) {
+	m.runs.Describe(ch)
+	m.duration.Describe(ch)
+	m.retries.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *MetricsMiddleware) Collect(ch chan<- prometheus.Metric) {
+	m.runs.Collect(ch)
+	m.duration.Collect(ch)
+	m.retries.Collect(ch)
+}
@@ -0,0 +1,144 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggingMiddleware(t *testing.T) {
+	t.Parallel()
+
+	base, err := NewBase(Code(1), BaseName("dummy_validator"))
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		Result   Result
+		Expected []string
+	}{
+		"success": {
+			Result: base.Success(),
+			Expected: []string{
+				`"msg"="validator finished"`,
+				`"code"="AM0001"`,
+				`"name"="dummy_validator"`,
+				`"status"="Success"`,
+				`"attempts"=0`,
+			},
+		},
+		"error": {
+			Result: base.Error(errors.New("boom")),
+			Expected: []string{
+				`"msg"="validator errored"`,
+				`"error"="boom"`,
+				`"status"="Error"`,
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var entries []string
+
+			log := funcr.New(func(prefix, args string) {
+				entries = append(entries, args)
+			}, funcr.Options{})
+
+			run := NewLoggingMiddleware(log).Wrap(func(context.Context, types.MetaBundle) Result {
+				return tc.Result
+			})
+
+			run(context.Background(), types.MetaBundle{})
+
+			require.Len(t, entries, 1)
+
+			for _, e := range tc.Expected {
+				assert.Contains(t, entries[0], e)
+			}
+
+			assert.Contains(t, entries[0], `"duration"=`)
+		})
+	}
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	t.Parallel()
+
+	base, err := NewBase(Code(1), BaseName("dummy_validator"))
+	require.NoError(t, err)
+
+	m := NewMetricsMiddleware()
+
+	results := []Result{
+		base.Success(),
+		base.Fail("failed"),
+		base.Success(),
+	}
+
+	results[2].Attempts = 3
+
+	for _, res := range results {
+		res := res
+
+		m.Wrap(func(context.Context, types.MetaBundle) Result { return res })(context.Background(), types.MetaBundle{})
+	}
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.runs.WithLabelValues("AM0001", "Success")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.runs.WithLabelValues("AM0001", "Failed")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.retries.WithLabelValues("AM0001")))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.duration))
+}
+
+func TestRunnerInstrumentation(t *testing.T) {
+	t.Parallel()
+
+	var observed []Result
+
+	runner, err := NewRunner(
+		WithInitializers{
+			NewValidatorMock(Code(1), "blocking_validator", "blocks until cancelled",
+				func(ctx context.Context, _ types.MetaBundle) Result {
+					<-ctx.Done()
+
+					return Result{Error: ctx.Err()}
+				},
+			),
+		},
+		WithTimeout(10*time.Millisecond),
+		WithInstrumentation{middlewareFunc(func(run RunFunc) RunFunc {
+			return func(ctx context.Context, mb types.MetaBundle) Result {
+				res := run(ctx, mb)
+
+				observed = append(observed, res)
+
+				return res
+			}
+		})},
+	)
+	require.NoError(t, err)
+
+	results := collectResults(runner.Run(context.Background(), types.MetaBundle{}))
+	require.Len(t, results, 1)
+
+	res := results[0]
+
+	require.Len(t, observed, 1)
+	assert.True(t, observed[0].IsTimedOut())
+	assert.Equal(t, Code(1), observed[0].Code)
+	assert.Equal(t, 1, observed[0].Attempts)
+	assert.GreaterOrEqual(t, observed[0].Duration, 10*time.Millisecond)
+	assert.Equal(t, observed[0].Duration, res.Duration)
+}
+
+type middlewareFunc func(RunFunc) RunFunc
+
+func (f middlewareFunc) Wrap(run RunFunc) RunFunc { return f(run) }
@@ -11,11 +11,28 @@ type Middleware interface {
 	Wrap(RunFunc) RunFunc
 }
 
+// ValidatorMiddleware is Middleware which depends on the Validator
+// whose task it wraps, e.g. to key cached results. The Runner calls
+// WrapValidator instead of Wrap for such Middleware.
+type ValidatorMiddleware interface {
+	Middleware
+	WrapValidator(Validator, RunFunc) RunFunc
+}
+
 type RunFunc func(context.Context, types.MetaBundle) Result
 
+const (
+	// DefaultRetryMaxAttempts is the number of attempts made by a
+	// RetryMiddleware when none is configured.
+	DefaultRetryMaxAttempts = 5
+	// DefaultRetryDelay is the delay between attempts made by a
+	// RetryMiddleware when none is configured.
+	DefaultRetryDelay = 2 * time.Second
+)
+
 func NewRetryMiddleware(opts ...RetryMiddlewareOption) *RetryMiddleware {
 	cfg := RetryMiddlewareConfig{
-		Delay: 2 * time.Second,
+		Backoff: ConstantBackoff(DefaultRetryDelay),
 	}
 
 	cfg.Option(opts...)
@@ -30,26 +47,109 @@ type RetryMiddleware struct {
 	cfg RetryMiddlewareConfig
 }
 
+// Wrap runs the task again after a delay chosen by the configured
+// Backoff as long as it returns retryable errors. Retries stop once
+// the maximum attempts are reached, the next delay would exceed the
+// maximum elapsed time or the context is done. The number of attempts
+// is recorded in the returned Result.
 func (r *RetryMiddleware) Wrap(run RunFunc) RunFunc {
 	return func(ctx context.Context, mb types.MetaBundle) Result {
-		var res Result
+		var (
+			res   Result
+			delay time.Duration
+			start = time.Now()
+		)
 
-		for attempts := 0; attempts < r.cfg.MaxAttempts; attempts++ {
+		for attempt := 1; ; attempt++ {
 			res = run(ctx, mb)
-			if !res.IsRetryableError() {
+			res.Attempts = attempt
+
+			if !res.IsRetryableError() || attempt >= r.cfg.MaxAttempts {
 				return res
 			}
 
-			time.Sleep(time.Duration(r.cfg.Delay))
+			delay = r.cfg.Backoff.Delay(attempt, delay)
+
+			if max := r.cfg.MaxElapsedTime; max > 0 && time.Since(start)+delay > max {
+				return res
+			}
+
+			if !wait(ctx, delay) {
+				return res
+			}
 		}
+	}
+}
+
+// wait blocks for 'delay' and returns 'true' unless
+// the context is done before the delay has passed.
+func wait(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
 
-		return res
+// NewTimeoutMiddleware returns a middleware which cancels the context
+// of a Validator task after 'timeout' and reports it as timed out. If
+// the context is cancelled by the caller instead, the task is reported
+// as cancelled. A 'timeout' of zero only reports cancellations.
+func NewTimeoutMiddleware(timeout time.Duration) *TimeoutMiddleware {
+	return &TimeoutMiddleware{
+		timeout: timeout,
+	}
+}
+
+type TimeoutMiddleware struct {
+	timeout time.Duration
+}
+
+// Wrap returns as soon as the context of the task is done. Validator
+// tasks which ignore their context keep running in the background
+// until they return, but their results are discarded.
+func (t *TimeoutMiddleware) Wrap(run RunFunc) RunFunc {
+	return func(ctx context.Context, mb types.MetaBundle) Result {
+		runCtx, cancel := context.WithCancel(ctx)
+		if t.timeout > 0 {
+			runCtx, cancel = context.WithTimeout(ctx, t.timeout)
+		}
+
+		defer cancel()
+
+		resCh := make(chan Result, 1)
+
+		go func() { resCh <- run(runCtx, mb) }()
+
+		select {
+		case res := <-resCh:
+			// tasks which return once their context is done
+			// are reported as timed out or cancelled as well
+			if runCtx.Err() == nil {
+				return res
+			}
+		case <-runCtx.Done():
+		}
+
+		if err := ctx.Err(); err != nil {
+			return cancelledResult(err)
+		}
+
+		return timedOutResult(t.timeout)
 	}
 }
 
 type RetryMiddlewareConfig struct {
 	MaxAttempts int
-	Delay       time.Duration
+	Backoff     Backoff
+	// MaxElapsedTime limits the time spent on all attempts. No
+	// attempt is made which would start after it has passed.
+	// Zero means no limit.
+	MaxElapsedTime time.Duration
 }
 
 func (c *RetryMiddlewareConfig) Option(opts ...RetryMiddlewareOption) {
@@ -60,7 +160,11 @@ func (c *RetryMiddlewareConfig) Option(opts ...RetryMiddlewareOption) {
 
 func (c *RetryMiddlewareConfig) Default() {
 	if c.MaxAttempts == 0 {
-		c.MaxAttempts = 5
+		c.MaxAttempts = DefaultRetryMaxAttempts
+	}
+
+	if c.Backoff == nil {
+		c.Backoff = ConstantBackoff(DefaultRetryDelay)
 	}
 }
 
@@ -74,8 +178,21 @@ func (ma WithMaxAttempts) ConfigureRetryMiddleware(c *RetryMiddlewareConfig) {
 	c.MaxAttempts = int(ma)
 }
 
+// WithDelay applies a ConstantBackoff with the given delay.
 type WithDelay time.Duration
 
 func (d WithDelay) ConfigureRetryMiddleware(c *RetryMiddlewareConfig) {
-	c.Delay = time.Duration(d)
+	c.Backoff = ConstantBackoff(d)
+}
+
+type WithBackoff struct{ Backoff }
+
+func (b WithBackoff) ConfigureRetryMiddleware(c *RetryMiddlewareConfig) {
+	c.Backoff = b.Backoff
+}
+
+type WithMaxElapsedTime time.Duration
+
+func (m WithMaxElapsedTime) ConfigureRetryMiddleware(c *RetryMiddlewareConfig) {
+	c.MaxElapsedTime = time.Duration(m)
 }
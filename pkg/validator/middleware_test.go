@@ -0,0 +1,98 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryMiddleware(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Options          []RetryMiddlewareOption
+		Succeed          int
+		ExpectedAttempts int
+		ExpectedSuccess  bool
+	}{
+		"succeeds without retries": {
+			Options:          []RetryMiddlewareOption{WithDelay(0)},
+			Succeed:          1,
+			ExpectedAttempts: 1,
+			ExpectedSuccess:  true,
+		},
+		"succeeds after retries": {
+			Options: []RetryMiddlewareOption{
+				WithBackoff{ExponentialBackoff{Initial: time.Millisecond}},
+			},
+			Succeed:          3,
+			ExpectedAttempts: 3,
+			ExpectedSuccess:  true,
+		},
+		"max attempts": {
+			Options:          []RetryMiddlewareOption{WithMaxAttempts(2), WithDelay(0)},
+			Succeed:          3,
+			ExpectedAttempts: 2,
+		},
+		"max elapsed time": {
+			Options: []RetryMiddlewareOption{
+				WithDelay(time.Hour),
+				WithMaxElapsedTime(time.Minute),
+			},
+			Succeed:          2,
+			ExpectedAttempts: 1,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts int
+
+			run := NewRetryMiddleware(tc.Options...).Wrap(func(context.Context, types.MetaBundle) Result {
+				attempts++
+
+				if attempts >= tc.Succeed {
+					return Result{success: true}
+				}
+
+				return Result{Error: errors.New("unavailable"), retryable: true}
+			})
+
+			res := run(context.Background(), types.MetaBundle{})
+
+			assert.Equal(t, tc.ExpectedSuccess, res.IsSuccess())
+			assert.Equal(t, tc.ExpectedAttempts, res.Attempts)
+			assert.Equal(t, tc.ExpectedAttempts, attempts)
+		})
+	}
+}
+
+func TestRetryMiddlewareStopsWaitingWhenCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	run := NewRetryMiddleware(WithDelay(time.Hour)).Wrap(func(context.Context, types.MetaBundle) Result {
+		cancel()
+
+		return Result{Error: errors.New("unavailable"), retryable: true}
+	})
+
+	done := make(chan Result)
+
+	go func() { done <- run(ctx, types.MetaBundle{}) }()
+
+	select {
+	case res := <-done:
+		assert.Equal(t, 1, res.Attempts)
+		assert.True(t, res.IsRetryableError())
+	case <-time.After(5 * time.Second):
+		t.Fatal("retry middleware kept waiting after the context was cancelled")
+	}
+}
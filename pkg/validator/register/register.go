@@ -17,4 +17,8 @@ import (
 	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0015"
 	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0016"
 	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0017"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0018"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0019"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0020"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0021"
 )
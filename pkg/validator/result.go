@@ -1,15 +1,47 @@
 package validator
 
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/fix"
+	"github.com/mt-sre/addon-metadata-operator/pkg/schema"
+)
+
 // Result encapsulates the status and reason for the result of
 // a Validator task running against a types.MetaBundle.
 type Result struct {
 	Code        Code
 	Name        string
 	Description string
+	Severity    Severity
 	FailureMsgs []string
-	Error       error
-	retryable   bool
-	success     bool
+	// Findings holds one entry per failure message which
+	// additionally locates the reason for the failure.
+	Findings []Finding
+	Error    error
+	// SkipReason explains why the Validator task was not run,
+	// e.g. 'prerequisite AM0021 failed'.
+	SkipReason string
+	// Attempts is the number of times the Validator task was run,
+	// e.g. more than once if it was retried. It is zero for tasks
+	// which were not run.
+	Attempts int
+	// Duration is the time the Validator task ran for including
+	// retries. It is zero for tasks which were not run.
+	Duration time.Duration
+	// Cached is 'true' if the Result was reused from an earlier
+	// run rather than produced by running the Validator task.
+	Cached    bool
+	retryable bool
+	success   bool
+	invalid   bool
+	skipped   bool
+	timedOut  bool
+	cancelled bool
 }
 
 // IsSuccess returns 'true' if the Validator task which
@@ -20,10 +52,278 @@ func (r Result) IsSuccess() bool { return r.success }
 // returned it encountered an error.
 func (r Result) IsError() bool { return r.Error != nil }
 
+// IsInvalid returns 'true' if the Result reports that the
+// addon metadata files could not be decoded strictly.
+func (r Result) IsInvalid() bool { return r.invalid }
+
+// IsSkipped returns 'true' if the Validator task was not
+// run because one of its prerequisites did not succeed.
+func (r Result) IsSkipped() bool { return r.skipped }
+
+// IsTimedOut returns 'true' if the Validator task did not
+// finish before its timeout. Such results are also errors.
+func (r Result) IsTimedOut() bool { return r.timedOut }
+
+// IsCancelled returns 'true' if the Validator task was interrupted
+// or never started because the run was cancelled. Such results are
+// also errors.
+func (r Result) IsCancelled() bool { return r.cancelled }
+
 // IsRetryableError returns 'true' if the Validator task which
 // returned it encountered an error, but the error can be retried.
 func (r Result) IsRetryableError() bool { return r.retryable }
 
+// WithSeverity returns a copy of the Result with its Severity
+// overridden. This allows a Validator to report individual
+// failures with a different Severity than its default.
+func (r Result) WithSeverity(sev Severity) Result {
+	r.Severity = sev
+
+	return r
+}
+
+// Locate returns a copy of the Result where every Finding with a path
+// is assigned the position of the corresponding field in 'positions'.
+func (r Result) Locate(positions schema.Positions) Result {
+	if len(positions) == 0 || len(r.Findings) == 0 {
+		return r
+	}
+
+	findings := make([]Finding, len(r.Findings))
+
+	for i, f := range r.Findings {
+		if f.Path != "" && f.Position == nil {
+			if pos, ok := positions.Lookup(f.Path); ok {
+				f.Position = &pos
+			}
+		}
+
+		findings[i] = f
+	}
+
+	r.Findings = findings
+
+	return r
+}
+
+// Status returns the ResultStatus which summarizes the Result.
+func (r Result) Status() ResultStatus {
+	switch {
+	case r.IsSuccess():
+		return ResultStatusSuccess
+	case r.IsTimedOut():
+		return ResultStatusTimedOut
+	case r.IsCancelled():
+		return ResultStatusCancelled
+	case r.IsError():
+		return ResultStatusError
+	case r.IsInvalid():
+		return ResultStatusInvalid
+	case r.IsSkipped():
+		return ResultStatusSkipped
+	default:
+		return ResultStatusFailed
+	}
+}
+
+// ResultStatus summarizes the outcome of a Validator task.
+type ResultStatus string
+
+const (
+	ResultStatusSuccess ResultStatus = "Success"
+	ResultStatusFailed  ResultStatus = "Failed"
+	ResultStatusError   ResultStatus = "Error"
+	// ResultStatusInvalid marks results which do not stem from a
+	// Validator but report malformed addon metadata files.
+	ResultStatusInvalid ResultStatus = "Invalid"
+	// ResultStatusSkipped marks results of Validators which were
+	// not run because one of their prerequisites did not succeed.
+	ResultStatusSkipped ResultStatus = "Skipped"
+	// ResultStatusTimedOut marks results of Validators which
+	// did not finish before their timeout.
+	ResultStatusTimedOut ResultStatus = "TimedOut"
+	// ResultStatusCancelled marks results of Validators which were
+	// interrupted or never started because the run was cancelled.
+	ResultStatusCancelled ResultStatus = "Cancelled"
+)
+
+// NewSkippedResult returns a Result of status ResultStatusSkipped
+// for the Validator 'v' which was not run for the given reason.
+func NewSkippedResult(v Validator, reason string) Result {
+	res := Result{
+		SkipReason: reason,
+		skipped:    true,
+	}
+
+	return res.identify(v)
+}
+
+// NewCancelledResult returns a Result of status ResultStatusCancelled
+// for the Validator 'v' which did not finish because of 'err', the
+// error of the cancelled context.
+func NewCancelledResult(v Validator, err error) Result {
+	return cancelledResult(err).identify(v)
+}
+
+// NewTimedOutResult returns a Result of status ResultStatusTimedOut
+// for the Validator 'v' which did not finish within 'timeout'.
+func NewTimedOutResult(v Validator, timeout time.Duration) Result {
+	return timedOutResult(timeout).identify(v)
+}
+
+func cancelledResult(err error) Result {
+	return Result{
+		Error:     fmt.Errorf("cancelled: %w", err),
+		cancelled: true,
+	}
+}
+
+func timedOutResult(timeout time.Duration) Result {
+	return Result{
+		Error:    fmt.Errorf("timed out after %s: %w", timeout, context.DeadlineExceeded),
+		timedOut: true,
+	}
+}
+
+// identify attributes 'r' to the Validator 'v' unless 'r' was
+// returned by a Validator. Results created outside of Validators,
+// e.g. by middleware reporting timeouts, carry no name of their own.
+func (r Result) identify(v Validator) Result {
+	if r.Name != "" {
+		return r
+	}
+
+	r.Code = v.Code()
+	r.Name = v.Name()
+	r.Description = v.Description()
+	r.Severity = v.Severity()
+
+	return r
+}
+
+// NewInvalidInputResult returns a Result of status ResultStatusInvalid
+// with one Finding per issue found while strictly decoding the addon
+// metadata files. The Result is not produced by a Validator and thus
+// carries no Code.
+func NewInvalidInputResult(issues ...schema.DecodeIssue) Result {
+	findings := make([]Finding, 0, len(issues))
+
+	for _, i := range issues {
+		pos := i.Position()
+
+		findings = append(findings, Finding{
+			Message:  i.Describe(),
+			Path:     i.Path,
+			Position: &pos,
+		})
+	}
+
+	return Result{
+		Name:        "strict_decoding",
+		Description: "Addon metadata files only contain known fields of the expected types and no duplicate keys",
+		Severity:    SeverityError,
+		FailureMsgs: messagesFromFindings(findings),
+		Findings:    findings,
+		invalid:     true,
+	}
+}
+
+// MarshalJSON implements json.Marshaler and provides a stable
+// serialized form of a Result which includes its status.
+func (r Result) MarshalJSON() ([]byte, error) {
+	res := serializedResult{
+		Code:        r.Code,
+		Name:        r.Name,
+		Description: r.Description,
+		Severity:    r.Severity,
+		Status:      r.Status(),
+		FailureMsgs: r.FailureMsgs,
+		Findings:    r.Findings,
+		SkipReason:  r.SkipReason,
+		Attempts:    r.Attempts,
+		Cached:      r.Cached,
+		Retryable:   r.retryable,
+		Wiki:        r.Code.WikiURL(),
+	}
+
+	if r.Error != nil {
+		res.Error = r.Error.Error()
+	}
+
+	if r.Duration > 0 {
+		res.Duration = r.Duration.String()
+	}
+
+	return json.Marshal(res)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and restores a Result
+// from the form produced by MarshalJSON. Errors are restored as
+// opaque errors carrying the original message.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	var res serializedResult
+
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+
+	*r = Result{
+		Code:        res.Code,
+		Name:        res.Name,
+		Description: res.Description,
+		Severity:    res.Severity,
+		FailureMsgs: res.FailureMsgs,
+		Findings:    res.Findings,
+		SkipReason:  res.SkipReason,
+		Attempts:    res.Attempts,
+		Cached:      res.Cached,
+		retryable:   res.Retryable,
+		success:     res.Status == ResultStatusSuccess,
+		invalid:     res.Status == ResultStatusInvalid,
+		skipped:     res.Status == ResultStatusSkipped,
+		timedOut:    res.Status == ResultStatusTimedOut,
+		cancelled:   res.Status == ResultStatusCancelled,
+	}
+
+	// results serialized before findings were introduced
+	// only carry failure messages
+	if r.Findings == nil {
+		r.Findings = findingsFromMessages(r.FailureMsgs)
+	}
+
+	if res.Error != "" {
+		r.Error = errors.New(res.Error)
+	}
+
+	if res.Duration != "" {
+		d, err := time.ParseDuration(res.Duration)
+		if err != nil {
+			return fmt.Errorf("parsing duration: %w", err)
+		}
+
+		r.Duration = d
+	}
+
+	return nil
+}
+
+type serializedResult struct {
+	Code        Code         `json:"code,omitempty"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Severity    Severity     `json:"severity"`
+	Status      ResultStatus `json:"status"`
+	FailureMsgs []string     `json:"failureMessages,omitempty"`
+	Findings    []Finding    `json:"findings,omitempty"`
+	Error       string       `json:"error,omitempty"`
+	SkipReason  string       `json:"skipReason,omitempty"`
+	Attempts    int          `json:"attempts,omitempty"`
+	Duration    string       `json:"duration,omitempty"`
+	Cached      bool         `json:"cached,omitempty"`
+	Retryable   bool         `json:"retryable,omitempty"`
+	Wiki        string       `json:"wiki"`
+}
+
 // ResultList is a sortable slice of Result instances.
 type ResultList []Result
 
@@ -32,10 +332,11 @@ func (l ResultList) Less(i, j int) bool { return l[i].Code < l[j].Code }
 func (l ResultList) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
 
 // HasFailure returns 'true' if any of the ResultList members
-// are failures or errors.
+// are failures or errors. Skipped results are not failures as
+// the prerequisite which caused them is reported instead.
 func (l ResultList) HasFailure() bool {
 	for _, r := range l {
-		if r.IsSuccess() {
+		if r.IsSuccess() || r.IsSkipped() {
 			continue
 		}
 
@@ -45,6 +346,48 @@ func (l ResultList) HasFailure() bool {
 	return false
 }
 
+// HasFailureAtOrAbove returns 'true' if any of the ResultList
+// members are errors or are failures with a Severity greater
+// than or equal to the given threshold.
+func (l ResultList) HasFailureAtOrAbove(threshold Severity) bool {
+	for _, r := range l {
+		if r.IsSuccess() || r.IsSkipped() {
+			continue
+		}
+
+		if r.IsError() || r.Severity >= threshold {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FixesByFile returns the edits suggested by the findings of the
+// ResultList members in order, grouped by the file defining the field
+// of each finding, e.g. the imageset for fields provided by it. Edits
+// of findings without a position are assigned to 'defaultFile'.
+func (l ResultList) FixesByFile(defaultFile string) map[string][]fix.Edit {
+	fixes := make(map[string][]fix.Edit)
+
+	for _, r := range l {
+		for _, f := range r.Findings {
+			if f.Fix == nil {
+				continue
+			}
+
+			file := defaultFile
+			if f.Position != nil && f.Position.File != "" {
+				file = f.Position.File
+			}
+
+			fixes[file] = append(fixes[file], *f.Fix)
+		}
+	}
+
+	return fixes
+}
+
 // Errors returns a slice of errors from the ResultList
 // members. If no errors were encountered then an empty slice
 // is returned.
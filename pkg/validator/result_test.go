@@ -0,0 +1,42 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/fix"
+	"github.com/mt-sre/addon-metadata-operator/pkg/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultListFixesByFile(t *testing.T) {
+	t.Parallel()
+
+	base, err := NewBase(Code(1), BaseName("dummy_validator"))
+	require.NoError(t, err)
+
+	setLabel := fix.Edit{Description: "set label", Op: fix.OpSet, Path: "label", Value: "label"}
+	setConfig := fix.Edit{Description: "set config", Op: fix.OpSet, Path: "config.env", Value: []string{}}
+	setChannel := fix.Edit{Description: "set channel", Op: fix.OpSet, Path: "defaultChannel", Value: "alpha"}
+
+	located := func(f Finding, file string) Finding {
+		f.Position = &schema.Position{File: file, Line: 1}
+
+		return f
+	}
+
+	results := ResultList{
+		base.FailWith(
+			located(NewFinding("label", "wrong label").WithFix(setLabel), "metadata/stage/addon.yaml"),
+			located(NewFinding("config", "wrong config").WithFix(setConfig), "addonimagesets/stage/addon.v1.0.0.yaml"),
+			NewFinding("defaultChannel", "missing channel").WithFix(setChannel),
+			NewFinding("namespaces", "no fix"),
+		),
+		base.Success(),
+	}
+
+	assert.Equal(t, map[string][]fix.Edit{
+		"metadata/stage/addon.yaml":              {setLabel, setChannel},
+		"addonimagesets/stage/addon.v1.0.0.yaml": {setConfig},
+	}, results.FixesByFile("metadata/stage/addon.yaml"))
+}
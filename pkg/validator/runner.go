@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
@@ -91,6 +93,10 @@ func NewRunner(opts ...RunnerOption) (*Runner, error) {
 		}
 	}
 
+	if err := checkPrerequisiteCycles(entries); err != nil {
+		return nil, err
+	}
+
 	return &Runner{
 		cfg:     cfg,
 		entries: entries,
@@ -102,34 +108,123 @@ type Runner struct {
 	entries map[Code]validatorEntry
 }
 
+// Run runs the Validators matching all filters against 'mb' and
+// streams their results. Validators run concurrently in waves so
+// that each one runs after the selected Validators it declares as
+// prerequisites. Validators with a prerequisite which did not
+// succeed are not run and reported as skipped instead.
+//
+// Every selected Validator emits exactly one Result, even if 'ctx'
+// is cancelled. The channel buffers all results so that the Runner
+// does not block on callers which stop reading early.
 func (r *Runner) Run(ctx context.Context, mb types.MetaBundle, filters ...Filter) <-chan Result {
-	resultCh := make(chan Result)
+	vals := r.GetValidators(filters...)
+	resultCh := make(chan Result, len(vals))
 
-	var wg sync.WaitGroup
+	var slots chan struct{}
 
-	vals := r.GetValidators(filters...)
+	if r.cfg.MaxParallelism > 0 {
+		slots = make(chan struct{}, r.cfg.MaxParallelism)
+	}
+
+	go func() {
+		defer close(resultCh)
 
-	wg.Add(len(vals))
+		done := make(map[Code]Result)
 
-	for _, val := range vals {
-		go func(v Validator) {
+		for _, wave := range waves(vals) {
+			for _, res := range r.runWave(ctx, mb, wave, done, slots, resultCh) {
+				done[res.Code] = res
+			}
+		}
+	}()
+
+	return resultCh
+}
+
+// runWave runs the Validators of a wave concurrently and sends their
+// results to 'resultCh'. Validators with a prerequisite in 'done' which
+// did not succeed are skipped. Each Validator occupies one of 'slots'
+// while it runs unless 'slots' is nil. The results are returned once
+// every Validator of the wave has finished.
+func (r *Runner) runWave(
+	ctx context.Context,
+	mb types.MetaBundle,
+	wave []Validator,
+	done map[Code]Result,
+	slots chan struct{},
+	resultCh chan<- Result,
+) []Result {
+	results := make([]Result, len(wave))
+
+	var wg sync.WaitGroup
+
+	wg.Add(len(wave))
+
+	for i, val := range wave {
+		go func(i int, v Validator) {
 			defer wg.Done()
 
-			run := r.applyMiddleware(v.Run)
+			results[i] = r.runValidator(ctx, mb, v, done, slots)
 
-			select {
-			case <-ctx.Done():
-			case resultCh <- run(ctx, mb):
-			}
-		}(val)
+			resultCh <- results[i]
+		}(i, val)
 	}
 
-	go func() {
-		wg.Wait()
-		close(resultCh)
-	}()
+	wg.Wait()
 
-	return resultCh
+	return results
+}
+
+func (r *Runner) runValidator(ctx context.Context, mb types.MetaBundle, v Validator, done map[Code]Result, slots chan struct{}) Result {
+	if err := ctx.Err(); err != nil {
+		return NewCancelledResult(v, err)
+	}
+
+	if reason := skipReason(v, done); reason != "" {
+		return NewSkippedResult(v, reason)
+	}
+
+	if slots != nil {
+		select {
+		case <-ctx.Done():
+			return NewCancelledResult(v, ctx.Err())
+		case slots <- struct{}{}:
+		}
+
+		defer func() { <-slots }()
+	}
+
+	run := NewTimeoutMiddleware(r.cfg.Timeout).Wrap(r.applyMiddleware(v))
+
+	return r.instrument(v, run)(ctx, mb)
+}
+
+// instrument completes the results of 'run' for the Validator 'v'
+// and wraps it with the logging middleware of the Runner and any
+// configured instrumentation, so that these observe the final Result
+// of every Validator task which is run, including timed out tasks.
+func (r *Runner) instrument(v Validator, run RunFunc) RunFunc {
+	res := func(ctx context.Context, mb types.MetaBundle) Result {
+		start := time.Now()
+
+		res := run(ctx, mb).identify(v).Locate(mb.Positions)
+
+		// attempts are only counted by the RetryMiddleware
+		if res.Attempts == 0 {
+			res.Attempts = 1
+		}
+
+		res.Duration = time.Since(start)
+
+		return res
+	}
+
+	for _, mw := range r.cfg.Instrumentation {
+		res = mw.Wrap(res)
+	}
+
+	return NewLoggingMiddleware(r.cfg.Logger).Wrap(res)
 }
 
 func (r *Runner) GetValidators(filters ...Filter) []Validator {
@@ -148,22 +243,35 @@ func (r *Runner) GetValidators(filters ...Filter) []Validator {
 	return result
 }
 
-func (r *Runner) applyMiddleware(run RunFunc) RunFunc {
-	res := run
+func (r *Runner) applyMiddleware(v Validator) RunFunc {
+	res := v.Run
 
 	for _, mw := range r.cfg.Middleware {
-		res = mw.Wrap(res)
+		if vmw, ok := mw.(ValidatorMiddleware); ok {
+			res = vmw.WrapValidator(v, res)
+		} else {
+			res = mw.Wrap(res)
+		}
 	}
 
 	return res
 }
 
 type RunnerConfig struct {
-	Initializers     []Initializer
-	Logger           logr.Logger
-	Middleware       []Middleware
-	OCMClient        OCMClient
-	QuayClient       QuayClient
+	Initializers []Initializer
+	// Instrumentation wraps Validator tasks outside of the Timeout
+	// and Middleware to observe their final results.
+	Instrumentation []Middleware
+	Logger          logr.Logger
+	// MaxParallelism limits the number of Validators running
+	// at the same time. Zero means no limit.
+	MaxParallelism int
+	Middleware     []Middleware
+	OCMClient      OCMClient
+	QuayClient     QuayClient
+	// Timeout limits the time each Validator may run for
+	// including retries. Zero means no limit.
+	Timeout          time.Duration
 	ValidatorOptions []ValidatorOption
 }
 
@@ -207,6 +315,24 @@ type WithMiddleware []Middleware
 
 func (m WithMiddleware) ApplyToRunnerConfig(c *RunnerConfig) { c.Middleware = m }
 
+// WithInstrumentation applies Middleware such as the MetricsMiddleware
+// which observes the final Result of every Validator task which is run.
+type WithInstrumentation []Middleware
+
+func (i WithInstrumentation) ApplyToRunnerConfig(c *RunnerConfig) { c.Instrumentation = i }
+
+// WithMaxParallelism applies the maximum number of
+// Validators which run at the same time.
+type WithMaxParallelism int
+
+func (m WithMaxParallelism) ApplyToRunnerConfig(c *RunnerConfig) { c.MaxParallelism = int(m) }
+
+// WithTimeout applies the time after which a running Validator
+// is cancelled and reported as timed out.
+type WithTimeout time.Duration
+
+func (t WithTimeout) ApplyToRunnerConfig(c *RunnerConfig) { c.Timeout = time.Duration(t) }
+
 type WithOCMClient struct{ OCMClient }
 
 func (o WithOCMClient) ApplyToRunnerConfig(c *RunnerConfig) { c.OCMClient = o }
@@ -221,6 +347,167 @@ func (w WithValidatorOptions) ApplyToRunnerConfig(c *RunnerConfig) {
 	c.ValidatorOptions = append(c.ValidatorOptions, w...)
 }
 
+// prerequisitesOf returns the codes of the Validators
+// which must succeed before 'v' is run.
+func prerequisitesOf(v Validator) []Code {
+	p, ok := v.(interface{ Prerequisites() []Code })
+	if !ok {
+		return nil
+	}
+
+	return p.Prerequisites()
+}
+
+// checkPrerequisiteCycles returns an error if the prerequisites of
+// the registered Validators form a cycle. Prerequisites which are
+// not registered are ignored.
+func checkPrerequisiteCycles(entries map[Code]validatorEntry) error {
+	const (
+		visiting = iota + 1
+		visited
+	)
+
+	state := make(map[Code]int, len(entries))
+
+	var visit func(c Code, path []Code) error
+
+	visit = func(c Code, path []Code) error {
+		e, ok := entries[c]
+		if !ok {
+			return nil
+		}
+
+		path = append(path, c)
+
+		switch state[c] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := make([]string, 0, len(path))
+
+			for i := len(path) - 2; i >= 0; i-- {
+				if path[i] == c {
+					for _, p := range path[i:] {
+						cycle = append(cycle, p.String())
+					}
+
+					break
+				}
+			}
+
+			return fmt.Errorf("prerequisites of validators form a cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[c] = visiting
+
+		for _, p := range prerequisitesOf(e.Validator) {
+			if err := visit(p, path); err != nil {
+				return err
+			}
+		}
+
+		state[c] = visited
+
+		return nil
+	}
+
+	codes := make([]Code, 0, len(entries))
+
+	for c := range entries {
+		codes = append(codes, c)
+	}
+
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+	for _, c := range codes {
+		if err := visit(c, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// waves groups 'vals' so that every Validator is placed in a later
+// wave than the members of 'vals' it declares as prerequisites. The
+// order of 'vals' is preserved within each wave.
+func waves(vals []Validator) [][]Validator {
+	selected := make(map[Code]Validator, len(vals))
+
+	for _, v := range vals {
+		selected[v.Code()] = v
+	}
+
+	depths := make(map[Code]int, len(vals))
+
+	var depth func(v Validator) int
+
+	depth = func(v Validator) int {
+		if d, ok := depths[v.Code()]; ok {
+			return d
+		}
+
+		var d int
+
+		for _, c := range prerequisitesOf(v) {
+			p, ok := selected[c]
+			if !ok {
+				continue
+			}
+
+			if pd := depth(p) + 1; pd > d {
+				d = pd
+			}
+		}
+
+		depths[v.Code()] = d
+
+		return d
+	}
+
+	var res [][]Validator
+
+	for _, v := range vals {
+		d := depth(v)
+
+		for len(res) <= d {
+			res = append(res, nil)
+		}
+
+		res[d] = append(res[d], v)
+	}
+
+	return res
+}
+
+// skipReason returns why 'v' must not run given the results of its
+// prerequisites in 'done' or an empty string if it may run.
+// Prerequisites without a result were not selected and are ignored.
+func skipReason(v Validator, done map[Code]Result) string {
+	for _, c := range prerequisitesOf(v) {
+		res, ok := done[c]
+		if !ok {
+			continue
+		}
+
+		switch res.Status() {
+		case ResultStatusSuccess:
+		case ResultStatusTimedOut:
+			return fmt.Sprintf("prerequisite %s timed out", c)
+		case ResultStatusCancelled:
+			return fmt.Sprintf("prerequisite %s was cancelled", c)
+		case ResultStatusError:
+			return fmt.Sprintf("prerequisite %s errored", c)
+		case ResultStatusSkipped:
+			return fmt.Sprintf("prerequisite %s skipped", c)
+		default:
+			return fmt.Sprintf("prerequisite %s failed", c)
+		}
+	}
+
+	return ""
+}
+
 type validatorEntry struct {
 	Validator
 }
@@ -231,7 +518,7 @@ func (e *validatorEntry) Satisfies(filters ...Filter) bool {
 	}
 
 	for _, f := range filters {
-		if f == nil || f(e) {
+		if f == nil || f(e.Validator) {
 			continue
 		}
 
@@ -255,6 +542,27 @@ func MatchesCodes(codes ...Code) Filter {
 	}
 }
 
+// Requires returns a Filter matching Validators which
+// declare any of the given requirements.
+func Requires(reqs ...Requirement) Filter {
+	return func(v Validator) bool {
+		r, ok := v.(interface{ Requires() []Requirement })
+		if !ok {
+			return false
+		}
+
+		for _, have := range r.Requires() {
+			for _, want := range reqs {
+				if have == want {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+}
+
 func Not(f Filter) Filter {
 	return func(v Validator) bool {
 		return !f(v)
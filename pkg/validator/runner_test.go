@@ -2,9 +2,16 @@ package validator
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/mt-sre/addon-metadata-operator/pkg/schema"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -98,6 +105,42 @@ func TestRunnerRegistration(t *testing.T) {
 	assert.Len(t, vals, 0)
 }
 
+func TestRunnerRequiresFilter(t *testing.T) {
+	t.Parallel()
+
+	newValidator := func(code Code, reqs ...Requirement) Initializer {
+		return func(Dependencies) (Validator, error) {
+			base, err := NewBase(code, BaseRequires(reqs...))
+
+			return &ValidatorMock{Base: base}, err
+		}
+	}
+
+	runner, err := NewRunner(
+		WithInitializers{
+			newValidator(Code(1)),
+			newValidator(Code(2), RequiresBundles),
+			newValidator(Code(3), RequiresNetwork),
+			newValidator(Code(4), RequiresBundles, RequiresNetwork),
+		},
+	)
+	require.NoError(t, err)
+
+	codes := func(vals []Validator) []Code {
+		res := make([]Code, 0, len(vals))
+
+		for _, v := range vals {
+			res = append(res, v.Code())
+		}
+
+		return res
+	}
+
+	assert.Equal(t, []Code{2, 4}, codes(runner.GetValidators(Requires(RequiresBundles))))
+	assert.Equal(t, []Code{2, 3, 4}, codes(runner.GetValidators(Requires(RequiresBundles, RequiresNetwork))))
+	assert.Equal(t, []Code{1}, codes(runner.GetValidators(Not(Requires(RequiresBundles, RequiresNetwork)))))
+}
+
 func TestRunnerMiddleware(t *testing.T) {
 	t.Parallel()
 
@@ -133,8 +176,327 @@ func TestRunnerMiddleware(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	<-runner.Run(context.TODO(), types.MetaBundle{})
+	results := collectResults(runner.Run(context.TODO(), types.MetaBundle{}))
+	require.Len(t, results, 1)
+
+	res := results[0]
 	assert.Equal(t, expectedCount, actualCount)
+	assert.Equal(t, expectedCount, res.Attempts)
+}
+
+func TestRunnerLocatesFindings(t *testing.T) {
+	t.Parallel()
+
+	base, err := NewBase(Code(1), BaseName("dummy_validator"))
+	require.NoError(t, err)
+
+	runner, err := NewRunner(
+		WithInitializers{
+			func(Dependencies) (Validator, error) {
+				return &ValidatorMock{
+					Base: base,
+					runner: func(context.Context, types.MetaBundle) Result {
+						return base.FailWith(
+							NewFinding("namespaces[1]", "namespace %q is invalid", "Foo"),
+							NewFinding("pagerduty.secretName", "secret name is missing"),
+							NewFinding("", "not located"),
+						)
+					},
+				}, nil
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	results := collectResults(runner.Run(context.TODO(), types.MetaBundle{
+		Positions: schema.Positions{
+			"namespaces[1]": {File: "addon.yaml", Line: 5, Column: 5},
+			"pagerduty":     {File: "addon.yaml", Line: 7, Column: 1},
+		},
+	}))
+	require.Len(t, results, 1)
+
+	res := results[0]
+
+	var actual []string
+
+	for _, f := range res.Findings {
+		actual = append(actual, f.String())
+	}
+
+	assert.Equal(t, []string{
+		`addon.yaml:5:5: namespace "Foo" is invalid`,
+		"addon.yaml:7:1: secret name is missing",
+		"not located",
+	}, actual)
+	assert.Equal(t, []string{`namespace "Foo" is invalid`, "secret name is missing", "not located"}, res.FailureMsgs)
+}
+
+func TestRunnerPrerequisites(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu  sync.Mutex
+		ran []Code
+	)
+
+	newValidator := func(code Code, res func(*Base) Result, prereqs ...Code) Initializer {
+		return func(Dependencies) (Validator, error) {
+			base, err := NewBase(code, BasePrerequisites(prereqs...))
+
+			return &ValidatorMock{
+				Base: base,
+				runner: func(context.Context, types.MetaBundle) Result {
+					mu.Lock()
+					defer mu.Unlock()
+
+					ran = append(ran, code)
+
+					return res(base)
+				},
+			}, err
+		}
+	}
+
+	succeed := func(b *Base) Result { return b.Success() }
+	fail := func(b *Base) Result { return b.Fail("failed") }
+
+	runner, err := NewRunner(
+		WithInitializers{
+			newValidator(Code(1), succeed),
+			newValidator(Code(2), fail),
+			newValidator(Code(3), succeed, Code(1)),
+			newValidator(Code(4), succeed, Code(2)),
+			newValidator(Code(5), succeed, Code(4)),
+			newValidator(Code(6), succeed, Code(1), Code(7)),
+		},
+	)
+	require.NoError(t, err)
+
+	statuses := make(map[Code]ResultStatus)
+	reasons := make(map[Code]string)
+
+	for res := range runner.Run(context.Background(), types.MetaBundle{}) {
+		statuses[res.Code] = res.Status()
+		reasons[res.Code] = res.SkipReason
+	}
+
+	assert.Equal(t, map[Code]ResultStatus{
+		1: ResultStatusSuccess,
+		2: ResultStatusFailed,
+		3: ResultStatusSuccess,
+		4: ResultStatusSkipped,
+		5: ResultStatusSkipped,
+		6: ResultStatusSuccess,
+	}, statuses)
+	assert.Equal(t, "prerequisite AM0002 failed", reasons[4])
+	assert.Equal(t, "prerequisite AM0004 skipped", reasons[5])
+	assert.ElementsMatch(t, []Code{1, 2, 3, 6}, ran)
+
+	ran = nil
+
+	for res := range runner.Run(context.Background(), types.MetaBundle{}, MatchesCodes(Code(4))) {
+		assert.Equal(t, ResultStatusSuccess, res.Status())
+	}
+
+	assert.Equal(t, []Code{4}, ran, "unselected prerequisites must be ignored")
+}
+
+func TestRunnerPrerequisiteCycle(t *testing.T) {
+	t.Parallel()
+
+	newValidator := func(code Code, prereqs ...Code) Initializer {
+		return func(Dependencies) (Validator, error) {
+			base, err := NewBase(code, BasePrerequisites(prereqs...))
+
+			return &ValidatorMock{Base: base}, err
+		}
+	}
+
+	_, err := NewRunner(
+		WithInitializers{
+			newValidator(Code(1), Code(3)),
+			newValidator(Code(2)),
+			newValidator(Code(3), Code(2), Code(1)),
+		},
+	)
+	assert.EqualError(t, err, "prerequisites of validators form a cycle: AM0001 -> AM0003 -> AM0001")
+}
+
+func TestRunnerTimeout(t *testing.T) {
+	t.Parallel()
+
+	runner, err := NewRunner(
+		WithInitializers{
+			NewValidatorMock(Code(1), "blocking_validator", "blocks until cancelled",
+				func(ctx context.Context, _ types.MetaBundle) Result {
+					<-ctx.Done()
+
+					return Result{Error: ctx.Err()}
+				},
+			),
+			NewValidatorMock(Code(2), "dummy_validator", "this is a dummy validator",
+				func(context.Context, types.MetaBundle) Result {
+					return Result{success: true}
+				},
+			),
+		},
+		WithTimeout(10*time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	var results ResultList
+
+	for res := range runner.Run(context.Background(), types.MetaBundle{}) {
+		results = append(results, res)
+	}
+
+	sort.Sort(results)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, ResultStatusTimedOut, results[0].Status())
+	assert.Equal(t, "blocking_validator", results[0].Name)
+	assert.True(t, results[0].IsError())
+	assert.ErrorIs(t, results[0].Error, context.DeadlineExceeded)
+	assert.EqualError(t, results[0].Error, "timed out after 10ms: context deadline exceeded")
+	assert.Equal(t, ResultStatusSuccess, results[1].Status())
+}
+
+func TestRunnerCancellation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started := make(chan struct{})
+
+	newValidator := func(code Code, run func(context.Context) Result, prereqs ...Code) Initializer {
+		return func(Dependencies) (Validator, error) {
+			base, err := NewBase(code, BasePrerequisites(prereqs...))
+
+			return &ValidatorMock{
+				Base: base,
+				runner: func(ctx context.Context, _ types.MetaBundle) Result {
+					return run(ctx)
+				},
+			}, err
+		}
+	}
+
+	runner, err := NewRunner(
+		WithInitializers{
+			newValidator(Code(1), func(ctx context.Context) Result {
+				close(started)
+				<-ctx.Done()
+
+				return Result{success: true}
+			}),
+			newValidator(Code(2), func(context.Context) Result {
+				t.Error("dependent of a cancelled validator must not run")
+
+				return Result{}
+			}, Code(1)),
+		},
+	)
+	require.NoError(t, err)
+
+	resultCh := runner.Run(ctx, types.MetaBundle{})
+
+	<-started
+	cancel()
+
+	statuses := make(map[Code]ResultStatus)
+
+	for res := range resultCh {
+		statuses[res.Code] = res.Status()
+
+		assert.ErrorIs(t, res.Error, context.Canceled)
+	}
+
+	assert.Equal(t, map[Code]ResultStatus{
+		1: ResultStatusCancelled,
+		2: ResultStatusCancelled,
+	}, statuses)
+}
+
+func TestRunnerDoesNotBlockOnUnreadResults(t *testing.T) {
+	t.Parallel()
+
+	var finished int32
+
+	run := func(context.Context, types.MetaBundle) Result {
+		atomic.AddInt32(&finished, 1)
+
+		return Result{success: true}
+	}
+
+	dependent := func(Dependencies) (Validator, error) {
+		base, err := NewBase(Code(3), BasePrerequisites(1))
+
+		return &ValidatorMock{Base: base, runner: run}, err
+	}
+
+	runner, err := NewRunner(
+		WithInitializers{
+			NewValidatorMock(Code(1), "validator_1", "counts", run),
+			NewValidatorMock(Code(2), "validator_2", "counts", run),
+			dependent,
+		},
+	)
+	require.NoError(t, err)
+
+	resultCh := runner.Run(context.Background(), types.MetaBundle{})
+
+	// all waves complete although no Result is read
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&finished) == 3 && len(resultCh) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Len(t, collectResults(resultCh), 3)
+}
+
+func TestRunnerMaxParallelism(t *testing.T) {
+	t.Parallel()
+
+	const maxParallelism = 2
+
+	var running, maxRunning int32
+
+	run := func(context.Context, types.MetaBundle) Result {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+
+		return Result{success: true}
+	}
+
+	var inits WithInitializers
+
+	for i := 1; i <= 6; i++ {
+		inits = append(inits, NewValidatorMock(Code(i), fmt.Sprintf("validator_%d", i), "sleeps", run))
+	}
+
+	runner, err := NewRunner(inits, WithMaxParallelism(maxParallelism))
+	require.NoError(t, err)
+
+	var count int
+
+	for res := range runner.Run(context.Background(), types.MetaBundle{}) {
+		assert.True(t, res.IsSuccess())
+
+		count++
+	}
+
+	assert.Equal(t, 6, count)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(maxParallelism))
 }
 
 func NewValidatorMock(
@@ -164,3 +526,71 @@ type ValidatorMock struct {
 func (v ValidatorMock) Run(ctx context.Context, mb types.MetaBundle) Result {
 	return v.runner(ctx, mb)
 }
+
+func TestResultJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	base, err := NewBase(Code(1), BaseName("dummy_validator"))
+	require.NoError(t, err)
+
+	retried := base.Success()
+	retried.Attempts = 3
+	retried.Duration = 1500 * time.Millisecond
+
+	cached := base.Fail("failed")
+	cached.Cached = true
+
+	for name, res := range map[string]Result{
+		"success": base.Success(),
+		"fail":    base.Fail("first", "second"),
+		"fail with findings": base.FailWith(NewFinding("id", "invalid id")).Locate(schema.Positions{
+			"id": {File: "addon.yaml", Line: 1, Column: 1},
+		}),
+		"error":           base.Error(errors.New("boom")),
+		"retryable error": base.RetryableError(errors.New("boom")),
+		"skipped":         NewSkippedResult(&ValidatorMock{Base: base}, "prerequisite AM0002 failed"),
+		"timed out":       NewTimedOutResult(&ValidatorMock{Base: base}, time.Second),
+		"cancelled":       NewCancelledResult(&ValidatorMock{Base: base}, context.Canceled),
+		"retried":         retried,
+		"cached":          cached,
+	} {
+		res := res
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := json.Marshal(res)
+			require.NoError(t, err)
+
+			var actual Result
+			require.NoError(t, json.Unmarshal(data, &actual))
+
+			assert.Equal(t, res.Code, actual.Code)
+			assert.Equal(t, res.Name, actual.Name)
+			assert.Equal(t, res.Status(), actual.Status())
+			assert.Equal(t, res.FailureMsgs, actual.FailureMsgs)
+			assert.Equal(t, res.Findings, actual.Findings)
+			assert.Equal(t, res.IsRetryableError(), actual.IsRetryableError())
+			assert.Equal(t, res.SkipReason, actual.SkipReason)
+			assert.Equal(t, res.Attempts, actual.Attempts)
+			assert.Equal(t, res.Duration, actual.Duration)
+			assert.Equal(t, res.Cached, actual.Cached)
+
+			if res.IsError() {
+				assert.EqualError(t, actual.Error, res.Error.Error())
+			}
+		})
+	}
+}
+
+// collectResults returns the Results of a Runner
+// once it has closed 'results'.
+func collectResults(results <-chan Result) []Result {
+	var collected []Result
+
+	for res := range results {
+		collected = append(collected, res)
+	}
+
+	return collected
+}
@@ -0,0 +1,63 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity describes how strongly a failed Result should
+// be considered when deciding the outcome of a validation run.
+type Severity int
+
+const (
+	// SeverityInfo failures are purely informational.
+	SeverityInfo Severity = iota
+	// SeverityWarning failures indicate a likely problem which
+	// should not block a release by default.
+	SeverityWarning
+	// SeverityError failures indicate a problem which must be
+	// fixed. This is the default severity of a Validator.
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("unknown severity <%d>", int(s))
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Severity) UnmarshalText(text []byte) error {
+	sev, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = sev
+
+	return nil
+}
+
+// ParseSeverity converts a given string to a Severity value.
+// An error is returned if the string does not name a severity.
+func ParseSeverity(maybeSeverity string) (Severity, error) {
+	for _, s := range []Severity{SeverityInfo, SeverityWarning, SeverityError} {
+		if strings.EqualFold(maybeSeverity, s.String()) {
+			return s, nil
+		}
+	}
+
+	return SeverityError, fmt.Errorf("unable to parse severity from '%s'", maybeSeverity)
+}
@@ -0,0 +1,106 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeverity(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Input          string
+		Expected       Severity
+		ErrorAssertion assert.ErrorAssertionFunc
+	}{
+		"info": {
+			Input:          "info",
+			Expected:       SeverityInfo,
+			ErrorAssertion: assert.NoError,
+		},
+		"upper case warning": {
+			Input:          "WARNING",
+			Expected:       SeverityWarning,
+			ErrorAssertion: assert.NoError,
+		},
+		"error": {
+			Input:          "error",
+			Expected:       SeverityError,
+			ErrorAssertion: assert.NoError,
+		},
+		"unknown": {
+			Input:          "fatal",
+			Expected:       SeverityError,
+			ErrorAssertion: assert.Error,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			sev, err := ParseSeverity(tc.Input)
+			tc.ErrorAssertion(t, err)
+
+			assert.Equal(t, tc.Expected, sev)
+		})
+	}
+}
+
+func TestResultListHasFailureAtOrAbove(t *testing.T) {
+	t.Parallel()
+
+	base, err := NewBase(Code(1))
+	require.NoError(t, err)
+
+	warnBase, err := NewBase(Code(2), BaseSeverity(SeverityWarning))
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		Results   ResultList
+		Threshold Severity
+		Expected  bool
+	}{
+		"success": {
+			Results:   ResultList{base.Success()},
+			Threshold: SeverityWarning,
+			Expected:  false,
+		},
+		"error severity failure": {
+			Results:   ResultList{base.Fail("failed")},
+			Threshold: SeverityError,
+			Expected:  true,
+		},
+		"warning severity failure below threshold": {
+			Results:   ResultList{warnBase.Fail("failed")},
+			Threshold: SeverityError,
+			Expected:  false,
+		},
+		"warning severity failure at threshold": {
+			Results:   ResultList{warnBase.Fail("failed")},
+			Threshold: SeverityWarning,
+			Expected:  true,
+		},
+		"overridden severity failure": {
+			Results:   ResultList{base.Fail("failed").WithSeverity(SeverityInfo)},
+			Threshold: SeverityWarning,
+			Expected:  false,
+		},
+		"errors ignore severity": {
+			Results:   ResultList{warnBase.Error(errors.New("boom"))},
+			Threshold: SeverityError,
+			Expected:  true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.Expected, tc.Results.HasFailureAtOrAbove(tc.Threshold))
+		})
+	}
+}
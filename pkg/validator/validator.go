@@ -17,6 +17,9 @@ type Validator interface {
 	Name() string
 	// Description returns the displayed description of a Validator instance.
 	Description() string
+	// Severity returns the default Severity of failures reported
+	// by a Validator instance.
+	Severity() Severity
 	// Run executes validation tasks against a types.MetaBundle and returns the
 	// result of that task. A context.Context instance is also passed to allow
 	// for cancellation and timeouts to propogate through the validation task
@@ -31,7 +34,10 @@ func NewBase(code Code, opts ...BaseOption) (*Base, error) {
 		return nil, fmt.Errorf("validator codes must be non-negative integers not %d", code)
 	}
 
-	cfg := Base{code: code}
+	cfg := Base{
+		code:     code,
+		severity: SeverityError,
+	}
 
 	cfg.Option(opts...)
 	cfg.Default()
@@ -41,14 +47,32 @@ func NewBase(code Code, opts ...BaseOption) (*Base, error) {
 
 // Base implements the base functionality used by Validator instances.
 type Base struct {
-	code Code
-	name string
-	desc string
+	code          Code
+	name          string
+	desc          string
+	severity      Severity
+	requires      []Requirement
+	prerequisites []Code
+	version       int
 }
 
 func (b *Base) Code() Code          { return b.code }
 func (b *Base) Name() string        { return b.name }
 func (b *Base) Description() string { return b.desc }
+func (b *Base) Severity() Severity  { return b.severity }
+
+// Requires returns the inputs beyond the addon metadata
+// which a Validator instance depends on.
+func (b *Base) Requires() []Requirement { return b.requires }
+
+// Prerequisites returns the codes of the Validators which must
+// succeed before a Validator instance is run.
+func (b *Base) Prerequisites() []Code { return b.prerequisites }
+
+// Version returns the revision of the checks performed by a
+// Validator instance. Results cached for other revisions are
+// not reused.
+func (b *Base) Version() int { return b.version }
 
 // Option applies a variadic slice of options to a Base instance.
 func (b *Base) Option(opts ...BaseOption) {
@@ -82,6 +106,18 @@ func (b *Base) Success() Result {
 func (b *Base) Fail(msgs ...string) Result {
 	res := b.populateResult()
 	res.FailureMsgs = msgs
+	res.Findings = findingsFromMessages(msgs)
+
+	return res
+}
+
+// FailWith is a helper which returns a populated Fail result.
+// Unlike Fail, each reason is a Finding which may point to the
+// addon metadata field that caused the validation task to fail.
+func (b *Base) FailWith(findings ...Finding) Result {
+	res := b.populateResult()
+	res.Findings = findings
+	res.FailureMsgs = messagesFromFindings(findings)
 
 	return res
 }
@@ -112,6 +148,7 @@ func (b *Base) populateResult() Result {
 		Code:        b.code,
 		Name:        b.name,
 		Description: b.desc,
+		Severity:    b.severity,
 	}
 }
 
@@ -129,6 +166,44 @@ func BaseDesc(desc string) BaseOption {
 	return func(b *Base) { b.desc = desc }
 }
 
+// BaseSeverity applies the given default severity to a base instance.
+func BaseSeverity(sev Severity) BaseOption {
+	return func(b *Base) { b.severity = sev }
+}
+
+// BaseRequires declares the inputs beyond the addon metadata
+// which a base instance depends on.
+func BaseRequires(reqs ...Requirement) BaseOption {
+	return func(b *Base) { b.requires = append(b.requires, reqs...) }
+}
+
+// BasePrerequisites declares the codes of the Validators which
+// must succeed before a base instance is run. The Runner skips
+// the instance if any of them fails, errors or is skipped.
+func BasePrerequisites(codes ...Code) BaseOption {
+	return func(b *Base) { b.prerequisites = append(b.prerequisites, codes...) }
+}
+
+// BaseVersion applies the given revision to a base instance. It must
+// be increased whenever the checks of the Validator change so that
+// results cached by earlier revisions are invalidated.
+func BaseVersion(version int) BaseOption {
+	return func(b *Base) { b.version = version }
+}
+
+// Requirement is an input beyond the addon metadata
+// which a Validator depends on.
+type Requirement string
+
+const (
+	// RequiresBundles marks Validators which inspect the
+	// bundles extracted from the index image of an addon.
+	RequiresBundles Requirement = "bundles"
+	// RequiresNetwork marks Validators which call external
+	// services such as OCM or Quay.
+	RequiresNetwork Requirement = "network"
+)
+
 // ValidatorList is a sortable slice of Validators.
 type ValidatorList []Validator
 
@@ -141,10 +216,48 @@ const codePrefix = "AM"
 // Code is a prefixed integer ID used to distinguish Validator implementations.
 type Code int
 
+// String returns the prefixed 'AMXXXX' form of the code. The zero
+// Code identifies no Validator and is rendered as an empty string.
 func (c Code) String() string {
+	if c == 0 {
+		return ""
+	}
+
 	return fmt.Sprintf("%s%04d", codePrefix, c)
 }
 
+// MarshalText implements encoding.TextMarshaler so that codes
+// are serialized in their prefixed 'AMXXXX' form.
+func (c Code) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and accepts
+// codes in the prefixed 'AMXXXX' form.
+func (c *Code) UnmarshalText(text []byte) error {
+	code, err := ParseCode(string(text))
+	if err != nil {
+		return err
+	}
+
+	*c = code
+
+	return nil
+}
+
+const wikiURL = "https://github.com/mt-sre/addon-metadata-operator/wiki"
+
+// WikiURL returns the location of the wiki page documenting
+// the Validator identified by the code. The zero Code has no
+// wiki page and an empty string is returned.
+func (c Code) WikiURL() string {
+	if c == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s", wikiURL, c)
+}
+
 // ParseCode converts a given string to a Code value.
 // An error is returned if the string is incorrectly formatted.
 func ParseCode(maybeCode string) (Code, error) {